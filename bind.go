@@ -0,0 +1,187 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// BindStruct registers flags using GNU conventions for the tagged fields
+// of the struct pointed to by sp, using the field values as defaults.
+//
+// The tag format is `vflag:"SHORT,LONG,desc=DESCRIPTION"` where SHORT is a
+// single character or empty, LONG is the long name or empty, and the optional
+// DESCRIPTION extends to the end of the tag and may contain commas. For example:
+//
+//	type config struct {
+//		Output  string   `vflag:"o,output,desc=Write output to FILE."`
+//		Headers []string `vflag:"H,header,desc=Add the given header."`
+//		Verbose bool     `vflag:",verbose,desc=Enable verbose output."`
+//	}
+//
+// Fields without a tag or tagged with `vflag:"-"` are ignored, except that
+// untagged struct fields are walked recursively. The supported field types are
+// the ones supported by the [*FlagSet] methods such as [*FlagSet.BoolVar],
+// [*FlagSet.DurationVar], [*FlagSet.StringVar], and [*FlagSet.StringSliceVar],
+// as well as the [Optional] types supported by methods such as [*FlagSet.OptionalIntVar].
+//
+// This method panics if sp is not a pointer to struct, if a tag is malformed,
+// or if a tagged field has an unsupported type.
+func (fs *FlagSet) BindStruct(sp any) {
+	rv := reflect.ValueOf(sp)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("vflag: BindStruct: expected pointer to struct, got %T", sp))
+	}
+	fs.bindStruct(rv.Elem())
+}
+
+func (fs *FlagSet) bindStruct(rv reflect.Value) {
+	rt := rv.Type()
+	for idx := 0; idx < rt.NumField(); idx++ {
+		field, value := rt.Field(idx), rv.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, found := field.Tag.Lookup("vflag")
+		if !found && field.Type.Kind() == reflect.Struct {
+			fs.bindStruct(value)
+			continue
+		}
+		if !found || tag == "-" {
+			continue
+		}
+
+		shortName, longName, helpText, err := parseBindStructTag(tag)
+		if err != nil {
+			panic(fmt.Errorf("vflag: BindStruct: field %s: %w", field.Name, err))
+		}
+		if !fs.bindField(value.Addr().Interface(), shortName, longName, helpText) {
+			panic(fmt.Errorf("vflag: BindStruct: field %s: unsupported type %s", field.Name, field.Type))
+		}
+	}
+}
+
+// parseBindStructTag parses a [*FlagSet.BindStruct] tag.
+func parseBindStructTag(tag string) (shortName rune, longName string, helpText []string, err error) {
+	if idx := strings.Index(tag, "desc="); idx >= 0 && (idx == 0 || tag[idx-1] == ',') {
+		helpText = []string{tag[idx+len("desc="):]}
+		tag = strings.TrimSuffix(tag[:idx], ",")
+	}
+
+	parts := strings.Split(tag, ",")
+	if len(parts) != 2 {
+		return 0, "", nil, fmt.Errorf("malformed tag: expected `SHORT,LONG[,desc=DESCRIPTION]`")
+	}
+
+	switch utf8.RuneCountInString(parts[0]) {
+	case 0:
+		// nothing
+	case 1:
+		shortName, _ = utf8.DecodeRuneInString(parts[0])
+	default:
+		return 0, "", nil, fmt.Errorf("short name must be a single character: %q", parts[0])
+	}
+
+	longName = parts[1]
+	if shortName == 0 && longName == "" {
+		return 0, "", nil, fmt.Errorf("malformed tag: both short and long names are empty")
+	}
+	return
+}
+
+// bindField registers the flags for the given field pointer and returns
+// false if the field pointer type is not supported.
+func (fs *FlagSet) bindField(fp any, shortName rune, longName string, helpText []string) bool {
+	switch vp := fp.(type) {
+	case *big.Float:
+		fs.BigFloatVar(vp, shortName, longName, helpText...)
+	case *big.Int:
+		fs.BigIntVar(vp, shortName, longName, helpText...)
+	case *bool:
+		fs.BoolVar(vp, shortName, longName, helpText...)
+	case **bool:
+		fs.BoolPtrVar(vp, shortName, longName, helpText...)
+	case *time.Duration:
+		fs.DurationVar(vp, shortName, longName, helpText...)
+	case *[]time.Duration:
+		fs.DurationSliceVar(vp, shortName, longName, helpText...)
+	case *os.FileMode:
+		fs.FileModeVar(vp, shortName, longName, helpText...)
+	case *float32:
+		fs.Float32Var(vp, shortName, longName, helpText...)
+	case *float64:
+		fs.Float64Var(vp, shortName, longName, helpText...)
+	case *[]float64:
+		fs.Float64SliceVar(vp, shortName, longName, helpText...)
+	case *int:
+		fs.IntVar(vp, shortName, longName, helpText...)
+	case *[]int:
+		fs.IntSliceVar(vp, shortName, longName, helpText...)
+	case *int8:
+		fs.Int8Var(vp, shortName, longName, helpText...)
+	case *int16:
+		fs.Int16Var(vp, shortName, longName, helpText...)
+	case *int32:
+		fs.Int32Var(vp, shortName, longName, helpText...)
+	case *int64:
+		fs.Int64Var(vp, shortName, longName, helpText...)
+	case *[]int64:
+		fs.Int64SliceVar(vp, shortName, longName, helpText...)
+	case *slog.Level:
+		fs.LogLevelVar(vp, shortName, longName, helpText...)
+	case *netip.Addr:
+		fs.NetipAddrVar(vp, shortName, longName, helpText...)
+	case *[]netip.Addr:
+		fs.IPSliceVar(vp, shortName, longName, helpText...)
+	case *SecretString:
+		fs.SecretStringVar(vp, shortName, longName, helpText...)
+	case *string:
+		fs.StringVar(vp, shortName, longName, helpText...)
+	case *[]string:
+		fs.StringSliceVar(vp, shortName, longName, helpText...)
+	case *map[string]string:
+		fs.StringToStringVar(vp, shortName, longName, helpText...)
+	case *uint:
+		fs.UintVar(vp, shortName, longName, helpText...)
+	case *[]uint:
+		fs.UintSliceVar(vp, shortName, longName, helpText...)
+	case *uint8:
+		fs.Uint8Var(vp, shortName, longName, helpText...)
+	case *uint16:
+		fs.Uint16Var(vp, shortName, longName, helpText...)
+	case *uint32:
+		fs.Uint32Var(vp, shortName, longName, helpText...)
+	case *uint64:
+		fs.Uint64Var(vp, shortName, longName, helpText...)
+	case *Optional[bool]:
+		fs.OptionalBoolVar(vp, shortName, longName, helpText...)
+	case *Optional[time.Duration]:
+		fs.OptionalDurationVar(vp, shortName, longName, helpText...)
+	case *Optional[float64]:
+		fs.OptionalFloat64Var(vp, shortName, longName, helpText...)
+	case *Optional[int]:
+		fs.OptionalIntVar(vp, shortName, longName, helpText...)
+	case *Optional[int64]:
+		fs.OptionalInt64Var(vp, shortName, longName, helpText...)
+	case *Optional[string]:
+		fs.OptionalStringVar(vp, shortName, longName, helpText...)
+	case *Optional[uint]:
+		fs.OptionalUintVar(vp, shortName, longName, helpText...)
+	case *Optional[uint64]:
+		fs.OptionalUint64Var(vp, shortName, longName, helpText...)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagSetBindStruct(t *testing.T) {
+	type network struct {
+		Timeout time.Duration `vflag:"t,timeout,desc=Set the timeout."`
+	}
+
+	type config struct {
+		Count    int64    `vflag:"n,,desc=Set count."`
+		Headers  []string `vflag:"H,header,desc=Add header, possibly more than once."`
+		Network  network
+		Output   string `vflag:"o,output"`
+		Ignored  string `vflag:"-"`
+		Untagged string
+		Verbose  bool `vflag:",verbose,desc=Enable verbose output."`
+		private  bool `vflag:"p,private"`
+	}
+
+	cfg := config{Output: "-"}
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.BindStruct(&cfg)
+
+	require.Len(t, fs.ShortFlags, 4)
+	require.Len(t, fs.LongFlags, 4)
+
+	assert.Equal(t, 'n', fs.ShortFlags[0].Name)
+	assert.Equal(t, []string{"Set count."}, fs.ShortFlags[0].Description)
+	assert.Equal(t, "header", fs.LongFlags[0].Name)
+	assert.Equal(t, []string{"Add header, possibly more than once."}, fs.LongFlags[0].Description)
+	assert.Equal(t, "timeout", fs.LongFlags[1].Name)
+	assert.Equal(t, "output", fs.LongFlags[2].Name)
+	assert.Empty(t, fs.LongFlags[2].Description)
+	assert.Equal(t, "-", fs.LongFlags[2].Value.String())
+	assert.Equal(t, "verbose", fs.LongFlags[3].Name)
+
+	err := fs.Parse([]string{"-n", "3", "-H", "A: 1", "--timeout", "5s", "-o", "out.txt", "--verbose"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cfg.Count)
+	assert.Equal(t, []string{"A: 1"}, cfg.Headers)
+	assert.Equal(t, 5*time.Second, cfg.Network.Timeout)
+	assert.Equal(t, "out.txt", cfg.Output)
+	assert.True(t, cfg.Verbose)
+}
+
+func TestFlagSetBindStructOptional(t *testing.T) {
+	var cfg struct {
+		Retries Optional[int]    `vflag:"r,retries"`
+		Timeout Optional[string] `vflag:",timeout"`
+	}
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.BindStruct(&cfg)
+
+	require.NoError(t, fs.Parse([]string{"-r", "0"}))
+	assert.True(t, cfg.Retries.IsSet())
+	assert.False(t, cfg.Timeout.IsSet())
+}
+
+func TestFlagSetBindStructPanics(t *testing.T) {
+	t.Run("not a pointer", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		assert.Panics(t, func() {
+			fs.BindStruct(struct{}{})
+		})
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value int
+		assert.Panics(t, func() {
+			fs.BindStruct(&value)
+		})
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var cfg struct {
+			Value complex128 `vflag:"c,complex"`
+		}
+		assert.PanicsWithError(t, "vflag: BindStruct: field Value: unsupported type complex128", func() {
+			fs.BindStruct(&cfg)
+		})
+	})
+
+	t.Run("malformed tag", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var cfg struct {
+			Value string `vflag:"value"`
+		}
+		assert.Panics(t, func() {
+			fs.BindStruct(&cfg)
+		})
+	})
+}
+
+func TestParseBindStructTag(t *testing.T) {
+	cases := []struct {
+		tag       string
+		wantShort rune
+		wantLong  string
+		wantHelp  []string
+		wantErr   bool
+	}{
+		{tag: "o,output", wantShort: 'o', wantLong: "output"},
+		{tag: "o,", wantShort: 'o'},
+		{tag: "é,", wantShort: 'é'},
+		{tag: ",output", wantLong: "output"},
+		{tag: "o,output,desc=Write to FILE, or stdout.", wantShort: 'o', wantLong: "output",
+			wantHelp: []string{"Write to FILE, or stdout."}},
+		{tag: ",output,desc=", wantLong: "output", wantHelp: []string{""}},
+		{tag: ",", wantErr: true},
+		{tag: "output", wantErr: true},
+		{tag: "oo,output", wantErr: true},
+		{tag: "o,output,extra", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tag, func(t *testing.T) {
+			shortName, longName, helpText, err := parseBindStructTag(tc.tag)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantShort, shortName)
+			assert.Equal(t, tc.wantLong, longName)
+			assert.Equal(t, tc.wantHelp, helpText)
+		})
+	}
+}
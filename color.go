@@ -0,0 +1,91 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ANSI escape sequences used by [usageStyle].
+const (
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiUnderline = "\x1b[4m"
+	ansiReset     = "\x1b[0m"
+)
+
+// isTerminal returns whether w is a terminal.
+//
+// This is a variable such that tests can override it.
+var isTerminal = func(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := file.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// colorEnabled returns whether to emit colors when writing to w given
+// the user preference, the `NO_COLOR` environment variable, and whether w
+// is a terminal. See https://no-color.org/ for more information.
+func colorEnabled(color bool, w io.Writer) bool {
+	return color && os.Getenv("NO_COLOR") == "" && isTerminal(w)
+}
+
+// usageStyle styles the usage text using ANSI escape sequences when enabled.
+type usageStyle struct {
+	enabled bool
+}
+
+// heading underlines a heading (e.g., `Flags`).
+func (st usageStyle) heading(value string) string {
+	return st.wrap(ansiUnderline, value)
+}
+
+// synopsis renders the flag name in bold and dims the argument
+// placeholder (e.g., `-o FILE` or `--verbose[=true|false]`).
+func (st usageStyle) synopsis(value string) string {
+	name, placeholder := splitSynopsis(value)
+	return st.wrap(ansiBold, name) + st.wrap(ansiDim, placeholder)
+}
+
+// wrap surrounds a non-empty value with the given escape and the reset escape.
+func (st usageStyle) wrap(escape, value string) string {
+	if !st.enabled || value == "" {
+		return value
+	}
+	return escape + value + ansiReset
+}
+
+// splitSynopsis splits a flag synopsis into the flag name and the
+// argument placeholder (e.g., `-o` and ` FILE` for `-o FILE`).
+func splitSynopsis(value string) (name, placeholder string) {
+	name = value
+	if idx := strings.IndexAny(value, " ["); idx > 0 {
+		name, placeholder = value[:idx], value[idx:]
+	}
+	return
+}
+
+// Regular expressions matching the lightweight markup used by [usageStyle.markup].
+var (
+	markupCode     = regexp.MustCompile("`([^`]+)`")
+	markupEmphasis = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+)
+
+// markup renders the `code` spans in bold and the *emphasis* underlined
+// when enabled and otherwise strips the markup characters.
+func (st usageStyle) markup(value string) string {
+	value = markupCode.ReplaceAllStringFunc(value, func(match string) string {
+		return st.wrap(ansiBold, match[1:len(match)-1])
+	})
+	return markupEmphasis.ReplaceAllStringFunc(value, func(match string) string {
+		return st.wrap(ansiUnderline, match[1:len(match)-1])
+	})
+}
@@ -0,0 +1,148 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Command is a node in a tree of commands (e.g., `git remote add`).
+//
+// Each [*Command] owns a [*FlagSet] parsing the flags that appear between
+// its name and the name of the selected subcommand, if any. When a command
+// has subcommands, the first positional argument selects the subcommand
+// and the remaining arguments are parsed by the subcommand.
+//
+// Construct using [NewCommand].
+type Command struct {
+	// Commands contains the subcommands.
+	//
+	// [NewCommand] initializes this field to an empty slice.
+	//
+	// Use [*Command.AddCommand] to add subcommands.
+	Commands []*Command
+
+	// FlagSet is the [*FlagSet] parsing this command flags.
+	//
+	// [NewCommand] initializes this field using [NewFlagSet] with the
+	// command name as the program name and a [*DefaultUsagePrinter].
+	//
+	// [*Command.Execute] rewrites the ProgramName of subcommands to be the
+	// full invocation path (e.g., `git remote add`) before parsing.
+	FlagSet *FlagSet
+
+	// Name is the command name.
+	//
+	// [NewCommand] initializes this field to the given name.
+	Name string
+
+	// Run is the function invoked with the positional arguments once
+	// parsing has succeeded and no subcommand has been selected.
+	//
+	// [NewCommand] initializes this field to nil, meaning that the
+	// command cannot be invoked without selecting a subcommand.
+	Run func(args []string) error
+
+	// Summary is a one-line description of the command used when
+	// listing the command in the parent's help.
+	//
+	// [NewCommand] initializes this field to "".
+	Summary string
+}
+
+// NewCommand returns a new [*Command] instance with the given name and
+// using the given [ErrorHandling] policy for its [*FlagSet].
+func NewCommand(name string, handling ErrorHandling) *Command {
+	fset := NewFlagSet(name, handling)
+	fset.UsagePrinter = NewDefaultUsagePrinter()
+	return &Command{
+		Commands: []*Command{},
+		FlagSet:  fset,
+		Name:     name,
+		Run:      nil,
+		Summary:  "",
+	}
+}
+
+// AddCommand appends a subcommand to the [*Command.Commands] slice.
+//
+// This method configures the [*FlagSet] to stop parsing at the first positional
+// argument, which selects the subcommand, and to accept any number of positional
+// arguments. If the [*FlagSet] uses a [*DefaultUsagePrinter] or a [*MarkdownUsagePrinter],
+// this method also lists the subcommand in the help and, if unset, sets the positional
+// arguments usage to `COMMAND [args ...]`.
+func (c *Command) AddCommand(cmd *Command) {
+	c.Commands = append(c.Commands, cmd)
+	c.FlagSet.DisablePermute = true
+	c.FlagSet.SetMinMaxPositionalArgs(0, math.MaxInt)
+	switch up := c.FlagSet.UsagePrinter.(type) {
+	case *DefaultUsagePrinter:
+		up.Commands = append(up.Commands, cmd)
+		if up.PositionalArgumentsUsage == "" {
+			up.PositionalArgumentsUsage = "COMMAND [args ...]"
+		}
+	case *MarkdownUsagePrinter:
+		up.Commands = append(up.Commands, cmd)
+		if up.PositionalArgumentsUsage == "" {
+			up.PositionalArgumentsUsage = "COMMAND [args ...]"
+		}
+	}
+}
+
+// FindCommand returns the subcommand with the given name, if any.
+func (c *Command) FindCommand(name string) (*Command, bool) {
+	for _, cmd := range c.Commands {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return nil, false
+}
+
+// ErrMissingCommand indicates that a command requiring a subcommand has
+// been invoked without selecting any subcommand.
+var ErrMissingCommand = errors.New("missing command")
+
+// ErrUnknownCommand indicates that the selected subcommand does not exist.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Execute parses the given arguments and dispatches to the selected subcommand
+// or, when there are no subcommands to select, invokes [*Command.Run].
+//
+// The args MUST NOT contain the program name, exactly like [*FlagSet.Parse].
+//
+// Parsing errors, as well as [ErrMissingCommand] and [ErrUnknownCommand], are
+// handled according to the [ErrorHandling] policy of the [*FlagSet] of the
+// command where they occurred. Errors returned by Run are returned as is.
+func (c *Command) Execute(args []string) error {
+	fset := c.FlagSet
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	positionals := fset.Args()
+
+	if len(c.Commands) <= 0 {
+		if c.Run == nil {
+			return nil
+		}
+		return c.Run(positionals)
+	}
+
+	if len(positionals) <= 0 {
+		if c.Run != nil {
+			return c.Run(positionals)
+		}
+		return fset.maybeHandleError(ErrMissingCommand)
+	}
+
+	cmd, found := c.FindCommand(positionals[0])
+	if !found {
+		return fset.maybeHandleError(fmt.Errorf("%w: %s", ErrUnknownCommand, positionals[0]))
+	}
+	cmd.FlagSet.ProgramName = fset.ProgramName + " " + cmd.Name
+	return cmd.Execute(positionals[1:])
+}
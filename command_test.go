@@ -0,0 +1,189 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand("git", ContinueOnError)
+
+	assert.Equal(t, "git", cmd.Name)
+	assert.Empty(t, cmd.Commands)
+	assert.Nil(t, cmd.Run)
+	require.NotNil(t, cmd.FlagSet)
+	assert.Equal(t, "git", cmd.FlagSet.ProgramName)
+	assert.Equal(t, ContinueOnError, cmd.FlagSet.ErrorHandling)
+	_, ok := cmd.FlagSet.UsagePrinter.(*DefaultUsagePrinter)
+	assert.True(t, ok)
+}
+
+func TestCommandAddCommand(t *testing.T) {
+	root := NewCommand("git", ContinueOnError)
+	remote := NewCommand("remote", ContinueOnError)
+	root.AddCommand(remote)
+
+	require.Len(t, root.Commands, 1)
+	assert.True(t, root.FlagSet.DisablePermute)
+	assert.Equal(t, 0, root.FlagSet.MinPositionalArgs)
+
+	up := root.FlagSet.UsagePrinter.(*DefaultUsagePrinter)
+	assert.Equal(t, []*Command{remote}, up.Commands)
+	assert.Equal(t, "COMMAND [args ...]", up.PositionalArgumentsUsage)
+
+	found, ok := root.FindCommand("remote")
+	assert.True(t, ok)
+	assert.Same(t, remote, found)
+
+	_, ok = root.FindCommand("nonexistent")
+	assert.False(t, ok)
+}
+
+// newTestCommandTree returns a `git remote add` command tree.
+func newTestCommandTree(gotArgs *[]string, verbose *bool) *Command {
+	root := NewCommand("git", ContinueOnError)
+	root.FlagSet.BoolVar(verbose, 'v', "verbose", "Enable verbose output.")
+
+	remote := NewCommand("remote", ContinueOnError)
+	remote.Summary = "Manage the set of tracked repositories."
+	root.AddCommand(remote)
+
+	add := NewCommand("add", ContinueOnError)
+	add.Summary = "Add a remote."
+	add.FlagSet.SetMinMaxPositionalArgs(2, 2)
+	add.Run = func(args []string) error {
+		*gotArgs = args
+		return nil
+	}
+	remote.AddCommand(add)
+	return root
+}
+
+func TestCommandExecute(t *testing.T) {
+	t.Run("dispatches to nested subcommands", func(t *testing.T) {
+		var (
+			gotArgs []string
+			verbose bool
+		)
+		root := newTestCommandTree(&gotArgs, &verbose)
+
+		err := root.Execute([]string{"-v", "remote", "add", "origin", "https://example.com/"})
+		require.NoError(t, err)
+		assert.True(t, verbose)
+		assert.Equal(t, []string{"origin", "https://example.com/"}, gotArgs)
+
+		add, _ := root.Commands[0].FindCommand("add")
+		assert.Equal(t, "git remote add", add.FlagSet.ProgramName)
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		var (
+			gotArgs []string
+			verbose bool
+		)
+		root := newTestCommandTree(&gotArgs, &verbose)
+
+		err := root.Execute([]string{})
+		assert.ErrorIs(t, err, ErrMissingCommand)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		var (
+			gotArgs []string
+			verbose bool
+		)
+		root := newTestCommandTree(&gotArgs, &verbose)
+
+		err := root.Execute([]string{"commit"})
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+		assert.Equal(t, "unknown command: commit", err.Error())
+	})
+
+	t.Run("subcommand parse error", func(t *testing.T) {
+		var (
+			gotArgs []string
+			verbose bool
+		)
+		root := newTestCommandTree(&gotArgs, &verbose)
+
+		err := root.Execute([]string{"remote", "add", "origin"})
+		assert.Error(t, err)
+		assert.Nil(t, gotArgs)
+	})
+
+	t.Run("run without subcommands", func(t *testing.T) {
+		cmd := NewCommand("echo", ContinueOnError)
+		cmd.FlagSet.SetMinMaxPositionalArgs(0, 8)
+		expect := errors.New("mocked error")
+		var gotArgs []string
+		cmd.Run = func(args []string) error {
+			gotArgs = args
+			return expect
+		}
+
+		err := cmd.Execute([]string{"a", "b"})
+		assert.ErrorIs(t, err, expect)
+		assert.Equal(t, []string{"a", "b"}, gotArgs)
+	})
+
+	t.Run("run with subcommands but none selected", func(t *testing.T) {
+		var (
+			gotArgs []string
+			verbose bool
+			called  bool
+		)
+		root := newTestCommandTree(&gotArgs, &verbose)
+		root.Run = func(args []string) error {
+			called = true
+			return nil
+		}
+
+		require.NoError(t, root.Execute([]string{"-v"}))
+		assert.True(t, called)
+	})
+
+	t.Run("ExitOnError with unknown command", func(t *testing.T) {
+		root := NewCommand("git", ExitOnError)
+		root.FlagSet.AutoHelp('h', "help", "Show help and exit.")
+		root.AddCommand(NewCommand("remote", ExitOnError))
+		var (
+			stderr bytes.Buffer
+			status int
+		)
+		root.FlagSet.Stderr = &stderr
+		root.FlagSet.Exit = func(code int) {
+			status = code
+			panic("mocked exit")
+		}
+
+		assert.Panics(t, func() {
+			root.Execute([]string{"commit"})
+		})
+		assert.Equal(t, 2, status)
+		assert.Equal(t, "git: unknown command: commit\ngit: try `git --help' for more help.\n", stderr.String())
+	})
+}
+
+func TestCommandUsage(t *testing.T) {
+	var (
+		gotArgs []string
+		verbose bool
+	)
+	root := newTestCommandTree(&gotArgs, &verbose)
+
+	var buf bytes.Buffer
+	root.Commands[0].FlagSet.ProgramName = "git remote"
+	root.Commands[0].FlagSet.PrintUsageString(&buf)
+
+	expect := "\nUsage\n\n    git remote COMMAND [args ...]\n" +
+		"\nCommands\n\n    add\n\n        Add a remote.\n\n"
+	assert.Equal(t, expect, buf.String())
+}
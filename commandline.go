@@ -0,0 +1,173 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CommandLine is the default [*FlagSet] used by the package-level functions,
+// such as [BoolVar] and [Parse], which ease replacing the stdlib [flag] package
+// in small programs. For example:
+//
+//	var verbose bool
+//	vflag.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+//	vflag.Parse()
+//	for _, arg := range vflag.Args() {
+//		// ...
+//	}
+//
+// CommandLine uses the base name of os.Args[0] as the ProgramName, the
+// [ExitOnError] policy, recognizes `-h` and `--help` using [*FlagSet.AutoHelp],
+// and accepts any number of positional arguments.
+var CommandLine = newCommandLine()
+
+// newCommandLine constructs the [CommandLine] [*FlagSet].
+func newCommandLine() *FlagSet {
+	var progname string
+	if len(os.Args) > 0 {
+		progname = filepath.Base(os.Args[0])
+	}
+	fs := NewFlagSet(progname, ExitOnError)
+	fs.AutoHelp('h', "help", "Show this help message and exit.")
+	fs.SetMinMaxPositionalArgs(0, math.MaxInt)
+	return fs
+}
+
+// Parse parses os.Args[1:] using [CommandLine].
+//
+// Since [CommandLine] uses the [ExitOnError] policy, this function
+// exits on error, unless the policy has been changed.
+func Parse() error {
+	return CommandLine.ParseOSArgs()
+}
+
+// Arg invokes [*FlagSet.Arg] on [CommandLine].
+func Arg(i int) string {
+	return CommandLine.Arg(i)
+}
+
+// Args invokes [*FlagSet.Args] on [CommandLine].
+func Args() []string {
+	return CommandLine.Args()
+}
+
+// Changed invokes [*FlagSet.Changed] on [CommandLine].
+func Changed(name string) bool {
+	return CommandLine.Changed(name)
+}
+
+// NArg invokes [*FlagSet.NArg] on [CommandLine].
+func NArg() int {
+	return CommandLine.NArg()
+}
+
+// NFlag invokes [*FlagSet.NFlag] on [CommandLine].
+func NFlag() int {
+	return CommandLine.NFlag()
+}
+
+// PrintDefaults invokes [*FlagSet.PrintDefaults] on [CommandLine].
+func PrintDefaults() {
+	CommandLine.PrintDefaults()
+}
+
+// Bool invokes [*FlagSet.Bool] on [CommandLine].
+func Bool(shortName rune, longName string, value bool, helpText ...string) *bool {
+	return CommandLine.Bool(shortName, longName, value, helpText...)
+}
+
+// BoolFunc invokes [*FlagSet.BoolFunc] on [CommandLine].
+func BoolFunc(shortName rune, longName string, helpText string, fn func(string) error) {
+	CommandLine.BoolFunc(shortName, longName, helpText, fn)
+}
+
+// BoolVar invokes [*FlagSet.BoolVar] on [CommandLine].
+func BoolVar(vp *bool, shortName rune, longName string, helpText ...string) {
+	CommandLine.BoolVar(vp, shortName, longName, helpText...)
+}
+
+// Duration invokes [*FlagSet.Duration] on [CommandLine].
+func Duration(shortName rune, longName string, value time.Duration, helpText ...string) *time.Duration {
+	return CommandLine.Duration(shortName, longName, value, helpText...)
+}
+
+// DurationVar invokes [*FlagSet.DurationVar] on [CommandLine].
+func DurationVar(vp *time.Duration, shortName rune, longName string, helpText ...string) {
+	CommandLine.DurationVar(vp, shortName, longName, helpText...)
+}
+
+// Float64 invokes [*FlagSet.Float64] on [CommandLine].
+func Float64(shortName rune, longName string, value float64, helpText ...string) *float64 {
+	return CommandLine.Float64(shortName, longName, value, helpText...)
+}
+
+// Float64Var invokes [*FlagSet.Float64Var] on [CommandLine].
+func Float64Var(vp *float64, shortName rune, longName string, helpText ...string) {
+	CommandLine.Float64Var(vp, shortName, longName, helpText...)
+}
+
+// Func invokes [*FlagSet.Func] on [CommandLine].
+func Func(shortName rune, longName string, helpText string, fn func(string) error) {
+	CommandLine.Func(shortName, longName, helpText, fn)
+}
+
+// Int invokes [*FlagSet.Int] on [CommandLine].
+func Int(shortName rune, longName string, value int, helpText ...string) *int {
+	return CommandLine.Int(shortName, longName, value, helpText...)
+}
+
+// IntVar invokes [*FlagSet.IntVar] on [CommandLine].
+func IntVar(vp *int, shortName rune, longName string, helpText ...string) {
+	CommandLine.IntVar(vp, shortName, longName, helpText...)
+}
+
+// Int64 invokes [*FlagSet.Int64] on [CommandLine].
+func Int64(shortName rune, longName string, value int64, helpText ...string) *int64 {
+	return CommandLine.Int64(shortName, longName, value, helpText...)
+}
+
+// Int64Var invokes [*FlagSet.Int64Var] on [CommandLine].
+func Int64Var(vp *int64, shortName rune, longName string, helpText ...string) {
+	CommandLine.Int64Var(vp, shortName, longName, helpText...)
+}
+
+// String invokes [*FlagSet.String] on [CommandLine].
+func String(shortName rune, longName string, value string, helpText ...string) *string {
+	return CommandLine.String(shortName, longName, value, helpText...)
+}
+
+// StringSliceVar invokes [*FlagSet.StringSliceVar] on [CommandLine].
+func StringSliceVar(vp *[]string, shortName rune, longName string, helpText ...string) {
+	CommandLine.StringSliceVar(vp, shortName, longName, helpText...)
+}
+
+// StringVar invokes [*FlagSet.StringVar] on [CommandLine].
+func StringVar(vp *string, shortName rune, longName string, helpText ...string) *StringFlag {
+	return CommandLine.StringVar(vp, shortName, longName, helpText...)
+}
+
+// Uint invokes [*FlagSet.Uint] on [CommandLine].
+func Uint(shortName rune, longName string, value uint, helpText ...string) *uint {
+	return CommandLine.Uint(shortName, longName, value, helpText...)
+}
+
+// UintVar invokes [*FlagSet.UintVar] on [CommandLine].
+func UintVar(vp *uint, shortName rune, longName string, helpText ...string) {
+	CommandLine.UintVar(vp, shortName, longName, helpText...)
+}
+
+// Uint64 invokes [*FlagSet.Uint64] on [CommandLine].
+func Uint64(shortName rune, longName string, value uint64, helpText ...string) *uint64 {
+	return CommandLine.Uint64(shortName, longName, value, helpText...)
+}
+
+// Uint64Var invokes [*FlagSet.Uint64Var] on [CommandLine].
+func Uint64Var(vp *uint64, shortName rune, longName string, helpText ...string) {
+	CommandLine.Uint64Var(vp, shortName, longName, helpText...)
+}
@@ -0,0 +1,126 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withCommandLine replaces [CommandLine] and os.Args for the duration of the test.
+func withCommandLine(t *testing.T, args ...string) {
+	savedArgs, savedCommandLine := os.Args, CommandLine
+	t.Cleanup(func() {
+		os.Args, CommandLine = savedArgs, savedCommandLine
+	})
+	os.Args = args
+	CommandLine = newCommandLine()
+	CommandLine.ErrorHandling = ContinueOnError
+}
+
+func TestNewCommandLine(t *testing.T) {
+	withCommandLine(t, "/usr/local/bin/prog")
+	assert.Equal(t, "prog", CommandLine.ProgramName)
+	assert.Equal(t, math.MaxInt, CommandLine.MaxPositionalArgs)
+	assert.Equal(t, "prog --help", CommandLine.HelpInvocation())
+
+	withCommandLine(t)
+	assert.Equal(t, "", CommandLine.ProgramName)
+	require.NoError(t, Parse())
+}
+
+func TestCommandLine(t *testing.T) {
+	withCommandLine(t, "prog", "-v", "--count", "3", "a", "-H", "A: 1", "b", "--output=out.txt",
+		"--ratio", "0.5", "-t", "1s", "--size", "7", "--workers", "2", "--limit", "9", "--trace",
+		"--level", "1", "--name", "x")
+
+	var (
+		headers []string
+		level   int
+		name    string
+		traced  bool
+		verbose bool
+	)
+	BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+	count := Int('n', "count", 1, "Set count.")
+	StringSliceVar(&headers, 'H', "header", "Add header.")
+	output := String('o', "output", "-", "Write output to file.")
+	ratio := Float64(0, "ratio", 0, "Set ratio.")
+	timeout := Duration('t', "timeout", 0, "Set timeout.")
+	size := Int64(0, "size", 0, "Set size.")
+	workers := Uint(0, "workers", 0, "Set workers.")
+	limit := Uint64(0, "limit", 0, "Set limit.")
+	BoolFunc(0, "trace", "Enable tracing.", func(string) error {
+		traced = true
+		return nil
+	})
+	IntVar(&level, 0, "level", "Set level.")
+	Func(0, "name", "Set name.", func(value string) error {
+		name = value
+		return nil
+	})
+	verbose2 := Bool(0, "verbose2", false, "Unused.")
+
+	require.NoError(t, Parse())
+
+	assert.True(t, verbose)
+	assert.False(t, *verbose2)
+	assert.Equal(t, 3, *count)
+	assert.Equal(t, []string{"A: 1"}, headers)
+	assert.Equal(t, "out.txt", *output)
+	assert.Equal(t, 0.5, *ratio)
+	assert.Equal(t, time.Second, *timeout)
+	assert.Equal(t, int64(7), *size)
+	assert.Equal(t, uint(2), *workers)
+	assert.Equal(t, uint64(9), *limit)
+	assert.True(t, traced)
+	assert.Equal(t, 1, level)
+	assert.Equal(t, "x", name)
+
+	assert.Equal(t, []string{"a", "b"}, Args())
+	assert.Equal(t, "a", Arg(0))
+	assert.Equal(t, 2, NArg())
+	assert.Equal(t, 12, NFlag())
+	assert.True(t, Changed("verbose"))
+	assert.False(t, Changed("verbose2"))
+
+	var buf strings.Builder
+	CommandLine.SetOutput(&buf)
+	PrintDefaults()
+	assert.Contains(t, buf.String(), "  -v, --verbose[=true|false]\n")
+}
+
+func TestCommandLineOtherVars(t *testing.T) {
+	withCommandLine(t, "prog", "--d", "2s", "--f", "1.5", "--i64", "-3", "--s", "x", "--u", "4", "--u64", "5")
+
+	var (
+		d   time.Duration
+		f   float64
+		i64 int64
+		s   string
+		u   uint
+		u64 uint64
+	)
+	DurationVar(&d, 0, "d")
+	Float64Var(&f, 0, "f")
+	Int64Var(&i64, 0, "i64")
+	StringVar(&s, 0, "s")
+	UintVar(&u, 0, "u")
+	Uint64Var(&u64, 0, "u64")
+
+	require.NoError(t, Parse())
+	assert.Equal(t, 2*time.Second, d)
+	assert.Equal(t, 1.5, f)
+	assert.Equal(t, int64(-3), i64)
+	assert.Equal(t, "x", s)
+	assert.Equal(t, uint(4), u)
+	assert.Equal(t, uint64(5), u64)
+}
@@ -0,0 +1,230 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"io"
+	"strings"
+
+	"github.com/bassosimone/must"
+)
+
+// CompactUsagePrinter is a [UsagePrinter] listing each flag on a single line
+// with aligned columns, like GNU tools do, rather than using the multi-paragraph
+// layout of the [*DefaultUsagePrinter]. For example:
+//
+//	Usage: curl [flags] URL
+//
+//	Transfer a URL using the HTTP/HTTPS protocol.
+//
+//	Flags:
+//	  -o, --output FILE  Write output to FILE. (default: -)
+//	      --retry INT    Retry the given number of times.
+//
+// Like [*FlagSet.PrintDefaults], we list flags with the same description,
+// and the long flags with their short form, together and append the default value to the description unless it is
+// a zero value or the description already contains it.
+//
+// Construct using [NewCompactUsagePrinter].
+type CompactUsagePrinter struct {
+	// Description contains the program description paragraphs.
+	//
+	// [NewCompactUsagePrinter] initializes this field to an empty slice.
+	//
+	// We strip the lightweight markup described in the [*DefaultUsagePrinter]
+	// field with the same name.
+	Description []string
+
+	// Example contains the examples paragraphs.
+	//
+	// [NewCompactUsagePrinter] initializes this field to an empty slice.
+	Example []string
+
+	// PositionalArgumentsUsage is the usage string for postional arguments.
+	//
+	// [NewCompactUsagePrinter] initializes this field to "". See the
+	// [*DefaultUsagePrinter] field with the same name for more information.
+	PositionalArgumentsUsage string
+}
+
+// NewCompactUsagePrinter constructs a new [*CompactUsagePrinter].
+func NewCompactUsagePrinter() *CompactUsagePrinter {
+	return &CompactUsagePrinter{}
+}
+
+var _ UsagePrinter = &CompactUsagePrinter{}
+
+// compactMaxSynopsis is the maximum width of the synopsis column. When a
+// synopsis is wider, the description starts on the following line.
+const compactMaxSynopsis = 30
+
+// compactFlag is a flag seen by [*CompactUsagePrinter.PrintUsageString].
+type compactFlag struct {
+	// short contains the short flags names including the prefix.
+	short []string
+
+	// shortUsage contains the short flags usage strings.
+	shortUsage []string
+
+	// long contains the long flags usage strings.
+	long []string
+
+	// description contains the single-line description.
+	description string
+
+	// group is the flag group.
+	group string
+}
+
+// synopsis returns the flag synopsis, where the short flags do not repeat
+// the argument name when there are long flags (e.g., `-o, --output FILE`).
+func (cf *compactFlag) synopsis() string {
+	switch {
+	case len(cf.long) <= 0:
+		return strings.Join(cf.shortUsage, ", ")
+	case len(cf.short) <= 0:
+		return "    " + strings.Join(cf.long, ", ")
+	default:
+		return strings.Join(append(append([]string{}, cf.short...), cf.long...), ", ")
+	}
+}
+
+// PrintUsageString implements [UsagePrinter].
+//
+// This method panics on I/O error.
+func (cp *CompactUsagePrinter) PrintUsageString(fset *FlagSet, w io.Writer) {
+	for _, entry := range fset.UsageHeader {
+		must.Fprintf(w, "%s\n\n", cp.paragraph(entry))
+	}
+
+	up := &DefaultUsagePrinter{PositionalArgumentsUsage: cp.PositionalArgumentsUsage}
+	must.Fprintf(w, "Usage: %s%s%s\n", fset.ProgramName, up.flagsName(fset), up.positionalArgumentsUsage(fset))
+
+	for _, entry := range cp.Description {
+		must.Fprintf(w, "\n%s\n", wrapText(usageStyle{}.markup(entry), wrapAtColumn, ""))
+	}
+
+	// Create the list of flags merging the ones with the same description
+	// and the long flags with their short form (see [LongFlag].ShortName)
+	var (
+		cflags []*compactFlag
+		index  = make(map[string]*compactFlag)
+		shorts = make(map[rune]*compactFlag)
+	)
+	lookup := func(docs flagDocs, linked *compactFlag) *compactFlag {
+		if linked != nil {
+			return linked
+		}
+		text := strings.Join(docs.description, " ")
+		if ref, ok := index[text]; ok && text != "" {
+			return ref
+		}
+		cflag := &compactFlag{description: defaultsDescription(docs), group: docs.group}
+		index[text] = cflag
+		cflags = append(cflags, cflag)
+		return cflag
+	}
+	for _, fx := range fset.ShortFlags {
+		cflag := lookup(fx.docs(), nil)
+		shorts[fx.Name] = cflag
+		cflag.short = append(cflag.short, fx.Prefix+string(fx.Name))
+		cflag.shortUsage = append(cflag.shortUsage, fx.Usage())
+		if fx.NegatedPrefix != "" {
+			cflag.short = append(cflag.short, fx.NegatedPrefix+string(fx.Name))
+			cflag.shortUsage = append(cflag.shortUsage, fx.NegatedPrefix+string(fx.Name))
+		}
+	}
+	for _, fx := range fset.LongFlags {
+		cflag := lookup(fx.docs(), shorts[fx.ShortName])
+		cflag.long = append(cflag.long, fx.Usage())
+		if !fx.HideAliases {
+			cflag.long = append(cflag.long, fx.AliasesUsage()...)
+		}
+		if fx.Negatable {
+			cflag.long = append(cflag.long, fx.Prefix+fx.NegatedName())
+		}
+		if bindings := fset.bindingsUsage(fx); !strings.Contains(cflag.description, bindings) {
+			cflag.description = strings.TrimSpace(cflag.description + bindings)
+		}
+	}
+
+	// Compute the width of the synopsis column
+	width := 0
+	for _, cflag := range cflags {
+		if size := len(cflag.synopsis()); size <= compactMaxSynopsis {
+			width = max(width, size)
+		}
+	}
+
+	// Print the flags under their group heading
+	groups := make([]string, 0, len(cflags))
+	for _, cflag := range cflags {
+		groups = append(groups, cflag.group)
+	}
+	for _, group := range orderFlagGroups(groups) {
+		heading := group
+		if heading == "" {
+			heading = "Flags"
+		}
+		must.Fprintf(w, "\n%s:\n", heading)
+		for _, cflag := range cflags {
+			if cflag.group == group {
+				cp.printFlag(w, cflag.synopsis(), cflag.description, width)
+			}
+		}
+	}
+
+	// Print the examples
+	if len(cp.Example) > 0 {
+		must.Fprintf(w, "\nExamples:\n")
+		for _, entry := range cp.Example {
+			must.Fprintf(w, "\n%s\n", wrapText(entry, wrapAtColumn, "  "))
+		}
+	}
+
+	for _, entry := range fset.UsageFooter {
+		must.Fprintf(w, "\n%s\n", cp.paragraph(entry))
+	}
+}
+
+// paragraph returns a word wrapped top-level paragraph, unless it starts with 4 spaces.
+func (cp *CompactUsagePrinter) paragraph(entry string) string {
+	if strings.HasPrefix(entry, indent4) {
+		return entry
+	}
+	return wrapText(entry, wrapAtColumn, "")
+}
+
+// printFlag prints a flag synopsis and its description aligned at the given width.
+func (cp *CompactUsagePrinter) printFlag(w io.Writer, synopsis, description string, width int) {
+	if description == "" {
+		must.Fprintf(w, "  %s\n", synopsis)
+		return
+	}
+	indent := strings.Repeat(" ", 2+width+2)
+	text := strings.TrimPrefix(wrapText(description, wrapAtColumn, indent), indent)
+	if len(synopsis) > width {
+		must.Fprintf(w, "  %s\n%s%s\n", synopsis, indent, text)
+		return
+	}
+	must.Fprintf(w, "  %-*s  %s\n", width, synopsis, text)
+}
+
+// PrintUsageError implements [UsagePrinter].
+//
+// This method panics on I/O error.
+func (cp *CompactUsagePrinter) PrintUsageError(fset *FlagSet, w io.Writer, err error) {
+	(&DefaultUsagePrinter{}).PrintUsageError(fset, w, err)
+}
+
+// AddDescription adds a paragraph to the current description.
+func (cp *CompactUsagePrinter) AddDescription(values ...string) {
+	cp.Description = append(cp.Description, values...)
+}
+
+// AddExamples adds a paragraph to the current examples.
+func (cp *CompactUsagePrinter) AddExamples(values ...string) {
+	cp.Example = append(cp.Example, values...)
+}
@@ -0,0 +1,66 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactUsagePrinter(t *testing.T) {
+	var (
+		output  = "-"
+		retry   int
+		silent  bool
+		logfile string
+	)
+	fs := NewFlagSet("curl", ContinueOnError)
+	fs.SetMinMaxPositionalArgs(1, 1)
+	fs.AutoHelp('h', "help", "Show this help message and exit.")
+	fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+	fs.BoolVar(&silent, 's', "", "Disable emitting output.")
+	fs.IntVar(&retry, 0, "retry", "Retry the given number of times.")
+	fs.Group("Logging options").StringVar(&logfile, 0, "log-file-with-a-very-long-name", "Write logs to FILE.")
+
+	cp := NewCompactUsagePrinter()
+	cp.PositionalArgumentsUsage = "URL"
+	cp.AddDescription("Transfer a URL using the HTTP/HTTPS protocol.")
+	cp.AddExamples("curl -o index.html https://www.example.com/")
+	fs.UsagePrinter = cp
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+
+	expect := strings.Join([]string{
+		"Usage: curl [flags] URL",
+		"",
+		"Transfer a URL using the HTTP/HTTPS protocol.",
+		"",
+		"Flags:",
+		"  -h, --help           Show this help message and exit.",
+		"  -o, --output STRING  Write output to FILE. (default: -)",
+		"  -s                   Disable emitting output.",
+		"      --retry INT      Retry the given number of times.",
+		"",
+		"Logging options:",
+		"      --log-file-with-a-very-long-name STRING",
+		"                       Write logs to FILE.",
+		"",
+		"Examples:",
+		"",
+		"  curl -o index.html https://www.example.com/",
+		"",
+	}, "\n")
+	assert.Equal(t, expect, sb.String())
+
+	t.Run("usage error", func(t *testing.T) {
+		var sb strings.Builder
+		fs.PrintUsageError(&sb, errors.New("unknown option: --verbose"))
+		assert.Equal(t, "curl: unknown option: --verbose\ncurl: try `curl --help' for more help.\n", sb.String())
+	})
+}
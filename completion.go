@@ -0,0 +1,431 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bassosimone/flagparser"
+	"github.com/bassosimone/must"
+)
+
+// Completion controls how the completion scripts complete a [*PositionalArgument].
+//
+// The zero value completes file names. Construct using [CompleteFiles],
+// [CompleteDirectories], [CompleteWords], or [CompleteNothing].
+type Completion struct {
+	kind  completionKind
+	words []string
+}
+
+// completionKind is the kind of [Completion].
+type completionKind int
+
+// These constants define the allowed [completionKind] values.
+const (
+	completionFiles = completionKind(iota)
+	completionDirectories
+	completionNothing
+	completionWords
+)
+
+// CompleteFiles returns a [Completion] completing file names.
+func CompleteFiles() Completion {
+	return Completion{kind: completionFiles}
+}
+
+// CompleteDirectories returns a [Completion] completing directory names.
+func CompleteDirectories() Completion {
+	return Completion{kind: completionDirectories}
+}
+
+// CompleteNothing returns a [Completion] that does not complete anything.
+func CompleteNothing() Completion {
+	return Completion{kind: completionNothing}
+}
+
+// CompleteWords returns a [Completion] completing the given words.
+func CompleteWords(words ...string) Completion {
+	return Completion{kind: completionWords, words: words}
+}
+
+// bashAction returns the bash code implementing the [Completion].
+func (c Completion) bashAction() string {
+	switch c.kind {
+	case completionDirectories:
+		return `COMPREPLY=($(compgen -d -- "$cur"))`
+	case completionNothing:
+		return "COMPREPLY=()"
+	case completionWords:
+		return fmt.Sprintf(`COMPREPLY=($(compgen -W %s -- "$cur"))`, bashQuote(strings.Join(c.words, " ")))
+	default:
+		return `COMPREPLY=($(compgen -f -- "$cur"))`
+	}
+}
+
+// zshAction returns the `_arguments` action implementing the [Completion].
+func (c Completion) zshAction() string {
+	switch c.kind {
+	case completionDirectories:
+		return "_files -/"
+	case completionNothing:
+		return " "
+	case completionWords:
+		words := make([]string, 0, len(c.words))
+		for _, word := range c.words {
+			words = append(words, strings.ReplaceAll(zshEscape(word), " ", `\ `))
+		}
+		return "(" + strings.Join(words, " ") + ")"
+	default:
+		return "_files"
+	}
+}
+
+// fishAction returns the `complete` options implementing the [Completion].
+func (c Completion) fishAction() string {
+	switch c.kind {
+	case completionDirectories:
+		return "-a '(__fish_complete_directories)'"
+	case completionNothing:
+		return ""
+	case completionWords:
+		return "-a " + fishQuote(strings.Join(c.words, " "))
+	default:
+		return "-F"
+	}
+}
+
+// completionSlot is a positional argument seen by the completion script generators.
+type completionSlot struct {
+	// completion is the [Completion] to use.
+	completion Completion
+
+	// index is the zero-based index of the positional argument
+	// or -1 for all the positional arguments from here on.
+	index int
+
+	// name is the positional argument name.
+	name string
+}
+
+// completionSlots returns the positional arguments seen by the completion script
+// generators. The Variadic argument covers all the following positional arguments.
+func (fs *FlagSet) completionSlots() []completionSlot {
+	output := make([]completionSlot, 0, len(fs.PositionalArguments)+1)
+	for idx, arg := range fs.PositionalArguments {
+		if arg.Variadic {
+			return append(output, completionSlot{arg.Completion, -1, arg.Name})
+		}
+		output = append(output, completionSlot{arg.Completion, idx, arg.Name})
+	}
+	if fs.MaxPositionalArgs > len(fs.PositionalArguments) {
+		output = append(output, completionSlot{CompleteFiles(), -1, "arg"})
+	}
+	return output
+}
+
+// completionFlag is a flag seen by the completion script generators.
+type completionFlag struct {
+	// argument is the argument name without decorations (e.g., `FILE`).
+	argument string
+
+	// description is the first description paragraph on a single line.
+	description string
+
+	// name is the flag name.
+	name string
+
+	// optype is the type of the [*flagparser.Option] built for the flag.
+	optype flagparser.OptionType
+
+	// prefix is the flag prefix.
+	prefix string
+
+	// short is true for short flags.
+	short bool
+}
+
+// requiresValue returns whether the flag requires a value.
+func (cf *completionFlag) requiresValue() bool {
+	return cf.optype == flagparser.OptionTypeGroupableArgumentRequired ||
+		cf.optype == flagparser.OptionTypeStandaloneArgumentRequired
+}
+
+// acceptsOptionalValue returns whether the flag accepts an optional `=value`.
+func (cf *completionFlag) acceptsOptionalValue() bool {
+	return cf.optype == flagparser.OptionTypeStandaloneArgumentOptional
+}
+
+// completionFlags returns the flags seen by the completion script generators.
+func (fs *FlagSet) completionFlags() []completionFlag {
+	output := make([]completionFlag, 0, len(fs.ShortFlags)+len(fs.LongFlags))
+	for _, fx := range fs.ShortFlags {
+		output = append(output, completionFlag{
+			argument:    completionArgument(fx.Description, fx.ArgumentName),
+			description: completionDescription(fx.docs()),
+			name:        string(fx.Name),
+			optype:      fx.MakeOption(fx).Type,
+			prefix:      fx.Prefix,
+			short:       true,
+		})
+	}
+	for _, fx := range fs.LongFlags {
+		for _, entry := range fx.makeOptions() {
+			output = append(output, completionFlag{
+				argument:    completionArgument(fx.Description, fx.ArgumentName),
+				description: completionDescription(fx.docs()),
+				name:        entry.option.Name,
+				optype:      entry.option.Type,
+				prefix:      fx.Prefix,
+				short:       false,
+			})
+		}
+	}
+	return output
+}
+
+// completionArgument returns the argument name without decorations.
+func completionArgument(description []string, defaultValue string) string {
+	output := argumentNameFromDocsOrDefault(description, defaultValue)
+	output = strings.TrimPrefix(output, " ")
+	output = strings.TrimPrefix(output, "[=")
+	output = strings.TrimSuffix(output, "]")
+	return output
+}
+
+// completionDescription returns the first description paragraph on a single line.
+func completionDescription(docs flagDocs) string {
+	if len(docs.description) <= 0 {
+		return ""
+	}
+	output := strings.ReplaceAll(docs.description[0], "@DEFAULT_VALUE@", docs.defaultValue())
+	return strings.Join(strings.Fields(output), " ")
+}
+
+// completionFuncNameRe matches the characters that cannot appear in a shell function name.
+var completionFuncNameRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// completionFuncName returns the name of the shell function implementing completion.
+func (fs *FlagSet) completionFuncName() string {
+	return "_" + completionFuncNameRe.ReplaceAllString(fs.ProgramName, "_") + "_completion"
+}
+
+// GenBashCompletion writes a bash completion script for the [*FlagSet] to
+// the given [io.Writer]. The script completes flag names and completes file
+// names for the values of flags requiring a value and for positional arguments.
+//
+// Load the script using `source <(program --completion-bash)` or by saving it
+// inside the bash-completion directory (e.g., `/etc/bash_completion.d`).
+//
+// This method panics if writing to the [io.Writer] fails.
+func (fs *FlagSet) GenBashCompletion(w io.Writer) {
+	flags := fs.completionFlags()
+	funcName := fs.completionFuncName()
+
+	must.Fprintf(w, "# bash completion for %s\n", fs.ProgramName)
+	must.Fprintf(w, "%s() {\n", funcName)
+	must.Fprintf(w, "    local cur prev\n")
+	must.Fprintf(w, "    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	must.Fprintf(w, "    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+
+	// Complete the value of the flags requiring a value
+	var valueFlags []string
+	for _, cf := range flags {
+		if cf.requiresValue() {
+			valueFlags = append(valueFlags, bashQuote(cf.prefix+cf.name))
+		}
+	}
+	if len(valueFlags) > 0 {
+		must.Fprintf(w, "    case \"$prev\" in\n")
+		must.Fprintf(w, "        %s)\n", strings.Join(valueFlags, "|"))
+		must.Fprintf(w, "            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+		must.Fprintf(w, "            return 0\n")
+		must.Fprintf(w, "            ;;\n")
+		must.Fprintf(w, "    esac\n")
+	}
+
+	// Complete the flag names
+	var patterns []string
+	if len(flags) > 0 {
+		var (
+			seen  = make(map[string]bool)
+			words []string
+		)
+		for _, cf := range flags {
+			words = append(words, cf.prefix+cf.name)
+			pattern := bashQuote(cf.prefix[:1]) + "*"
+			if !seen[pattern] {
+				seen[pattern] = true
+				patterns = append(patterns, pattern)
+			}
+		}
+		sort.Strings(patterns)
+		must.Fprintf(w, "    case \"$cur\" in\n")
+		must.Fprintf(w, "        %s)\n", strings.Join(patterns, "|"))
+		must.Fprintf(w, "            COMPREPLY=($(compgen -W %s -- \"$cur\"))\n", bashQuote(strings.Join(words, " ")))
+		must.Fprintf(w, "            return 0\n")
+		must.Fprintf(w, "            ;;\n")
+		must.Fprintf(w, "    esac\n")
+	}
+
+	// Complete the positional arguments
+	switch {
+	case len(fs.PositionalArguments) > 0:
+		fs.genBashPositionalCompletion(w, valueFlags, patterns)
+	case fs.MaxPositionalArgs > 0:
+		must.Fprintf(w, "    COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	}
+
+	must.Fprintf(w, "}\n")
+	must.Fprintf(w, "complete -F %s %s\n", funcName, bashQuote(fs.ProgramName))
+}
+
+// genBashPositionalCompletion writes the bash code completing the named positional
+// arguments, which counts the positional arguments preceding the current word,
+// skipping flags and the values of flags requiring a value.
+func (fs *FlagSet) genBashPositionalCompletion(w io.Writer, valueFlags, patterns []string) {
+	must.Fprintf(w, "    local i npos=0 skip=0\n")
+	must.Fprintf(w, "    for ((i=1; i<COMP_CWORD; i++)); do\n")
+	must.Fprintf(w, "        if ((skip)); then\n")
+	must.Fprintf(w, "            skip=0\n")
+	must.Fprintf(w, "            continue\n")
+	must.Fprintf(w, "        fi\n")
+	must.Fprintf(w, "        case \"${COMP_WORDS[i]}\" in\n")
+	if len(valueFlags) > 0 {
+		must.Fprintf(w, "            %s) skip=1 ;;\n", strings.Join(valueFlags, "|"))
+	}
+	if len(patterns) > 0 {
+		must.Fprintf(w, "            %s) ;;\n", strings.Join(patterns, "|"))
+	}
+	must.Fprintf(w, "            *) npos=$((npos+1)) ;;\n")
+	must.Fprintf(w, "        esac\n")
+	must.Fprintf(w, "    done\n")
+	must.Fprintf(w, "    case \"$npos\" in\n")
+	for _, slot := range fs.completionSlots() {
+		pattern := "*"
+		if slot.index >= 0 {
+			pattern = strconv.Itoa(slot.index)
+		}
+		must.Fprintf(w, "        %s) %s ;;\n", pattern, slot.completion.bashAction())
+	}
+	must.Fprintf(w, "    esac\n")
+}
+
+// bashQuote quotes a string for bash using single quotes.
+func bashQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// GenZshCompletion writes a zsh completion script for the [*FlagSet] to
+// the given [io.Writer]. The script uses `_arguments` to complete flag names,
+// showing their descriptions, and completes file names for the values of
+// flags requiring a value and for positional arguments.
+//
+// Load the script by saving it as `_program` inside a directory in `$fpath`.
+//
+// This method panics if writing to the [io.Writer] fails.
+func (fs *FlagSet) GenZshCompletion(w io.Writer) {
+	must.Fprintf(w, "#compdef %s\n\n", fs.ProgramName)
+	must.Fprintf(w, "_arguments -s")
+	for _, cf := range fs.completionFlags() {
+		var spec string
+		description := "[" + zshEscape(cf.description) + "]"
+		switch {
+		case cf.requiresValue() && cf.short:
+			spec = cf.prefix + cf.name + "+" + description + ":" + zshEscape(cf.argument) + ":_files"
+		case cf.requiresValue():
+			spec = cf.prefix + cf.name + "=" + description + ":" + zshEscape(cf.argument) + ":_files"
+		case cf.acceptsOptionalValue():
+			spec = cf.prefix + cf.name + "=-" + description + "::" + zshEscape(cf.argument) + ":"
+		default:
+			spec = cf.prefix + cf.name + description
+		}
+		must.Fprintf(w, " \\\n    %s", bashQuote(spec))
+	}
+	switch {
+	case len(fs.PositionalArguments) > 0:
+		for _, slot := range fs.completionSlots() {
+			position := "*"
+			if slot.index >= 0 {
+				position = strconv.Itoa(slot.index + 1)
+			}
+			spec := position + ":" + zshEscape(slot.name) + ":" + slot.completion.zshAction()
+			must.Fprintf(w, " \\\n    %s", bashQuote(spec))
+		}
+	case fs.MaxPositionalArgs > 0:
+		must.Fprintf(w, " \\\n    %s", bashQuote("*:arg:_files"))
+	}
+	must.Fprintf(w, "\n")
+}
+
+// zshEscape escapes the characters with special meaning inside `_arguments` specs.
+func zshEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(value)
+}
+
+// GenFishCompletion writes a fish completion script for the [*FlagSet] to
+// the given [io.Writer]. The script completes flag names, showing their
+// descriptions, and completes file names for flags requiring a value.
+//
+// Flags using prefixes other than `-` and `--` (e.g., dig-style `+short`)
+// are completed as arguments, since fish does not support custom prefixes.
+//
+// Load the script by saving it as `program.fish` inside `~/.config/fish/completions`.
+//
+// This method panics if writing to the [io.Writer] fails.
+func (fs *FlagSet) GenFishCompletion(w io.Writer) {
+	program := fishQuote(fs.ProgramName)
+	if fs.MaxPositionalArgs <= 0 || len(fs.PositionalArguments) > 0 {
+		must.Fprintf(w, "complete -c %s -f\n", program)
+	}
+	for _, cf := range fs.completionFlags() {
+		var option string
+		switch {
+		case cf.short && cf.prefix == "-":
+			option = "-s " + fishQuote(cf.name)
+		case !cf.short && cf.prefix == "--":
+			option = "-l " + fishQuote(cf.name)
+		case !cf.short && cf.prefix == "-":
+			option = "-o " + fishQuote(cf.name)
+		default:
+			option = "-a " + fishQuote(cf.prefix+cf.name)
+		}
+		if cf.requiresValue() {
+			option += " -r -F"
+		}
+		must.Fprintf(w, "complete -c %s %s -d %s\n", program, option, fishQuote(cf.description))
+	}
+
+	// Complete the named positional arguments
+	if len(fs.PositionalArguments) <= 0 {
+		return
+	}
+	var conditions []string
+	for _, slot := range fs.completionSlots() {
+		condition := strings.Join(conditions, "; and ")
+		if slot.index >= 0 {
+			nth := "__fish_is_nth_token " + strconv.Itoa(slot.index+1)
+			condition, conditions = nth, append(conditions, "not "+nth)
+		}
+		action := slot.completion.fishAction()
+		if action == "" {
+			continue
+		}
+		if condition != "" {
+			action = "-n " + fishQuote(condition) + " " + action
+		}
+		must.Fprintf(w, "complete -c %s %s\n", program, action)
+	}
+}
+
+// fishQuote quotes a string for fish using single quotes.
+func fishQuote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,210 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCompletionFlagSet returns a [*FlagSet] for testing completion.
+func newTestCompletionFlagSet() *FlagSet {
+	var (
+		https   string
+		output  string
+		verbose bool
+	)
+	fs := NewFlagSet("my-prog", ContinueOnError)
+	fs.SetMinMaxPositionalArgs(0, 1)
+	fs.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+	fs.StringVar(&output, 'o', "output", "Write to `FILE` (default: @DEFAULT_VALUE@).")
+	output = "-"
+	lf := NewLongFlagString(NewValueString(&https), "https", "Use DNS-over-HTTPS [RFC 8484].")
+	lf.MakeOption = LongFlagMakeOptionWithOptionalValue
+	lf.DefaultValue = "/dns-query"
+	fs.AddLongFlagDig(lf)
+	return fs
+}
+
+func TestFlagSetGenBashCompletion(t *testing.T) {
+	var buf bytes.Buffer
+	newTestCompletionFlagSet().GenBashCompletion(&buf)
+
+	expect := `# bash completion for my-prog
+_my_prog_completion() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "$prev" in
+        '-o'|'--output')
+            COMPREPLY=($(compgen -f -- "$cur"))
+            return 0
+            ;;
+    esac
+    case "$cur" in
+        '+'*|'-'*)
+            COMPREPLY=($(compgen -W '-v -o --verbose --output +https' -- "$cur"))
+            return 0
+            ;;
+    esac
+    COMPREPLY=($(compgen -f -- "$cur"))
+}
+complete -F _my_prog_completion 'my-prog'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetGenBashCompletionNoFlags(t *testing.T) {
+	var buf bytes.Buffer
+	NewFlagSet("prog", ContinueOnError).GenBashCompletion(&buf)
+
+	expect := `# bash completion for prog
+_prog_completion() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+}
+complete -F _prog_completion 'prog'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetGenZshCompletion(t *testing.T) {
+	var buf bytes.Buffer
+	newTestCompletionFlagSet().GenZshCompletion(&buf)
+
+	expect := `#compdef my-prog
+
+_arguments -s \
+    '-v[Enable verbose output.]' \
+    '-o+[Write to ` + "`FILE`" + ` (default\: -).]:FILE:_files' \
+    '--verbose=-[Enable verbose output.]::true|false:' \
+    '--output=[Write to ` + "`FILE`" + ` (default\: -).]:FILE:_files' \
+    '+https=-[Use DNS-over-HTTPS \[RFC 8484\].]::STRING:' \
+    '*:arg:_files'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetGenFishCompletion(t *testing.T) {
+	var buf bytes.Buffer
+	newTestCompletionFlagSet().GenFishCompletion(&buf)
+
+	expect := `complete -c 'my-prog' -s 'v' -d 'Enable verbose output.'
+complete -c 'my-prog' -s 'o' -r -F -d 'Write to ` + "`FILE`" + ` (default: -).'
+complete -c 'my-prog' -l 'verbose' -d 'Enable verbose output.'
+complete -c 'my-prog' -l 'output' -r -F -d 'Write to ` + "`FILE`" + ` (default: -).'
+complete -c 'my-prog' -a '+https' -d 'Use DNS-over-HTTPS [RFC 8484].'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetGenFishCompletionNoPositionals(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("prog", ContinueOnError)
+	var verbose bool
+	fs.BoolVar(&verbose, 0, "verbose", "It's verbose.")
+	fs.GenFishCompletion(&buf)
+
+	expect := `complete -c 'prog' -f
+complete -c 'prog' -l 'verbose' -d 'It\'s verbose.'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+// newTestPositionalCompletionFlagSet returns a [*FlagSet] for testing
+// the completion of named positional arguments.
+func newTestPositionalCompletionFlagSet() *FlagSet {
+	var (
+		command string
+		dir     string
+		files   []string
+		output  string
+	)
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.StringVar(&output, 'o', "output", "Write to FILE.")
+	fs.PositionalStringVar(&command, "COMMAND", "The command to run.")
+	fs.PositionalArguments[0].Completion = CompleteWords("build", "test")
+	fs.PositionalStringVar(&dir, "DIR", "The working directory.")
+	fs.PositionalArguments[1].Completion = CompleteDirectories()
+	fs.PositionalRestVar(&files, "FILE", "The files to process.")
+	return fs
+}
+
+func TestFlagSetGenBashCompletionPositionals(t *testing.T) {
+	var buf bytes.Buffer
+	newTestPositionalCompletionFlagSet().GenBashCompletion(&buf)
+
+	expect := `# bash completion for tool
+_tool_completion() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "$prev" in
+        '-o'|'--output')
+            COMPREPLY=($(compgen -f -- "$cur"))
+            return 0
+            ;;
+    esac
+    case "$cur" in
+        '-'*)
+            COMPREPLY=($(compgen -W '-o --output' -- "$cur"))
+            return 0
+            ;;
+    esac
+    local i npos=0 skip=0
+    for ((i=1; i<COMP_CWORD; i++)); do
+        if ((skip)); then
+            skip=0
+            continue
+        fi
+        case "${COMP_WORDS[i]}" in
+            '-o'|'--output') skip=1 ;;
+            '-'*) ;;
+            *) npos=$((npos+1)) ;;
+        esac
+    done
+    case "$npos" in
+        0) COMPREPLY=($(compgen -W 'build test' -- "$cur")) ;;
+        1) COMPREPLY=($(compgen -d -- "$cur")) ;;
+        *) COMPREPLY=($(compgen -f -- "$cur")) ;;
+    esac
+}
+complete -F _tool_completion 'tool'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetGenZshCompletionPositionals(t *testing.T) {
+	var buf bytes.Buffer
+	newTestPositionalCompletionFlagSet().GenZshCompletion(&buf)
+
+	expect := `#compdef tool
+
+_arguments -s \
+    '-o+[Write to FILE.]:STRING:_files' \
+    '--output=[Write to FILE.]:STRING:_files' \
+    '1:COMMAND:(build test)' \
+    '2:DIR:_files -/' \
+    '*:FILE:_files'
+`
+	assert.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetGenFishCompletionPositionals(t *testing.T) {
+	var buf bytes.Buffer
+	newTestPositionalCompletionFlagSet().GenFishCompletion(&buf)
+
+	expect := `complete -c 'tool' -f
+complete -c 'tool' -s 'o' -r -F -d 'Write to FILE.'
+complete -c 'tool' -l 'output' -r -F -d 'Write to FILE.'
+complete -c 'tool' -n '__fish_is_nth_token 1' -a 'build test'
+complete -c 'tool' -n '__fish_is_nth_token 2' -a '(__fish_complete_directories)'
+complete -c 'tool' -n 'not __fish_is_nth_token 1; and not __fish_is_nth_token 2' -F
+`
+	assert.Equal(t, expect, buf.String())
+}
@@ -0,0 +1,267 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/bassosimone/runtimex"
+)
+
+// ConfigDecoder decodes the content of a config file into v.
+//
+// The signature matches [json.Unmarshal] as well as the Unmarshal functions
+// of common YAML and TOML packages (e.g., `gopkg.in/yaml.v3` and
+// `github.com/BurntSushi/toml`), which can be used without adapters.
+type ConfigDecoder func(data []byte, v any) error
+
+// ConfigLoader loads default flag values from config files.
+//
+// Config files contain a top-level map keyed by long flag name or alias.
+// Scalar values are converted to string and passed to [Value.Set]. List
+// values cause [Value.Set] to be invoked once per entry, which is useful
+// for flags accumulating values, such as [ValueStringSlice].
+//
+// Map values cause [Value.Set] to be invoked once per `KEY=VALUE` entry, which
+// is useful for flags bound to a [ValueStringToString].
+//
+// For example, the following JSON config file:
+//
+//	{"output": "index.html", "header": ["Accept: */*", "User-Agent: curl"]}
+//
+// is equivalent to `--output index.html --header 'Accept: */*' --header 'User-Agent: curl'`.
+//
+// Construct using [NewConfigLoader].
+type ConfigLoader struct {
+	// Decoders maps a file extension (e.g., `.json`) to its [ConfigDecoder].
+	//
+	// [NewConfigLoader] initializes this field to map `.json` to [json.Unmarshal].
+	//
+	// Register additional decoders to support other formats. For example:
+	//
+	//	loader.Decoders[".yaml"] = yaml.Unmarshal
+	//	loader.Decoders[".toml"] = toml.Unmarshal
+	Decoders map[string]ConfigDecoder
+
+	// ReadFile is the function to read config files.
+	//
+	// [NewConfigLoader] initializes this field to [os.ReadFile].
+	ReadFile func(name string) ([]byte, error)
+}
+
+// NewConfigLoader returns a new [*ConfigLoader] instance. We document the
+// defaults in the [*ConfigLoader] documentation.
+func NewConfigLoader() *ConfigLoader {
+	return &ConfigLoader{
+		Decoders: map[string]ConfigDecoder{
+			".json": json.Unmarshal,
+		},
+		ReadFile: os.ReadFile,
+	}
+}
+
+// configEntry is a flag value loaded from a config file.
+type configEntry struct {
+	// flag is the long flag the entry refers to.
+	flag *LongFlag
+
+	// source is the config file path, used when reporting errors.
+	source string
+
+	// values contains the values to pass to [Value.Set].
+	values []string
+}
+
+// Load loads the config file at the given path and stages its values into
+// the given [*FlagSet]. Call this method before [*FlagSet.Parse].
+//
+// [*FlagSet.Parse] assigns the staged values only to the flags that do not
+// appear on the command line, such that explicit command-line flags always
+// win. When loading several config files, values from files loaded later
+// override values from files loaded earlier.
+//
+// This method returns an error if the file cannot be read or decoded, if
+// the file extension has no registered [ConfigDecoder], if a key does not
+// match any long flag name or alias, or if a value has an unsupported type.
+// Errors assigning the staged values are reported by [*FlagSet.Parse].
+func (cl *ConfigLoader) Load(fs *FlagSet, path string) error {
+	decoder, found := cl.Decoders[filepath.Ext(path)]
+	if !found {
+		return fmt.Errorf("%s: unsupported config file extension", path)
+	}
+
+	data, err := cl.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var content map[string]any
+	if err := decoder(data, &content); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Process the keys in a predictable order to get predictable errors
+	keys := slices.Sorted(maps.Keys(content))
+
+	entries := make([]configEntry, 0, len(keys))
+	for _, key := range keys {
+		flag := fs.lookupLongFlag(key)
+		if flag == nil {
+			return fmt.Errorf("%s: unknown config key: %s", path, key)
+		}
+		values, err := configValueStrings(content[key])
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", path, key, err)
+		}
+		entries = append(entries, configEntry{flag: flag, source: path, values: values})
+	}
+
+	for _, entry := range entries {
+		fs.stageConfigEntry(entry)
+	}
+	return nil
+}
+
+// stageConfigEntry stages an entry replacing a previous entry for the same flag.
+func (fs *FlagSet) stageConfigEntry(entry configEntry) {
+	for idx := range fs.config {
+		if fs.config[idx].flag == entry.flag {
+			fs.config[idx] = entry
+			return
+		}
+	}
+	fs.config = append(fs.config, entry)
+}
+
+// applyConfig assigns the staged config values and then the values of the bound
+// environment variables to the flags not given on the command line, including
+// the flags set by previous [*FlagSet.ParsePartial] calls, such that environment
+// variables override config files. We call this method after assigning the
+// command line values, such that we know which flags were given.
+func (fs *FlagSet) applyConfig() error {
+	for _, entry := range fs.config {
+		if fs.longFlagGiven(entry.flag) {
+			continue
+		}
+		for _, value := range entry.values {
+			if err := entry.flag.Value.Set(value); err != nil {
+				return fmt.Errorf("%s: %s: %w", entry.source, entry.flag.Name, err)
+			}
+		}
+		entry.flag.configured = true
+	}
+
+	for _, fx := range fs.LongFlags {
+		if fx.EnvVar == "" || fs.longFlagGiven(fx) {
+			continue
+		}
+		if value, found := os.LookupEnv(fx.EnvVar); found {
+			if err := fx.Value.Set(value); err != nil {
+				return fmt.Errorf("%s: %w", fx.EnvVar, err)
+			}
+			fx.configured = true
+		}
+	}
+	return nil
+}
+
+// SetEnvVar binds the long flag with the given name or alias to the given
+// environment variable, which provides the flag value when the flag does not
+// appear on the command line. Values from environment variables override the
+// values loaded using a [*ConfigLoader]. The help shows the environment variable
+// next to the flag (e.g., `[env: CURL_OUTPUT]`).
+//
+// This method panics if there is no long flag with the given name.
+func (fs *FlagSet) SetEnvVar(name, envVar string) {
+	fx := fs.lookupLongFlag(name)
+	runtimex.Assert(fx != nil)
+	fx.EnvVar = envVar
+}
+
+// bindingsUsage returns the help annotation listing the alternative ways to
+// configure the long flag (e.g., ` [env: CURL_OUTPUT] [config: output]`).
+func (fs *FlagSet) bindingsUsage(fx *LongFlag) (output string) {
+	if fx.EnvVar != "" {
+		output += " [env: " + fx.EnvVar + "]"
+	}
+	if fs.ShowConfigKeys {
+		output += " [config: " + fx.Name + "]"
+	}
+	return
+}
+
+// longFlagGiven returns whether [*FlagSet.Parse] found the long flag, one of its
+// aliases, its negation, or the short flag named by its ShortName.
+func (fs *FlagSet) longFlagGiven(fx *LongFlag) bool {
+	if fx.occurrences > 0 {
+		return true
+	}
+	for _, sfx := range fs.ShortFlags {
+		if fx.ShortName != 0 && sfx.Name == fx.ShortName && sfx.occurrences > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// configValueStrings converts a decoded config value to a list of strings.
+//
+// Maps are converted to a list of `KEY=VALUE` strings sorted by key, which
+// is what flags bound to a [ValueStringToString] expect.
+func configValueStrings(value any) ([]string, error) {
+	if dict, ok := value.(map[string]any); ok {
+		output := make([]string, 0, len(dict))
+		for _, key := range slices.Sorted(maps.Keys(dict)) {
+			s, err := configScalarString(dict[key])
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, key+"="+s)
+		}
+		return output, nil
+	}
+	if list, ok := value.([]any); ok {
+		output := make([]string, 0, len(list))
+		for _, entry := range list {
+			s, err := configScalarString(entry)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, s)
+		}
+		return output, nil
+	}
+	s, err := configScalarString(value)
+	if err != nil {
+		return nil, err
+	}
+	return []string{s}, nil
+}
+
+// configScalarString converts a decoded config scalar to string.
+func configScalarString(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		// Note: JSON decodes all numbers as float64 and using the
+		// 'f' format avoids emitting exponents for large integers.
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("unsupported config value type: %T", value)
+	}
+}
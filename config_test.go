@@ -0,0 +1,308 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConfigLoader returns a [*ConfigLoader] reading from the given map.
+func newTestConfigLoader(files map[string]string) *ConfigLoader {
+	cl := NewConfigLoader()
+	cl.ReadFile = func(name string) ([]byte, error) {
+		data, found := files[name]
+		if !found {
+			return nil, errors.New("file not found")
+		}
+		return []byte(data), nil
+	}
+	return cl
+}
+
+func TestNewConfigLoader(t *testing.T) {
+	cl := NewConfigLoader()
+	require.Contains(t, cl.Decoders, ".json")
+	require.NotNil(t, cl.ReadFile)
+}
+
+func TestConfigLoaderLoad(t *testing.T) {
+	t.Run("assigns values not given on the command line", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{
+			"config.json": `{"output": "index.html", "count": 1000000, "verbose": true, "header": ["A: 1", "B: 2"]}`,
+		})
+
+		var (
+			count   int64
+			headers []string
+			output  string
+			verbose bool
+		)
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.Int64Var(&count, 'n', "count", "Set count.")
+		fs.StringSliceVar(&headers, 'H', "header", "Add header.")
+		fs.StringVar(&output, 'o', "output", "Write output to file.")
+		fs.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+
+		require.NoError(t, cl.Load(fs, "config.json"))
+		require.NoError(t, fs.Parse([]string{"-o", "out.txt", "--header", "C: 3"}))
+
+		assert.Equal(t, int64(1000000), count)
+		assert.Equal(t, []string{"C: 3"}, headers)
+		assert.Equal(t, "out.txt", output)
+		assert.True(t, verbose)
+	})
+
+	t.Run("negated flags win over config", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"color": true}`})
+
+		var color bool
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.NegatableBoolVar(&color, 0, "color", "Colorize the output.")
+
+		require.NoError(t, cl.Load(fs, "config.json"))
+		require.NoError(t, fs.Parse([]string{"--no-color"}))
+		assert.False(t, color)
+	})
+
+	t.Run("long flags with non-comparable values win over config", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"format": "yaml"}`})
+
+		var format string
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.EnumVar(&format, []string{"json", "yaml"}, 'f', "format", "Set format.")
+
+		require.NoError(t, cl.Load(fs, "config.json"))
+		require.NoError(t, fs.Parse([]string{"--format", "json"}))
+		assert.Equal(t, "json", format)
+	})
+
+	t.Run("short flags with non-comparable values win over config", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"format": "yaml", "name": "config"}`})
+
+		var (
+			format string
+			names  []string
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.EnumVar(&format, []string{"json", "yaml"}, 'f', "format", "Set format.")
+		fs.Func('n', "name", "Set name.", func(value string) error {
+			names = append(names, value)
+			return nil
+		})
+
+		require.NoError(t, cl.Load(fs, "config.json"))
+		require.NoError(t, fs.Parse([]string{"-f", "json", "-n", "cli"}))
+		assert.Equal(t, "json", format)
+		assert.Equal(t, []string{"cli"}, names)
+	})
+
+	t.Run("maps become KEY=VALUE entries", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"define": {"B": 2, "A": "1"}}`})
+
+		var defines map[string]string
+		fs := NewFlagSet("cc", ContinueOnError)
+		fs.StringToStringVar(&defines, 'D', "define", "Define a macro.")
+
+		require.NoError(t, cl.Load(fs, "config.json"))
+		require.NoError(t, fs.Parse([]string{}))
+		assert.Equal(t, map[string]string{"A": "1", "B": "2"}, defines)
+	})
+
+	t.Run("later files override earlier files", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{
+			"system.json": `{"output": "system.txt"}`,
+			"user.json":   `{"output": "user.txt"}`,
+		})
+
+		var output string
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.StringVar(&output, 'o', "output", "Write output to file.")
+
+		require.NoError(t, cl.Load(fs, "system.json"))
+		require.NoError(t, cl.Load(fs, "user.json"))
+		require.NoError(t, fs.Parse([]string{}))
+
+		assert.Equal(t, "user.txt", output)
+	})
+
+	t.Run("custom decoder", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{"config.yaml": "ignored"})
+		cl.Decoders[".yaml"] = func(data []byte, v any) error {
+			return json.Unmarshal([]byte(`{"output": "yaml.txt"}`), v)
+		}
+
+		var output string
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.StringVar(&output, 'o', "output", "Write output to file.")
+
+		require.NoError(t, cl.Load(fs, "config.yaml"))
+		require.NoError(t, fs.Parse([]string{}))
+		assert.Equal(t, "yaml.txt", output)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			path    string
+			content string
+			wantErr string
+		}{
+			{
+				name:    "unsupported extension",
+				path:    "config.ini",
+				content: "",
+				wantErr: "config.ini: unsupported config file extension",
+			},
+
+			{
+				name:    "missing file",
+				path:    "missing.json",
+				wantErr: "file not found",
+			},
+
+			{
+				name:    "invalid content",
+				path:    "config.json",
+				content: `{`,
+				wantErr: "config.json: unexpected end of JSON input",
+			},
+
+			{
+				name:    "unknown key",
+				path:    "config.json",
+				content: `{"nonexistent": 1}`,
+				wantErr: "config.json: unknown config key: nonexistent",
+			},
+
+			{
+				name:    "unsupported value type",
+				path:    "config.json",
+				content: `{"output": [["nested"]]}`,
+				wantErr: "config.json: output: unsupported config value type: []interface {}",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				files := map[string]string{}
+				if tc.path != "missing.json" {
+					files[tc.path] = tc.content
+				}
+				cl := newTestConfigLoader(files)
+
+				var output string
+				fs := NewFlagSet("curl", ContinueOnError)
+				fs.StringVar(&output, 'o', "output", "Write output to file.")
+
+				err := cl.Load(fs, tc.path)
+				require.Error(t, err)
+				assert.Equal(t, tc.wantErr, err.Error())
+			})
+		}
+	})
+
+	t.Run("invalid value reported by Parse", func(t *testing.T) {
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"count": "many"}`})
+
+		var count int64
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.Int64Var(&count, 'n', "count", "Set count.")
+
+		require.NoError(t, cl.Load(fs, "config.json"))
+		err := fs.Parse([]string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config.json: count: ")
+	})
+}
+
+func TestFlagSetSetEnvVar(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *int) {
+		var (
+			output string
+			retry  int
+		)
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.IntVar(&retry, 0, "retry", "Retry the given number of times.")
+		fs.SetEnvVar("output", "CURL_OUTPUT")
+		fs.SetEnvVar("retry", "CURL_RETRY")
+		return fs, &output, &retry
+	}
+
+	t.Run("assigns values not given on the command line", func(t *testing.T) {
+		t.Setenv("CURL_OUTPUT", "index.html")
+		t.Setenv("CURL_RETRY", "3")
+		fs, output, retry := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"-o", "out.txt"}))
+		assert.Equal(t, "out.txt", *output)
+		assert.Equal(t, 3, *retry)
+	})
+
+	t.Run("overrides config values", func(t *testing.T) {
+		t.Setenv("CURL_RETRY", "3")
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"retry": 5, "output": "x.html"}`})
+		fs, output, retry := newFlagSet()
+		require.NoError(t, cl.Load(fs, "config.json"))
+		require.NoError(t, fs.Parse([]string{}))
+		assert.Equal(t, "x.html", *output)
+		assert.Equal(t, 3, *retry)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Setenv("CURL_RETRY", "x")
+		fs, _, _ := newFlagSet()
+		err := fs.Parse([]string{})
+		assert.ErrorContains(t, err, "CURL_RETRY: ")
+	})
+
+	t.Run("help", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		fs.ShowConfigKeys = true
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "\n    -o STRING, --output STRING [env: CURL_OUTPUT] [config: output]\n")
+
+		fs.ShowConfigKeys = false
+		fs.UsagePrinter = NewCompactUsagePrinter()
+		sb.Reset()
+		fs.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "Write output to FILE. [env: CURL_OUTPUT]\n")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		assert.Panics(t, func() { fs.SetEnvVar("verbose", "CURL_VERBOSE") })
+	})
+}
+
+func TestConfigScalarString(t *testing.T) {
+	cases := []struct {
+		input  any
+		expect string
+	}{
+		{"hello", "hello"},
+		{true, "true"},
+		{float64(1e6), "1000000"},
+		{float64(1.5), "1.5"},
+		{float32(2.5), "2.5"},
+		{int64(-7), "-7"},
+		{uint8(7), "7"},
+	}
+
+	for _, tc := range cases {
+		output, err := configScalarString(tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expect, output)
+	}
+
+	_, err := configScalarString(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bassosimone/runtimex"
+)
+
+// Constraint checks whether a flag value is valid and returns an error otherwise.
+//
+// Use [MatchRegexp], [MinLength], [MaxLength], [NonEmpty], and [OneOf] to construct
+// constraints and [All], [Any], and [Not] to combine them. For example:
+//
+//	identifier := vflag.All(vflag.NonEmpty(), vflag.Not(vflag.MatchRegexp("^[0-9]")))
+//	fs.StringVar(&name, 'n', "name", "Use the given NAME.").Validate(identifier)
+//	fs.StringVar(&user, 'u', "user", "Use the given USER.").Validate(identifier)
+type Constraint func(value string) error
+
+// All returns a [Constraint] requiring the value to satisfy all the given
+// constraints. The returned constraint fails with the first error.
+func All(constraints ...Constraint) Constraint {
+	return func(value string) error {
+		for _, check := range constraints {
+			if err := check(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Any returns a [Constraint] requiring the value to satisfy at least one of
+// the given constraints. The returned constraint fails with all the errors.
+func Any(constraints ...Constraint) Constraint {
+	return func(value string) error {
+		var errs []error
+		for _, check := range constraints {
+			err := check(value)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// Not returns a [Constraint] requiring the value not to satisfy the given constraint.
+func Not(constraint Constraint) Constraint {
+	return func(value string) error {
+		if constraint(value) == nil {
+			return fmt.Errorf("invalid value %q", value)
+		}
+		return nil
+	}
+}
+
+// MatchRegexp returns a [Constraint] requiring the value to match the given regular expression.
+//
+// This function panics if the pattern is not a valid regular expression.
+func MatchRegexp(pattern string) Constraint {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("invalid value %q: must match %s", value, pattern)
+		}
+		return nil
+	}
+}
+
+// MinLength returns a [Constraint] requiring the value to contain at least size characters.
+func MinLength(size int) Constraint {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) < size {
+			return fmt.Errorf("invalid value %q: must be at least %d characters long", value, size)
+		}
+		return nil
+	}
+}
+
+// MaxLength returns a [Constraint] requiring the value to contain at most size characters.
+func MaxLength(size int) Constraint {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > size {
+			return fmt.Errorf("invalid value %q: must be at most %d characters long", value, size)
+		}
+		return nil
+	}
+}
+
+// NonEmpty returns a [Constraint] requiring the value not to be empty.
+func NonEmpty() Constraint {
+	return func(value string) error {
+		if value == "" {
+			return errors.New("invalid value: must not be empty")
+		}
+		return nil
+	}
+}
+
+// OneOf returns a [Constraint] requiring the value to be one of the given choices.
+func OneOf(choices ...string) Constraint {
+	return func(value string) error {
+		if !slices.Contains(choices, value) {
+			return fmt.Errorf("invalid value %q: must be one of: %s", value, strings.Join(choices, ", "))
+		}
+		return nil
+	}
+}
+
+// StringFlag contains the flags registered by [*FlagSet.StringVar] and
+// allows to constrain the values they accept. For example:
+//
+//	fs.StringVar(&name, 'n', "name", "Use the given NAME.").MatchRegexp("^[a-z-]+$")
+//
+// Constraints are checked by [Value.Set], hence violating them causes
+// [*FlagSet.Parse] to fail. Each method returns the [*StringFlag] itself,
+// which allows to chain several constraints.
+type StringFlag struct {
+	// ShortFlag is the short flag or nil.
+	ShortFlag *ShortFlag
+
+	// LongFlag is the long flag or nil.
+	LongFlag *LongFlag
+}
+
+// MatchRegexp requires the value to match the given regular expression.
+//
+// This method panics if the pattern is not a valid regular expression.
+func (sf *StringFlag) MatchRegexp(pattern string) *StringFlag {
+	return sf.Validate(MatchRegexp(pattern))
+}
+
+// MinLength requires the value to contain at least size characters.
+func (sf *StringFlag) MinLength(size int) *StringFlag {
+	return sf.Validate(MinLength(size))
+}
+
+// MaxLength requires the value to contain at most size characters.
+func (sf *StringFlag) MaxLength(size int) *StringFlag {
+	return sf.Validate(MaxLength(size))
+}
+
+// NonEmpty requires the value not to be empty, such that, for example, `--output ""`
+// fails, since empty values are almost always mistakes caused by shell quoting.
+func (sf *StringFlag) NonEmpty() *StringFlag {
+	return sf.Validate(NonEmpty())
+}
+
+// OneOf requires the value to be one of the given choices, which is a lightweight
+// alternative to [*FlagSet.EnumVar] for existing string flags.
+//
+// This method sets the ArgumentName of the flags to the choices separated
+// by `|` (e.g., ` gzip|zstd|none`), such that the help lists them.
+func (sf *StringFlag) OneOf(choices ...string) *StringFlag {
+	argumentName := " " + strings.Join(choices, "|")
+	if sf.ShortFlag != nil {
+		sf.ShortFlag.ArgumentName = argumentName
+	}
+	if sf.LongFlag != nil {
+		sf.LongFlag.ArgumentName = argumentName
+	}
+	return sf.Validate(OneOf(choices...))
+}
+
+// Validate requires the value to satisfy the given [Constraint].
+//
+// This method panics if the constraint is nil.
+func (sf *StringFlag) Validate(constraint Constraint) *StringFlag {
+	runtimex.Assert(constraint != nil)
+	var value Value
+	switch {
+	case sf.ShortFlag != nil:
+		value = sf.ShortFlag.Value
+	case sf.LongFlag != nil:
+		value = sf.LongFlag.Value
+	default:
+		return sf
+	}
+
+	// Note: we use a pointer such that the flags still share the same
+	// comparable [Value], which [*FlagSet.Changed] relies upon.
+	wrapped := &valueConstrained{Value: value, check: constraint}
+	if sf.ShortFlag != nil {
+		sf.ShortFlag.Value = wrapped
+	}
+	if sf.LongFlag != nil {
+		sf.LongFlag.Value = wrapped
+	}
+	return sf
+}
+
+// valueConstrained wraps a [Value] and checks a [Constraint] before setting it.
+type valueConstrained struct {
+	Value
+	check Constraint
+}
+
+// Set implements [Value].
+func (v *valueConstrained) Set(value string) error {
+	if err := v.check(value); err != nil {
+		return err
+	}
+	return v.Value.Set(value)
+}
@@ -0,0 +1,131 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringFlagConstraints(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string) {
+		var name string
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.StringVar(&name, 'n', "name", "Use the given NAME.").
+			MatchRegexp("^[a-z-]+$").MinLength(3).MaxLength(8)
+		return fset, &name
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		fset, name := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"--name", "my-app"}))
+		assert.Equal(t, "my-app", *name)
+		assert.True(t, fset.Changed("n"))
+	})
+
+	cases := []struct {
+		input  string
+		expect string
+	}{
+		{"MyApp", `invalid value "MyApp": must match ^[a-z-]+$`},
+		{"ab", `invalid value "ab": must be at least 3 characters long`},
+		{"long-application", `invalid value "long-application": must be at most 8 characters long`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			fset, name := newFlagSet()
+			err := fset.Parse([]string{"-n", tc.input})
+			assert.EqualError(t, err, tc.expect)
+			assert.Equal(t, "", *name)
+		})
+	}
+
+	t.Run("only long flag", func(t *testing.T) {
+		var name string
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.StringVar(&name, 0, "name", "Use the given NAME.").MinLength(1)
+		assert.Error(t, fset.Parse([]string{"--name="}))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		var name string
+		fset := NewFlagSet("prog", ContinueOnError)
+		assert.Panics(t, func() {
+			fset.StringVar(&name, 'n', "", "Use the given NAME.").MatchRegexp("[")
+		})
+	})
+}
+
+func TestStringFlagOneOf(t *testing.T) {
+	var compression string
+	fset := NewFlagSet("prog", ContinueOnError)
+	sf := fset.StringVar(&compression, 'c', "compression", "Use the given compression.").OneOf("gzip", "zstd", "none")
+
+	assert.Equal(t, " gzip|zstd|none", sf.ShortFlag.ArgumentName)
+	assert.Equal(t, " gzip|zstd|none", sf.LongFlag.ArgumentName)
+	assert.Equal(t, "--compression gzip|zstd|none", sf.LongFlag.Usage())
+
+	require.NoError(t, fset.Parse([]string{"-c", "zstd"}))
+	assert.Equal(t, "zstd", compression)
+
+	err := fset.Parse([]string{"--compression", "bzip2"})
+	assert.EqualError(t, err, `invalid value "bzip2": must be one of: gzip, zstd, none`)
+	assert.Equal(t, "zstd", compression)
+}
+
+func TestStringFlagNonEmpty(t *testing.T) {
+	output := "-"
+	fset := NewFlagSet("prog", ContinueOnError)
+	fset.StringVar(&output, 'o', "output", "Write output to FILE.").NonEmpty()
+
+	assert.EqualError(t, fset.Parse([]string{"--output", ""}), "invalid value: must not be empty")
+	assert.EqualError(t, fset.Parse([]string{"-o", ""}), "invalid value: must not be empty")
+	assert.Equal(t, "-", output)
+
+	require.NoError(t, fset.Parse([]string{"--output", "out.txt"}))
+	assert.Equal(t, "out.txt", output)
+}
+
+func TestConstraintCombinators(t *testing.T) {
+	identifier := All(NonEmpty(), MaxLength(8), Not(MatchRegexp("^[0-9]")))
+	portOrService := Any(MatchRegexp("^[0-9]+$"), OneOf("http", "https"))
+
+	cases := []struct {
+		constraint Constraint
+		input      string
+		expect     string
+	}{
+		{identifier, "user_1", ""},
+		{identifier, "", "invalid value: must not be empty"},
+		{identifier, "very_long_name", `invalid value "very_long_name": must be at most 8 characters long`},
+		{identifier, "1user", `invalid value "1user"`},
+		{portOrService, "8080", ""},
+		{portOrService, "https", ""},
+		{portOrService, "ftp", "invalid value \"ftp\": must match ^[0-9]+$\ninvalid value \"ftp\": must be one of: http, https"},
+		{All(), "anything", ""},
+	}
+	for _, tc := range cases {
+		err := tc.constraint(tc.input)
+		if tc.expect == "" {
+			assert.NoError(t, err, tc.input)
+			continue
+		}
+		assert.EqualError(t, err, tc.expect, tc.input)
+	}
+
+	t.Run("reused across flags", func(t *testing.T) {
+		var name, user string
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.StringVar(&name, 'n', "name", "Use the given NAME.").Validate(identifier)
+		fset.StringVar(&user, 'u', "user", "Use the given USER.").Validate(identifier)
+
+		require.NoError(t, fset.Parse([]string{"-n", "app", "-u", "alice"}))
+		assert.Equal(t, "app", name)
+		assert.Equal(t, "alice", user)
+		assert.EqualError(t, fset.Parse([]string{"--user", "2bob"}), `invalid value "2bob"`)
+	})
+}
@@ -21,5 +21,17 @@ The package provides comprehensive flag definition methods including [*FlagSet.B
 [*FlagSet.Int64Var], [*FlagSet.StringVar], etc. that accept existing pointers to variables
 holding initial-default values. The [*FlagSet.AutoHelp] method helps to automatically
 generate and handle help flags (typically `-h` and `--help`).
+
+The [*Command] type builds trees of commands (e.g., `git remote add`) where each
+command owns a [*FlagSet] and the first positional argument selects the subcommand.
+Use [NewCommand] to create commands, [*Command.AddCommand] to nest them, and
+[*Command.Execute] to parse the command line and dispatch.
+
+The [*ConfigLoader] type loads default flag values from config files keyed by long
+flag name. Values given on the command line always take precedence.
+
+The [CommandLine] variable is the default [*FlagSet] and package-level functions
+such as [BoolVar], [Parse], and [Args] operate on it, easing migrating small
+programs from the stdlib [flag] package.
 */
 package vflag
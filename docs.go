@@ -0,0 +1,50 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+// DocEntry is a named entry of the Environment and Files help sections
+// (e.g., the `HTTPS_PROXY` environment variable and its description).
+//
+// Construct using [NewDocEntry].
+type DocEntry struct {
+	// Description contains the description paragraphs.
+	Description []string
+
+	// Name is the entry name (e.g., `HTTPS_PROXY` or `~/.curlrc`).
+	Name string
+}
+
+// NewDocEntry constructs a new [*DocEntry].
+func NewDocEntry(name string, helpText ...string) *DocEntry {
+	return &DocEntry{
+		Description: helpText,
+		Name:        name,
+	}
+}
+
+// AddAuthors adds paragraphs to the Authors help section.
+func (fs *FlagSet) AddAuthors(values ...string) {
+	fs.Authors = append(fs.Authors, values...)
+}
+
+// AddBugs adds paragraphs to the Bugs help section.
+func (fs *FlagSet) AddBugs(values ...string) {
+	fs.Bugs = append(fs.Bugs, values...)
+}
+
+// AddEnvironmentDoc documents an environment variable in the Environment help section.
+func (fs *FlagSet) AddEnvironmentDoc(name string, helpText ...string) {
+	fs.Environment = append(fs.Environment, NewDocEntry(name, helpText...))
+}
+
+// AddFileDoc documents a file in the Files help section.
+func (fs *FlagSet) AddFileDoc(name string, helpText ...string) {
+	fs.Files = append(fs.Files, NewDocEntry(name, helpText...))
+}
+
+// AddSeeAlso adds references to the See Also help section (e.g., `wget(1)`).
+func (fs *FlagSet) AddSeeAlso(values ...string) {
+	fs.SeeAlso = append(fs.SeeAlso, values...)
+}
@@ -0,0 +1,96 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagSetDocSections(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.AddEnvironmentDoc("HTTPS_PROXY", "The proxy to use for HTTPS.")
+		fs.AddFileDoc("~/.curlrc", "The default config file.")
+		fs.AddAuthors("Daniel Stenberg and many contributors.")
+		fs.AddBugs("Report bugs at https://github.com/curl/curl/issues.")
+		fs.AddSeeAlso("wget(1)", "libcurl(3)")
+		return fs
+	}
+
+	t.Run("usage", func(t *testing.T) {
+		fs := newFlagSet()
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+
+		expect := strings.Join([]string{
+			"",
+			"Usage",
+			"",
+			"    curl",
+			"",
+			"Environment",
+			"",
+			"    HTTPS_PROXY",
+			"",
+			"        The proxy to use for HTTPS.",
+			"",
+			"Files",
+			"",
+			"    ~/.curlrc",
+			"",
+			"        The default config file.",
+			"",
+			"Authors",
+			"",
+			"    Daniel Stenberg and many contributors.",
+			"",
+			"Bugs",
+			"",
+			"    Report bugs at https://github.com/curl/curl/issues.",
+			"",
+			"See Also",
+			"",
+			"    wget(1), libcurl(3)",
+			"",
+			"",
+		}, "\n")
+		assert.Equal(t, expect, sb.String())
+	})
+
+	t.Run("man page", func(t *testing.T) {
+		fs := newFlagSet()
+		var sb strings.Builder
+		GenManPage(fs, 1, &sb)
+
+		expect := strings.Join([]string{
+			`.TH "CURL" 1`,
+			`.SH NAME`,
+			`curl`,
+			`.SH SYNOPSIS`,
+			`.B curl`,
+			`.SH ENVIRONMENT`,
+			`.TP`,
+			`\fBHTTPS_PROXY\fR`,
+			`The proxy to use for HTTPS.`,
+			`.SH FILES`,
+			`.TP`,
+			`\fB~/.curlrc\fR`,
+			`The default config file.`,
+			`.SH AUTHORS`,
+			`.PP`,
+			`Daniel Stenberg and many contributors.`,
+			`.SH BUGS`,
+			`.PP`,
+			`Report bugs at https://github.com/curl/curl/issues.`,
+			`.SH SEE ALSO`,
+			`wget(1), libcurl(3)`,
+			``,
+		}, "\n")
+		assert.Equal(t, expect, sb.String())
+	})
+}
@@ -0,0 +1,155 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"io"
+	"strings"
+
+	"github.com/bassosimone/must"
+)
+
+// HelpTopic returns the flag for which the user requested help using
+// `--help FLAG` or `--help=FLAG` when PerFlagHelp is true, or an empty
+// string otherwise. The flag is returned as written on the command line.
+//
+// Use this method after [*FlagSet.Parse] returns [ErrHelp] to decide
+// whether to call [*FlagSet.PrintFlagHelp] or to print the full usage.
+func (fs *FlagSet) HelpTopic() string {
+	return fs.helpTopic
+}
+
+// helpFlags returns the help flags names including their prefix.
+func (fs *FlagSet) helpFlags() []string {
+	var helps []string
+	for _, fx := range fs.ShortFlags {
+		if _, ok := fx.Value.(ValueAutoHelp); ok {
+			helps = append(helps, fx.Prefix+string(fx.Name))
+		}
+	}
+	for _, fx := range fs.LongFlags {
+		if _, ok := fx.Value.(ValueAutoHelp); ok {
+			helps = append(helps, fx.Prefix+fx.Name)
+		}
+	}
+	return helps
+}
+
+// HelpLevel returns the [HelpLevel] requested by the user when
+// [*FlagSet.Parse] returns [ErrHelp].
+func (fs *FlagSet) HelpLevel() HelpLevel {
+	return fs.helpLevel
+}
+
+// findHelpLevel returns the level selected using `--help=short` or `--help=full`,
+// scanning the arguments until the OptionsArgumentsSeparator.
+func (fs *FlagSet) findHelpLevel(args []string) (HelpLevel, bool) {
+	helps := fs.helpFlags()
+	for _, arg := range args {
+		if arg == fs.OptionsArgumentsSeparator {
+			break
+		}
+		for _, help := range helps {
+			switch arg {
+			case help + "=short":
+				return HelpShort, true
+			case help + "=full":
+				return HelpFull, true
+			}
+		}
+	}
+	return HelpFull, false
+}
+
+// findHelpTopic returns the flag following a help flag (e.g., `--help output`
+// or `--help=output`) when such a flag exists, scanning the arguments
+// until the OptionsArgumentsSeparator.
+func (fs *FlagSet) findHelpTopic(args []string) (string, bool) {
+	helps := fs.helpFlags()
+	for idx, arg := range args {
+		if arg == fs.OptionsArgumentsSeparator {
+			break
+		}
+		for _, help := range helps {
+			topic := ""
+			switch {
+			case arg == help && idx+1 < len(args):
+				topic = args[idx+1]
+			case strings.HasPrefix(arg, help+"="):
+				topic = strings.TrimPrefix(arg, help+"=")
+			}
+			if _, _, found := fs.lookupFlag(topic); topic != "" && found {
+				return topic, true
+			}
+		}
+	}
+	return "", false
+}
+
+// lookupFlag returns the flag with the given name, which may include
+// the prefix (e.g., `--output` or `output`), if any.
+func (fs *FlagSet) lookupFlag(name string) (*ShortFlag, *LongFlag, bool) {
+	for _, fx := range fs.LongFlags {
+		for _, candidate := range append([]string{fx.Name}, fx.Aliases...) {
+			if name == candidate || name == fx.Prefix+candidate {
+				return nil, fx, true
+			}
+		}
+	}
+	for _, fx := range fs.ShortFlags {
+		if name == string(fx.Name) || name == fx.Prefix+string(fx.Name) {
+			return fx, nil, true
+		}
+	}
+	return nil, nil, false
+}
+
+// PrintFlagHelp writes the detailed help of the flag with the given name,
+// which may include the prefix (e.g., `--output` or `output`), including
+// the short or long form of the flag, to the given [io.Writer].
+//
+// This method returns false, without writing anything, if there is no
+// flag with the given name. It panics if writing to the [io.Writer] fails.
+func (fs *FlagSet) PrintFlagHelp(w io.Writer, name string) bool {
+	if _, _, found := fs.lookupFlag(name); !found {
+		return false
+	}
+	shorts, longs := fs.boundFlags(name)
+
+	var (
+		description []string
+		docs        flagDocs
+		synopsis    []string
+	)
+	for _, fx := range shorts {
+		synopsis = append(synopsis, fx.Usage())
+		if fx.NegatedPrefix != "" {
+			synopsis = append(synopsis, fx.NegatedPrefix+string(fx.Name))
+		}
+		description = fx.Description
+		docs = fx.docs()
+	}
+	for _, fx := range longs {
+		synopsis = append(synopsis, fx.Usage())
+		synopsis = append(synopsis, fx.AliasesUsage()...)
+		if fx.Negatable {
+			synopsis = append(synopsis, fx.Prefix+fx.NegatedName())
+		}
+		description = fx.Description
+		docs = fx.docs()
+	}
+
+	up, ok := fs.UsagePrinter.(*DefaultUsagePrinter)
+	if !ok {
+		up = &DefaultUsagePrinter{}
+	}
+	up.div1(w, strings.Join(synopsis, ", "))
+	for _, dentry := range description {
+		dentry = strings.ReplaceAll(dentry, "@DEFAULT_VALUE@", docs.defaultValue())
+		up.div2(w, dentry)
+	}
+	must.Fprintf(w, "\n")
+	return true
+}
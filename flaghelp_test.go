@@ -0,0 +1,142 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagSetPerFlagHelp(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			output  string
+			verbose bool
+		)
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.PerFlagHelp = true
+		fset.SetMinMaxPositionalArgs(0, 1)
+		fset.AutoHelp('h', "help", "Show this help message and exit.")
+		fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		return fset
+	}
+
+	for _, args := range [][]string{
+		{"--help", "output"},
+		{"--help=output"},
+		{"-h", "--output"},
+		{"-v", "--help", "o"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			fset := newFlagSet()
+			err := fset.Parse(args)
+			require.ErrorIs(t, err, ErrHelp)
+			assert.Equal(t, args[len(args)-1][strings.Index(args[len(args)-1], "=")+1:], fset.HelpTopic())
+		})
+	}
+
+	t.Run("not a flag", func(t *testing.T) {
+		fset := newFlagSet()
+		err := fset.Parse([]string{"--help", "file.txt"})
+		require.ErrorIs(t, err, ErrHelp)
+		assert.Equal(t, "", fset.HelpTopic())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.PerFlagHelp = false
+		err := fset.Parse([]string{"--help", "output"})
+		require.ErrorIs(t, err, ErrHelp)
+		assert.Equal(t, "", fset.HelpTopic())
+	})
+
+	t.Run("exit on error", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.ErrorHandling = ExitOnError
+		var stdout strings.Builder
+		fset.Stdout = &stdout
+		fset.Exit = func(status int) { panic(status) }
+		assert.PanicsWithValue(t, 0, func() {
+			fset.Parse([]string{"--help=output"})
+		})
+		expect := strings.Join([]string{
+			"",
+			"    -o STRING, --output STRING",
+			"",
+			"        Write output to FILE.",
+			"",
+			"",
+		}, "\n")
+		assert.Equal(t, expect, stdout.String())
+	})
+
+	t.Run("PrintFlagHelp with non-comparable values", func(t *testing.T) {
+		var color string
+		fset := newFlagSet()
+		fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		var sb strings.Builder
+		assert.True(t, fset.PrintFlagHelp(&sb, "c"))
+		assert.Contains(t, sb.String(), "\n    -c red|blue, --color red|blue\n")
+	})
+
+	t.Run("PrintFlagHelp with unknown flag", func(t *testing.T) {
+		var sb strings.Builder
+		assert.False(t, newFlagSet().PrintFlagHelp(&sb, "quiet"))
+		assert.Empty(t, sb.String())
+	})
+}
+
+func TestFlagSetHelpLevels(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var output string
+		fset := NewFlagSet("curl", ContinueOnError)
+		fset.SetMinMaxPositionalArgs(1, 1)
+		fset.AutoHelpLevels('h', HelpShort, "help", HelpFull, "Show this help message and exit.")
+		fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		return fset
+	}
+
+	for _, tc := range []struct {
+		args  []string
+		level HelpLevel
+	}{
+		{[]string{"-h"}, HelpShort},
+		{[]string{"--help"}, HelpFull},
+		{[]string{"--help=short"}, HelpShort},
+		{[]string{"--help=full"}, HelpFull},
+		{[]string{"-h=full"}, HelpFull},
+	} {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fset := newFlagSet()
+			err := fset.Parse(tc.args)
+			require.ErrorIs(t, err, ErrHelp)
+			assert.Equal(t, tc.level, fset.HelpLevel())
+		})
+	}
+
+	t.Run("exit on error", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.ErrorHandling = ExitOnError
+		var stdout strings.Builder
+		fset.Stdout = &stdout
+		fset.Exit = func(status int) { panic(status) }
+		assert.PanicsWithValue(t, 0, func() {
+			fset.Parse([]string{"-h"})
+		})
+		expect := strings.Join([]string{
+			"Usage: curl [flags] arg",
+			"",
+			"Flags: -h, --help, -o STRING, --output STRING",
+			"",
+			"Try `curl --help' for the full help.",
+			"",
+		}, "\n")
+		assert.Equal(t, expect, stdout.String())
+	})
+}
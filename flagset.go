@@ -8,10 +8,17 @@ package vflag
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/bassosimone/flagparser"
+	"github.com/bassosimone/must"
 	"github.com/bassosimone/runtimex"
 )
 
@@ -30,6 +37,43 @@ const (
 	PanicOnError
 )
 
+// DuplicatePolicy controls what happens when a flag appears multiple times.
+type DuplicatePolicy int
+
+// These constants define the allowed [DuplicatePolicy] values.
+const (
+	// DuplicateLastWins causes the last occurrence to determine the value,
+	// which is the default. Flags accumulating values, such as the ones
+	// bound to a [ValueStringSlice], see all the occurrences.
+	DuplicateLastWins = DuplicatePolicy(iota)
+
+	// DuplicateFirstWins causes [*FlagSet.Parse] to ignore all
+	// the occurrences except the first one.
+	DuplicateFirstWins
+
+	// DuplicateError causes [*FlagSet.Parse] to fail.
+	DuplicateError
+)
+
+// AttachedValuePolicy controls whether a short flag taking a value accepts
+// the value attached to the flag (e.g., `-ofile.txt` rather than `-o file.txt`).
+type AttachedValuePolicy int
+
+// These constants define the allowed [AttachedValuePolicy] values.
+const (
+	// AttachedValueAllowed accepts both the attached and the separate
+	// value (e.g., `-ofile.txt` and `-o file.txt`), which is the default.
+	AttachedValueAllowed = AttachedValuePolicy(iota)
+
+	// AttachedValueRequired causes [*FlagSet.Parse] to fail unless
+	// the value is attached to the flag (e.g., `-ofile.txt`).
+	AttachedValueRequired
+
+	// AttachedValueRejected causes [*FlagSet.Parse] to fail unless the
+	// value is a separate argument (e.g., `-o file.txt`), for clarity.
+	AttachedValueRejected
+)
+
 // FlagSet allows to parse flags from the command line. The zero value is not
 // ready to use. Construct using the [NewFlagSet] constructor.
 //
@@ -41,6 +85,77 @@ const (
 // The [*FlagSet] will recognize `--verbose` as a syntactically valid flag
 // that has not been configured and print an "unknown flag" error.
 type FlagSet struct {
+	// AllowAbbreviations causes [*FlagSet.Parse] to accept unambiguous
+	// prefixes of the long flags names, like GNU getopt_long does (e.g.,
+	// `--verb` for `--verbose`). When a prefix matches several flags,
+	// [*FlagSet.Parse] fails with an error listing the candidates.
+	//
+	// [NewFlagSet] initializes this field to false.
+	AllowAbbreviations bool
+
+	// Authors contains the paragraphs of the Authors help section.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// Use [*FlagSet.AddAuthors] to append to this field.
+	Authors []string
+
+	// Bugs contains the paragraphs of the Bugs help section, which usually
+	// explain how to report bugs (e.g., the URL of the issue tracker).
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// Use [*FlagSet.AddBugs] to append to this field.
+	Bugs []string
+
+	// CaseInsensitive causes [*FlagSet.Parse] to match the long flags, their
+	// aliases, and their negations ignoring the case (e.g., `--Output` and
+	// `--OUTPUT` match `--output`), which is useful for Windows-oriented tools.
+	// The help and the errors still use the flags names as registered.
+	//
+	// [NewFlagSet] initializes this field to false.
+	CaseInsensitive bool
+
+	// CollectAllErrors causes [*FlagSet.Parse] to continue past unknown
+	// options, options missing their argument, and invalid values, and
+	// to return an error joining all the errors that occurred.
+	//
+	// [NewFlagSet] initializes this field to false.
+	//
+	// When this field is false, [*FlagSet.Parse] returns the first error.
+	CollectAllErrors bool
+
+	// CollectUnknownOptions causes [*FlagSet.Parse] to collect unknown
+	// options into [*FlagSet.UnknownArgs] rather than failing, which is
+	// useful to forward them verbatim to a child process.
+	//
+	// [NewFlagSet] initializes this field to false.
+	//
+	// Since we cannot know whether an unknown option takes a value, we only
+	// collect the argument containing the option. For example, we collect
+	// `--foo=bar` as it is, while, for `--foo bar`, we collect `--foo` and
+	// treat `bar` as a positional argument. Likewise, we collect a group of
+	// short options containing an unknown option (e.g., `-vx`) as a whole.
+	CollectUnknownOptions bool
+
+	// DashIsPositional causes [*FlagSet.Parse] to treat a bare `-` as a
+	// positional argument, regardless of the configured prefixes, which
+	// matches the convention of using `-` to indicate stdin or stdout.
+	//
+	// [NewFlagSet] initializes this field to false.
+	//
+	// A bare `-` following a flag requiring a value is still the flag
+	// value, therefore, `-o -` continues to assign `-` to `-o`.
+	DashIsPositional bool
+
+	// DisableGrouping causes [*FlagSet.Parse] to treat the short flags as
+	// standalone options, such that `-xv` is not the same as `-x -v`, and
+	// such that the short flags take their value as a separate argument or
+	// using `=` (e.g., `-o FILE` or `-o=FILE`) rather than attached to them.
+	//
+	// [NewFlagSet] initializes this field to false.
+	DisableGrouping bool
+
 	// DisablePermute disable the permutation of options and arguments.
 	//
 	// [NewFlagSet] initializes this field to false.
@@ -75,6 +190,13 @@ type FlagSet struct {
 	// becomes unnecessary and the UX is improved.
 	DisablePermute bool
 
+	// Environment contains the environment variables listed in the Environment help section.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// Use [*FlagSet.AddEnvironmentDoc] to append to this field.
+	Environment []*DocEntry
+
 	// ErrorHandling is the [ErrorHandling] policy.
 	//
 	// [NewFlagSet] initializes this field to [ContinueOnError].
@@ -85,6 +207,37 @@ type FlagSet struct {
 	// [NewFlagSet] initializes this field to [os.Exit].
 	Exit func(status int)
 
+	// ExpandResponseFiles causes [*FlagSet.Parse] to replace each `@FILE`
+	// argument with the arguments contained in FILE, which is how linkers
+	// and compilers cope with very long command lines.
+	//
+	// [NewFlagSet] initializes this field to false.
+	//
+	// We split the contents of FILE into arguments using the POSIX shell
+	// quoting rules (see [*FlagSet.ParseString]), such that, for example,
+	// `-o 'my file.o'` yields the `-o` and `my file.o` arguments.
+	//
+	// Response files may reference other response files up to the depth
+	// given by MaxResponseFileDepth. Arguments following the
+	// OptionsArgumentsSeparator and arguments consumed as the value of
+	// an option (e.g., `@alice` in `--user @alice`) are never expanded.
+	ExpandResponseFiles bool
+
+	// Files contains the files listed in the Files help section.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// Use [*FlagSet.AddFileDoc] to append to this field.
+	Files []*DocEntry
+
+	// LongFlagPrefix is the prefix that [*FlagSet.AddLongFlag] assigns to the
+	// long flags added afterwards using the `--` prefix (e.g., `-` to parse
+	// java-style `-verbose` options), which includes the flags added using
+	// convenience methods such as [*FlagSet.BoolVar].
+	//
+	// [NewFlagSet] initializes this field to "", meaning that we use `--`.
+	LongFlagPrefix string
+
 	// LongFlags contains the long flags to parse.
 	//
 	// Long flags are multi-character flags (e.g., `--verbose`, `--output`)
@@ -99,6 +252,13 @@ type FlagSet struct {
 	// arguments to be on the command line.
 	MaxPositionalArgs int
 
+	// MaxResponseFileDepth is the maximum nesting depth of response files.
+	//
+	// [NewFlagSet] initializes this field to 8.
+	//
+	// See ExpandResponseFiles for more information.
+	MaxResponseFileDepth int
+
 	// MinPositionalArgs is the minimum number of positional arguments.
 	//
 	// [NewFlagSet] initializes this field to 0.
@@ -107,6 +267,17 @@ type FlagSet struct {
 	// arguments to be on the command line.
 	MinPositionalArgs int
 
+	// NumericShorthand is the name of the flag receiving the value of the numeric
+	// shorthand arguments, like head and tail do (e.g., when this field is `lines`,
+	// `-5` is equivalent to `--lines 5`).
+	//
+	// [NewFlagSet] initializes this field to "", which disables numeric shorthands.
+	// [*FlagSet.Parse] fails when this field does not name a registered flag.
+	//
+	// Use [*FlagSet.NumericShorthandVar] to register a flag and set this field. Short
+	// flags named after a digit (e.g., `-4`) take precedence over the shorthand.
+	NumericShorthand string
+
 	// OptionsArgumentsSeparator separates options and arguments.
 	//
 	// [NewFlagSet] initializes this field to "--".
@@ -116,17 +287,109 @@ type FlagSet struct {
 	// all the remaining entries as positional arguments.
 	OptionsArgumentsSeparator string
 
+	// PerFlagHelp causes [*FlagSet.Parse] to recognize `--help FLAG` and
+	// `--help=FLAG`, where FLAG is the name of a flag with or without its
+	// prefix (e.g., `output` or `--output`), as a request to print only the
+	// detailed help of such a flag, which is useful with very long help texts.
+	//
+	// [NewFlagSet] initializes this field to false.
+	//
+	// In this case, [*FlagSet.Parse] returns [ErrHelp] and [*FlagSet.HelpTopic]
+	// returns the FLAG. With [ExitOnError], [*FlagSet.Parse] prints the flag
+	// help using [*FlagSet.PrintFlagHelp]. When FLAG does not name a flag, we
+	// treat `--help FLAG` as a help flag followed by a positional argument.
+	PerFlagHelp bool
+
+	// PositionalArguments contains the named positional arguments.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// Use [*FlagSet.PositionalStringVar], [*FlagSet.PositionalIntVar], etc.
+	// to declare positional arguments. See [*FlagSet.AddPositionalArgument].
+	PositionalArguments []*PositionalArgument
+
+	// PositionalPattern is the usage string for positional arguments
+	// set by [*FlagSet.SetPositionalPattern] (e.g., `SRC... DST`).
+	//
+	// [NewFlagSet] initializes this field to "".
+	//
+	// The [*DefaultUsagePrinter] uses this field unless its own
+	// PositionalArgumentsUsage field is not empty.
+	PositionalPattern string
+
+	// PosixlyCorrect causes [*FlagSet.Parse] to behave as if DisablePermute
+	// were true, like GNU getopt does when the POSIXLY_CORRECT environment
+	// variable is set. When such a variable is set, [*FlagSet.Parse] behaves
+	// as if this field were true, so users can request the POSIX behavior.
+	//
+	// [NewFlagSet] initializes this field to false.
+	PosixlyCorrect bool
+
 	// ProgramName is the program name.
 	//
 	// [NewFlagSet] initializes this field to the given program name.
 	ProgramName string
 
+	// RejectDashValues causes [*FlagSet.Parse] to reject a separate argument
+	// starting with `-` as the value of a flag (e.g., `--output --force`),
+	// which is likely a flag given by mistake in place of the value, unless
+	// the flag has AllowDashValue set. Values attached to the flag (e.g.,
+	// `--output=-x` or `-o-x`) and the bare `-` are always accepted.
+	//
+	// [NewFlagSet] initializes this field to false.
+	RejectDashValues bool
+
+	// RequireEquals causes [*FlagSet.Parse] to reject the long flags taking
+	// a value that do not use `=` to attach the value (e.g., `--output FILE`
+	// rather than `--output=FILE`), which prevents mistaking the following
+	// argument for the value when the user forgets the value (e.g., in
+	// `--output --force`). This does not affect the groupable short flags,
+	// for which you can use the [AttachedValuePolicy] instead.
+	//
+	// [NewFlagSet] initializes this field to false.
+	RequireEquals bool
+
+	// RetainOptionsArgumentsSeparator causes [*FlagSet.Parse] to include the
+	// OptionsArgumentsSeparator within the positional arguments returned by
+	// [*FlagSet.Args]. This is useful for wrappers that re-execute other tools
+	// and need to preserve the meaning of the command line. For example, given
+	// `wrapper -v -- -x file`, [*FlagSet.Args] returns `-- -x file`.
+	//
+	// [NewFlagSet] initializes this field to false.
+	RetainOptionsArgumentsSeparator bool
+
+	// SeeAlso contains the references listed in the See Also help section.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// Use [*FlagSet.AddSeeAlso] to append to this field.
+	SeeAlso []string
+
+	// ShowConfigKeys causes the usage printers to annotate each long flag
+	// with the key to use for configuring it using a [*ConfigLoader] config
+	// file (e.g., `[config: output]`).
+	//
+	// [NewFlagSet] initializes this field to false.
+	ShowConfigKeys bool
+
 	// ShortFlags contains the short flags to parse.
 	//
 	// Short flags are single-character flags (e.g., `-v`, `-o`) that can be
 	// grouped together on the command line.
 	ShortFlags []*ShortFlag
 
+	// StopAtFirstPositional causes [*FlagSet.Parse] to stop parsing options
+	// at the first positional argument and to make it and all the following
+	// arguments available using [*FlagSet.Remainder]. This is what subcommand
+	// dispatch needs: given `git -C dir commit -m msg`, the remainder is
+	// `commit -m msg`.
+	//
+	// [NewFlagSet] initializes this field to false.
+	//
+	// When this field is true, [*FlagSet.Parse] ignores DisablePermute,
+	// MinPositionalArgs, and MaxPositionalArgs.
+	StopAtFirstPositional bool
+
 	// Stderr is the [io.Writer] to use as the stderr.
 	//
 	// [NewFlagSet] initializes this field to [os.Stderr].
@@ -141,6 +404,50 @@ type FlagSet struct {
 	// We use this field with [ExitOnError] policy.
 	Stdout io.Writer
 
+	// TooFewPositionalArgsMessage replaces the error message used when
+	// there are fewer than MinPositionalArgs positional arguments, such
+	// that errors match the vocabulary of the program (e.g., `expected
+	// exactly one URL`). The @MIN@ and @MAX@ placeholders are replaced
+	// with MinPositionalArgs and MaxPositionalArgs, and the @HAVE@
+	// placeholder with the number of positional arguments given.
+	//
+	// [NewFlagSet] initializes this field to "", meaning that we use
+	// the error message produced by the parser.
+	TooFewPositionalArgsMessage string
+
+	// TooManyPositionalArgsMessage is like TooFewPositionalArgsMessage but
+	// is used when there are more than MaxPositionalArgs positional arguments.
+	//
+	// [NewFlagSet] initializes this field to "".
+	TooManyPositionalArgsMessage string
+
+	// Usage is the function invoked instead of the UsagePrinter, when not nil,
+	// to print the usage, which eases migrating from [flag.FlagSet.Usage].
+	//
+	// [NewFlagSet] initializes this field to nil.
+	//
+	// With [ExitOnError], [*FlagSet.Parse] invokes this function when the user
+	// requests help and after printing the error on Stderr when parsing fails.
+	// The function usually invokes [*FlagSet.PrintDefaults].
+	Usage func()
+
+	// UsageFooter contains the paragraphs printed at the end of the usage,
+	// for example, the URL where to report bugs.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// The [*DefaultUsagePrinter] and the [*CompactUsagePrinter] word wrap each
+	// paragraph unless it starts with 4 spaces, like the description paragraphs.
+	UsageFooter []string
+
+	// UsageHeader contains the paragraphs printed at the beginning of the
+	// usage, before the Usage section, for example, a copyright line.
+	//
+	// [NewFlagSet] initializes this field to an empty slice.
+	//
+	// See UsageFooter for more information about the formatting.
+	UsageHeader []string
+
 	// UsagePrinter is the [UsagePrinter] to use.
 	//
 	// [NewFlagSet] initializes this field to an empty [*DefaultUsagePrinter]
@@ -150,8 +457,33 @@ type FlagSet struct {
 	// We use this field with [ExitOnError] policy.
 	UsagePrinter UsagePrinter
 
+	// ValueSeparator is the ValueSeparator that [*FlagSet.AddLongFlag] assigns
+	// to the long flags added afterwards whose ValueSeparator is empty (e.g.,
+	// `:` to parse javac-style `-encoding:UTF-8` options).
+	//
+	// [NewFlagSet] initializes this field to "", meaning that we use `=`.
+	ValueSeparator string
+
+	// config contains the values staged by [*ConfigLoader.Load].
+	config []configEntry
+
+	// group is the group selected using [*FlagSet.Group].
+	group string
+
+	// helpLevel is the [HelpLevel] requested by the user.
+	helpLevel HelpLevel
+
+	// helpTopic is the flag for which the user requested help.
+	helpTopic string
+
 	// positionals buffers the positional arguments.
 	positionals []string
+
+	// unknownArgs buffers the unknown options and their values.
+	unknownArgs []string
+
+	// validators contains the validators added using [*FlagSet.AddValidator].
+	validators []func(fs *FlagSet) error
 }
 
 // NewFlagSet returns a new [*FlagSet] instance. We use the given progname as
@@ -165,22 +497,80 @@ func NewFlagSet(progname string, handling ErrorHandling) *FlagSet {
 		expectedShortFlags  = 16
 	)
 	return &FlagSet{
-		DisablePermute:            false,
-		ErrorHandling:             handling,
-		Exit:                      os.Exit,
-		LongFlags:                 make([]*LongFlag, 0, expectedLongFlags),
-		MaxPositionalArgs:         0,
-		MinPositionalArgs:         0,
-		OptionsArgumentsSeparator: "--",
-		ProgramName:               progname,
-		ShortFlags:                make([]*ShortFlag, 0, expectedShortFlags),
-		Stderr:                    os.Stderr,
-		Stdout:                    os.Stdout,
-		UsagePrinter:              &DefaultUsagePrinter{},
-		positionals:               make([]string, 0, expectedPositionals),
+		AllowAbbreviations:              false,
+		Authors:                         []string{},
+		Bugs:                            []string{},
+		CaseInsensitive:                 false,
+		CollectAllErrors:                false,
+		CollectUnknownOptions:           false,
+		DashIsPositional:                false,
+		DisableGrouping:                 false,
+		DisablePermute:                  false,
+		Environment:                     []*DocEntry{},
+		ErrorHandling:                   handling,
+		Exit:                            os.Exit,
+		ExpandResponseFiles:             false,
+		Files:                           []*DocEntry{},
+		LongFlagPrefix:                  "",
+		LongFlags:                       make([]*LongFlag, 0, expectedLongFlags),
+		MaxPositionalArgs:               0,
+		MaxResponseFileDepth:            8,
+		MinPositionalArgs:               0,
+		NumericShorthand:                "",
+		OptionsArgumentsSeparator:       "--",
+		PerFlagHelp:                     false,
+		PositionalArguments:             []*PositionalArgument{},
+		PositionalPattern:               "",
+		PosixlyCorrect:                  false,
+		ProgramName:                     progname,
+		RejectDashValues:                false,
+		RequireEquals:                   false,
+		RetainOptionsArgumentsSeparator: false,
+		SeeAlso:                         []string{},
+		ShowConfigKeys:                  false,
+		ShortFlags:                      make([]*ShortFlag, 0, expectedShortFlags),
+		StopAtFirstPositional:           false,
+		Stderr:                          os.Stderr,
+		Stdout:                          os.Stdout,
+		TooFewPositionalArgsMessage:     "",
+		TooManyPositionalArgsMessage:    "",
+		Usage:                           nil,
+		UsageFooter:                     []string{},
+		UsageHeader:                     []string{},
+		UsagePrinter:                    &DefaultUsagePrinter{},
+		ValueSeparator:                  "",
+		positionals:                     make([]string, 0, expectedPositionals),
 	}
 }
 
+// NewJavaStyleFlagSet is like [NewFlagSet] but preconfigures the [*FlagSet]
+// for the java-style and X11-style conventions, where all the flags use the
+// `-` prefix (e.g., `-verbose` and `-v`), we do not group the short flags, and
+// the flags take their value as a separate argument or using `=` (e.g.,
+// `-display :0` or `-display=:0`).
+//
+// To this end, we set LongFlagPrefix to `-` and DisableGrouping to true.
+func NewJavaStyleFlagSet(progname string, handling ErrorHandling) *FlagSet {
+	fs := NewFlagSet(progname, handling)
+	fs.DisableGrouping = true
+	fs.LongFlagPrefix = "-"
+	return fs
+}
+
+// SetOutput sets both the Stdout and the Stderr fields to the given [io.Writer].
+//
+// This method eases migrating from [flag.FlagSet.SetOutput].
+func (fs *FlagSet) SetOutput(w io.Writer) {
+	fs.Stdout = w
+	fs.Stderr = w
+}
+
+// Output returns the [io.Writer] used for errors and [*FlagSet.PrintDefaults],
+// which is the Stderr field.
+func (fs *FlagSet) Output() io.Writer {
+	return fs.Stderr
+}
+
 // SetMinMaxPositionalArgs sets the minimum and maximum positional arguments.
 func (fs *FlagSet) SetMinMaxPositionalArgs(minArgs, maxArgs int) {
 	fs.MinPositionalArgs = minArgs
@@ -194,7 +584,13 @@ func (fs *FlagSet) SetMinMaxPositionalArgs(minArgs, maxArgs int) {
 //
 // For GNU-style flags, prefer using the convenience methods like [*FlagSet.BoolVar],
 // [*FlagSet.StringVar], etc., which create and add both short and long flags.
+//
+// If the flag Group is empty, this method sets it to the current group
+// selected using [*FlagSet.Group].
 func (fs *FlagSet) AddShortFlag(flag *ShortFlag) {
+	if flag.Group == "" {
+		flag.Group = fs.group
+	}
 	fs.ShortFlags = append(fs.ShortFlags, flag)
 }
 
@@ -205,10 +601,81 @@ func (fs *FlagSet) AddShortFlag(flag *ShortFlag) {
 //
 // For GNU-style flags, prefer using the convenience methods like [*FlagSet.BoolVar],
 // [*FlagSet.StringVar], etc., which create and add both short and long flags.
+//
+// If the flag Group is empty, this method sets it to the current group
+// selected using [*FlagSet.Group]. Likewise, if the flag ValueSeparator is
+// empty, this method sets it to the [*FlagSet] ValueSeparator, and, if the
+// flag Prefix is `--`, this method sets it to the [*FlagSet] LongFlagPrefix.
 func (fs *FlagSet) AddLongFlag(flag *LongFlag) {
+	if flag.Group == "" {
+		flag.Group = fs.group
+	}
+	if flag.ValueSeparator == "" {
+		flag.ValueSeparator = fs.ValueSeparator
+	}
+	if flag.Prefix == "--" && fs.LongFlagPrefix != "" {
+		flag.Prefix = fs.LongFlagPrefix
+	}
 	fs.LongFlags = append(fs.LongFlags, flag)
 }
 
+// addLongFlag is like [*FlagSet.AddLongFlag] but also sets the flag ShortName
+// to remember the short flag sharing the same [Value], if shortName is not zero.
+func (fs *FlagSet) addLongFlag(flag *LongFlag, shortName rune) {
+	flag.ShortName = shortName
+	fs.AddLongFlag(flag)
+}
+
+// AddLongFlagAliases adds aliases to the [*LongFlag] with the given name.
+//
+// This is a convenience method for adding aliases to flags registered using
+// methods such as [*FlagSet.StringVar], which do not return the [*LongFlag].
+//
+// Example:
+//
+//	fset.StringVar(&color, 0, "color", "Colorize the output.")
+//	fset.AddLongFlagAliases("color", "colour") // Adds --colour alias
+//
+// This method panics if there is no long flag with the given name.
+func (fs *FlagSet) AddLongFlagAliases(name string, aliases ...string) {
+	fx := fs.lookupLongFlag(name)
+	runtimex.Assert(fx != nil)
+	fx.Aliases = append(fx.Aliases, aliases...)
+}
+
+// AddShortFlagPrefixAliases adds prefix aliases to the [*ShortFlag] with the given name.
+//
+// This is a convenience method for adding prefix aliases to flags registered using
+// methods such as [*FlagSet.BoolVar], which do not return the [*ShortFlag].
+//
+// Example:
+//
+//	fset.BoolVar(&ipv4, '4', "", "Use IPv4.")
+//	fset.AddShortFlagPrefixAliases('4', "/") // Adds /4 alias
+//
+// This method panics if there is no short flag with the given name.
+func (fs *FlagSet) AddShortFlagPrefixAliases(name rune, prefixes ...string) {
+	idx := slices.IndexFunc(fs.ShortFlags, func(fx *ShortFlag) bool { return fx.Name == name })
+	runtimex.Assert(idx >= 0)
+	fs.ShortFlags[idx].PrefixAliases = append(fs.ShortFlags[idx].PrefixAliases, prefixes...)
+}
+
+// AllowDashValue sets AllowDashValue for the flag with the given name and
+// its short or long form (see [*FlagSet.MarkRequired]), such that the flag accepts a separate
+// argument starting with `-` as its value when RejectDashValues is true.
+//
+// Names are either long flag names or aliases, or short flag names, like
+// in [*FlagSet.Changed]. This method panics if there is no such flag.
+func (fs *FlagSet) AllowDashValue(name string) {
+	shorts, longs := fs.boundFlags(name)
+	for _, fx := range shorts {
+		fx.AllowDashValue = true
+	}
+	for _, fx := range longs {
+		fx.AllowDashValue = true
+	}
+}
+
 // AddLongFlagDig appends a [*LongFlag] to the [*FlagSet.LongFlags] slice after
 // setting its [*LongFlag.Prefix] to `+` (dig-style convention).
 //
@@ -222,7 +689,50 @@ func (fs *FlagSet) AddLongFlag(flag *LongFlag) {
 //	fset.AddLongFlagDig(lf) // Adds +short flag
 func (fs *FlagSet) AddLongFlagDig(flag *LongFlag) {
 	flag.Prefix = "+"
-	fs.LongFlags = append(fs.LongFlags, flag)
+	fs.AddLongFlag(flag)
+}
+
+// AddLongFlagFind appends a [*LongFlag] to the [*FlagSet.LongFlags] slice after
+// setting its [*LongFlag.Prefix] to `-` (find-style convention).
+//
+// This is a convenience method for adding find-style flags like `-name` or `-mtime`,
+// which coexist with the short flags using the `-` prefix. When an argument matches
+// the name of such a flag (e.g., `-name`), we use the long flag, otherwise we use
+// the short flags (e.g., `-n` or `-nv`).
+//
+// Example:
+//
+//	var name string
+//	lf := vflag.NewLongFlagString(vflag.NewValueString(&name), "name", "Match the `PATTERN`.")
+//	fset.AddLongFlagFind(lf) // Adds -name flag
+func (fs *FlagSet) AddLongFlagFind(flag *LongFlag) {
+	flag.Prefix = "-"
+	fs.AddLongFlag(flag)
+}
+
+// Group selects the group of the flags added afterwards and returns the
+// [*FlagSet] itself, which allows to write, for example:
+//
+//	fs.Group("Output options").StringVar(&output, 'o', "output", "Write output to FILE.")
+//
+// The [*DefaultUsagePrinter] lists the flags of each group under a heading
+// named after the group. Use an empty name to select the default group,
+// whose flags the [*DefaultUsagePrinter] lists under the `Flags` heading.
+func (fs *FlagSet) Group(name string) *FlagSet {
+	fs.group = name
+	return fs
+}
+
+// AddValidator adds a function validating the [*FlagSet] after parsing.
+//
+// Validators run in order after [*FlagSet.Parse] has successfully assigned all
+// the flags, which allows to enforce invariants involving several flags. Their
+// errors are subject to the [ErrorHandling] policy like any other parse error.
+//
+// This method panics if fn is nil.
+func (fs *FlagSet) AddValidator(fn func(fs *FlagSet) error) {
+	runtimex.Assert(fn != nil)
+	fs.validators = append(fs.validators, fn)
 }
 
 // Args returns the positional arguments collected by [*FlagSet.Parse].
@@ -230,6 +740,117 @@ func (fs *FlagSet) Args() []string {
 	return fs.positionals
 }
 
+// Remainder returns the arguments starting at the first positional argument,
+// which [*FlagSet.Parse] did not parse when StopAtFirstPositional is true.
+//
+// Since [*FlagSet.Parse] treats the remainder as positional arguments, this
+// method returns the same arguments of [*FlagSet.Args] in such a case and
+// returns nil when StopAtFirstPositional is false.
+func (fs *FlagSet) Remainder() []string {
+	if !fs.StopAtFirstPositional {
+		return nil
+	}
+	return fs.positionals
+}
+
+// UnknownArgs returns the arguments containing unknown options collected
+// by [*FlagSet.Parse] when CollectUnknownOptions is true.
+func (fs *FlagSet) UnknownArgs() []string {
+	return fs.unknownArgs
+}
+
+// Arg returns the i-th positional argument collected by [*FlagSet.Parse]
+// or an empty string if the requested argument does not exist.
+func (fs *FlagSet) Arg(i int) string {
+	if i < 0 || i >= len(fs.positionals) {
+		return ""
+	}
+	return fs.positionals[i]
+}
+
+// NArg returns the number of positional arguments collected by [*FlagSet.Parse].
+func (fs *FlagSet) NArg() int {
+	return len(fs.positionals)
+}
+
+// NFlag returns the number of flags that [*FlagSet.Parse] found on the command line.
+//
+// The short and long forms of a flag, such as the flags registered by
+// [*FlagSet.StringVar], count as a single flag. Flags are counted once
+// regardless of how many times they appear on the command line.
+func (fs *FlagSet) NFlag() int {
+	var count int
+	for _, fx := range fs.ShortFlags {
+		if fx.occurrences > 0 {
+			count++
+		}
+	}
+	for _, fx := range fs.LongFlags {
+		if fx.occurrences > 0 && !slices.ContainsFunc(fs.ShortFlags, func(sfx *ShortFlag) bool {
+			return sfx.occurrences > 0 && sameFlag(sfx, fx)
+		}) {
+			count++
+		}
+	}
+	return count
+}
+
+// Changed returns whether [*FlagSet.Parse] found the flag with the given name
+// on the command line, which allows distinguishing values set on the command
+// line from defaults and from values loaded using [*ConfigLoader.Load].
+//
+// The name is either a long flag name or alias (e.g., `output`), or a short
+// flag name (e.g., `o`). The short and long forms of a flag, such as the
+// flags registered by [*FlagSet.StringVar], count as the same flag (see
+// the [*LongFlag] ShortName), therefore, `-o FILE` changes both `o` and `output`.
+//
+// Use [*ShortFlag.WasSet] and [*LongFlag.WasSet] to query individual flags.
+func (fs *FlagSet) Changed(name string) bool {
+	return fs.Occurrences(name) > 0
+}
+
+// Occurrences returns how many times [*FlagSet.Parse] found the flag with the
+// given name on the command line, regardless of the [Value] type, which allows,
+// for example, to warn when a flag is repeated.
+//
+// The name is either a long flag name or alias, or a short flag name. Like in
+// [*FlagSet.Changed], the short and long forms of a flag count as the same flag,
+// therefore, `-o FILE --output FILE` counts as two occurrences of `output`.
+func (fs *FlagSet) Occurrences(name string) (count int) {
+	shorts, longs := fs.linkedFlags(name)
+	for _, fx := range shorts {
+		count += fx.occurrences
+	}
+	for _, fx := range longs {
+		count += fx.occurrences
+	}
+	return
+}
+
+// linkedFlags returns the short flags and the long flags with the given name,
+// or alias, and their short or long forms (see [sameFlag]).
+func (fs *FlagSet) linkedFlags(name string) (shorts []*ShortFlag, longs []*LongFlag) {
+	named := fs.lookupLongFlag(name)
+	for _, fx := range fs.ShortFlags {
+		if string(fx.Name) == name || (named != nil && sameFlag(fx, named)) {
+			shorts = append(shorts, fx)
+		}
+	}
+	for _, fx := range fs.LongFlags {
+		if fx == named || slices.ContainsFunc(shorts, func(sfx *ShortFlag) bool { return sameFlag(sfx, fx) }) {
+			longs = append(longs, fx)
+		}
+	}
+	return
+}
+
+// sameFlag returns whether the short and long flags are the two forms of the same
+// flag, such as the `-o` and `--output` flags registered by [*FlagSet.StringVar],
+// which is the case when the ShortName of the long flag is the short flag name.
+func sameFlag(sfx *ShortFlag, lfx *LongFlag) bool {
+	return lfx.ShortName != 0 && lfx.ShortName == sfx.Name
+}
+
 // Parse parses the given command line arguments, It assigns positional arguments
 // and each flag [Value] as a side effect of parsing.
 //
@@ -239,9 +860,73 @@ func (fs *FlagSet) Args() []string {
 // Depending on the [ErrorHandling] policy, on failure, this method may return the
 // error, invoke [os.Exit], or call panic with the error that occurred.
 //
-// This method panics if a long flag has the same name as a short flag.
+// This method panics if a long flag, or one of its aliases, has the same name
+// as a short flag or as another long flag.
+//
+// To parse the arguments in several batches, see [*FlagSet.ParsePartial].
 func (fs *FlagSet) Parse(args []string) error {
-	return fs.maybeHandleError(fs.parse(args))
+	return fs.maybeHandleError(fs.parse(args, true))
+}
+
+// ParsePartial is like [*FlagSet.Parse] but parses a batch of arguments preceding
+// the ones parsed by a later [*FlagSet.Parse] call (e.g., arguments read from a
+// configuration file before the command line arguments). For example:
+//
+//	fset.ParsePartial([]string{"--retry", "3", "--verbose"})
+//	fset.Parse(os.Args[1:])
+//
+// The calls accumulate, with the following semantics:
+//
+//   - the flags assigned by a later call override the ones assigned by the earlier
+//     calls, except for the values accumulating the occurrences (e.g., slices);
+//
+//   - the [DuplicatePolicy] and the MaxOccurrences apply within each call, such
+//     that a later call may override a flag that does not allow repetitions;
+//
+//   - [*FlagSet.Changed], [*FlagSet.Occurrences], and the required flags checks
+//     count the occurrences across all the calls;
+//
+//   - the positional arguments of all the calls are concatenated in order.
+//
+// We never reset this state, therefore, use a new [*FlagSet] for parsing
+// another sequence of calls.
+//
+// The final [*FlagSet.Parse] call considers all the calls when checking the
+// positional arguments limits and the required flags, assigning the named
+// positional arguments, the config values, and the environment variables,
+// and running the validators, which ParsePartial does not do.
+func (fs *FlagSet) ParsePartial(args []string) error {
+	return fs.maybeHandleError(fs.parse(args, false))
+}
+
+// ParseString is like [*FlagSet.Parse] but splits the given command line into
+// arguments using the POSIX shell quoting rules, without performing expansions,
+// which is useful for REPLs, configuration files, and tests. For example:
+//
+//	fset.ParseString(`-o "my file.txt" https://example.com`)
+//
+// Like for [*FlagSet.Parse], the command line MUST NOT contain the program name.
+// Failing to split the command line (e.g., because of an unterminated quote)
+// is subject to the [ErrorHandling] policy like any other parse error.
+func (fs *FlagSet) ParseString(cmdline string) error {
+	args, err := splitShellWords(cmdline)
+	if err != nil {
+		return fs.maybeHandleError(err)
+	}
+	return fs.Parse(args)
+}
+
+// ParseOSArgs is like [*FlagSet.Parse] but parses os.Args[1:]. If ProgramName
+// is empty, this method sets it to the base name of os.Args[0] before parsing.
+func (fs *FlagSet) ParseOSArgs() error {
+	var args []string
+	if len(os.Args) > 1 {
+		args = os.Args[1:]
+	}
+	if fs.ProgramName == "" && len(os.Args) > 0 {
+		fs.ProgramName = filepath.Base(os.Args[0])
+	}
+	return fs.Parse(args)
 }
 
 // ErrHelp is the error returned in case the user requested for `help`.
@@ -251,46 +936,206 @@ func (fs *FlagSet) Parse(args []string) error {
 // This error is never returned when using the [ExitOnError] policy.
 var ErrHelp = errors.New("help requested")
 
-func (fs *FlagSet) parse(args []string) error {
-	// configure the command line parser
+func (fs *FlagSet) parse(args []string, final bool) error {
+	// configure the command line parser accounting for the positional
+	// arguments collected by previous [*FlagSet.ParsePartial] calls
 	px := &flagparser.Parser{
-		DisablePermute:            fs.DisablePermute,
+		DisablePermute:            fs.permuteDisabled(),
 		MaxPositionalArguments:    fs.MaxPositionalArgs,
-		MinPositionalArguments:    fs.MinPositionalArgs,
+		MinPositionalArguments:    max(fs.MinPositionalArgs-len(fs.positionals), 0),
 		OptionsArgumentsSeparator: fs.OptionsArgumentsSeparator,
 		Options:                   []*flagparser.Option{},
 	}
+	if fs.MaxPositionalArgs != math.MaxInt {
+		px.MaxPositionalArguments = max(fs.MaxPositionalArgs-len(fs.positionals), 0)
+	}
+	if !final {
+		px.MinPositionalArguments = 0
+	}
+	if fs.StopAtFirstPositional {
+		px.DisablePermute = true
+		px.MinPositionalArguments = 0
+		px.MaxPositionalArguments = math.MaxInt
+	}
 
-	// build options and value map from short flags
+	// build options and value map from short flags, where the groupable flags
+	// with a non-ASCII name use internal names (see [internalNames])
 	pview := make(map[string]Value)
+	pcount := make(map[string]*int)
+	pmax := make(map[string]int)
+	ppolicy := make(map[string]DuplicatePolicy)
+	pflag := make(map[string]string)
+	names := &internalNames{}
 	for _, fx := range fs.ShortFlags {
 		opt := fx.MakeOption(fx)
+		if fs.DisableGrouping {
+			opt.Type = standaloneOptionType(opt)
+		}
 		px.Options = append(px.Options, opt)
-		pview[opt.Name] = fx.Value
+		key := opt.Name
+		if len(opt.Name) > 1 && findGroupableOption(opt.Prefix, opt.Name, []*flagparser.Option{opt}) != nil {
+			key = names.add(opt)
+		}
+		pview[key] = fx.Value
+		pcount[key] = &fx.occurrences
+		pmax[key] = fx.MaxOccurrences
+		ppolicy[key] = fx.OnDuplicate
+		pflag[key] = "-" + string(fx.Name)
 	}
 
-	// build options and value map from long flags
-	for _, fx := range fs.LongFlags {
-		opt := fx.MakeOption(fx)
-		_, found := pview[opt.Name]
-		runtimex.Assert(!found)
+	// build options and value map from the negated short flags, which use
+	// internal names since the parser requires each name to appear once
+	// regardless of the prefix (i.e., `+x` and `-x` cannot both be `x`)
+	for _, fx := range fs.ShortFlags {
+		if fx.NegatedPrefix == "" {
+			continue
+		}
+		opt := &flagparser.Option{
+			Type:   flagparser.OptionTypeGroupableArgumentNone,
+			Prefix: fx.NegatedPrefix,
+			Name:   string(fx.Name),
+		}
 		px.Options = append(px.Options, opt)
-		pview[opt.Name] = fx.Value
+		key := names.add(opt)
+		pview[key] = valueNegated{fx.Value}
+		pcount[key] = &fx.occurrences
+		pmax[key] = fx.MaxOccurrences
+		ppolicy[key] = fx.OnDuplicate
+		pflag[key] = "-" + string(fx.Name)
 	}
 
-	// parse the command line
-	values, err := px.Parse(args)
+	// build options and value map from long flags, their aliases, and their negations,
+	// where a long flag linked to a short flag shares the short flag's identity
+	for _, fx := range fs.LongFlags {
+		identity := "--" + fx.Name
+		if fx.ShortName != 0 {
+			identity = "-" + string(fx.ShortName)
+		}
+		for _, entry := range fx.makeOptions() {
+			_, found := pview[entry.option.Name]
+			runtimex.Assert(!found)
+			px.Options = append(px.Options, entry.option)
+			pview[entry.option.Name] = entry.value
+			pcount[entry.option.Name] = &fx.occurrences
+			pmax[entry.option.Name] = fx.MaxOccurrences
+			ppolicy[entry.option.Name] = fx.OnDuplicate
+			pflag[entry.option.Name] = identity
+		}
+	}
+
+	// expand the response files, if needed
+	if fs.ExpandResponseFiles {
+		expanded, err := fs.expandResponseFiles(args, px.Options, 0)
+		if err != nil {
+			return err
+		}
+		args = expanded
+	}
+
+	// rewrite the short flags using a prefix alias, if needed
+	args = fs.rewritePrefixAliases(args, px.Options)
+
+	// rewrite the long flags using a different case (e.g., `--Output`), if needed
+	args = fs.rewriteCaseInsensitive(args, px.Options)
+
+	// rewrite the abbreviated long flags (e.g., `--verb`), if needed
+	args, err := fs.rewriteAbbreviations(args, px.Options)
 	if err != nil {
 		return err
 	}
 
+	// rewrite the numeric shorthands (e.g., `-5`), if needed
+	args, err = fs.rewriteNumericShorthands(args, px.Options)
+	if err != nil {
+		return err
+	}
+
+	// rewrite the long flags using a custom value separator, if needed
+	args = fs.rewriteValueSeparators(args, px.Options)
+
+	// rewrite the short flags with an optional value used without a value, if needed
+	args = fs.rewriteOptionalValues(args, px.Options)
+
+	// handle `--help=short` and `--help=full`
+	fs.helpLevel, fs.helpTopic = HelpFull, ""
+	if level, found := fs.findHelpLevel(args); found {
+		fs.helpLevel = level
+		return ErrHelp
+	}
+
+	// handle `--help FLAG` and `--help=FLAG`, if needed
+	if fs.PerFlagHelp {
+		if topic, found := fs.findHelpTopic(args); found {
+			fs.helpTopic = topic
+			return ErrHelp
+		}
+	}
+
+	// enforce the short flags attached value policies, if needed
+	if err := fs.checkAttachedValues(args, px.Options); err != nil {
+		return err
+	}
+
+	// rename the options that need an internal name and rewrite accordingly
+	args = names.apply(fs, args, px.Options)
+
+	// reject the values starting with `-` given as separate arguments, if needed
+	if err := fs.checkDashValues(args, px.Options); err != nil {
+		return err
+	}
+
+	// enforce using `=` for the long flags values, if needed
+	if err := fs.checkRequireEquals(args, px.Options); err != nil {
+		return err
+	}
+
+	// hide the bare dashes from the parser, if needed
+	if fs.DashIsPositional {
+		args = slices.Clone(args)
+		for idx, arg := range args {
+			if arg == "-" {
+				args[idx] = dashPlaceholder
+			}
+		}
+	}
+
+	// parse the command line
+	var errs []error
+	values, err := px.Parse(args)
+	for err != nil {
+		idx := errorTokenIndex(err)
+
+		// when collecting unknown options, move them aside and retry
+		if errors.As(err, &flagparser.ErrUnknownOption{}) && fs.CollectUnknownOptions && idx >= 0 {
+			fs.unknownArgs = append(fs.unknownArgs, names.restore(restoreDash(args[idx])))
+			args = slices.Delete(slices.Clone(args), idx, idx+1)
+			values, err = px.Parse(args)
+			continue
+		}
+
+		// when collecting errors, skip the offending token and retry
+		errs = append(errs, fs.withPositionalArgsMessage(names.restoreError(withSuggestions(err, px.Options))))
+		if !fs.CollectAllErrors || idx < 0 {
+			return errors.Join(errs...)
+		}
+		args = slices.Delete(slices.Clone(args), idx, idx+1)
+		values, err = px.Parse(args)
+	}
+
 	// map the parsed values back to options and positionals
+	seen := make(map[string]int)
 	for _, value := range values {
 		switch value := value.(type) {
 
 		// positional argument: just add to the internal slice of positionals
 		case flagparser.ValuePositionalArgument:
-			fs.positionals = append(fs.positionals, value.Value)
+			fs.positionals = append(fs.positionals, restoreDash(value.Value))
+
+		// separator: add to the positionals if we need to retain it
+		case flagparser.ValueOptionsArgumentsSeparator:
+			if fs.RetainOptionsArgumentsSeparator {
+				fs.positionals = append(fs.positionals, value.Separator)
+			}
 
 		// option: find the corresponding value and attempt to set it
 		case flagparser.ValueOption:
@@ -298,18 +1143,192 @@ func (fs *FlagSet) parse(args []string) error {
 			val, found := pview[optname]
 			runtimex.Assert(found) // should not happen
 
+			// count the occurrences of the flag including its short or long form
+			seen[pflag[optname]]++
+			occurrences := seen[pflag[optname]]
+
+			// apply the duplicate policy and the maximum number of occurrences
+			limit := pmax[optname]
+			if occurrences > 1 && ppolicy[optname] == DuplicateFirstWins {
+				*pcount[optname]++
+				continue
+			}
+			if ppolicy[optname] == DuplicateError {
+				limit = 1
+			}
+			if limit > 0 && occurrences > limit {
+				err := names.restoreError(tooManyOccurrences(value.Option, limit))
+				if !fs.CollectAllErrors {
+					return err
+				}
+				errs = append(errs, err)
+				continue
+			}
+
 			// assign a value to the flag
-			if err := val.Set(value.Value); err != nil {
-				return err
+			if err := val.Set(restoreDash(value.Value)); err != nil {
+				if !fs.CollectAllErrors {
+					return err
+				}
+				errs = append(errs, err)
+				continue
 			}
+			*pcount[optname]++
 
 			// detect [ValueAutoHelp] and transform it to [ErrHelp]
-			if _, ok := val.(ValueAutoHelp); ok {
+			if help, ok := val.(ValueAutoHelp); ok {
+				fs.helpLevel = help.Level
 				return ErrHelp
 			}
 		}
 	}
-	return nil
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// defer the remaining steps to the final [*FlagSet.Parse] call
+	if !final {
+		return nil
+	}
+
+	// assign the config and environment values for flags not given on the command line
+	if err := fs.applyConfig(); err != nil {
+		return err
+	}
+
+	// make sure all the required flags have been set
+	if errs := fs.checkRequired(); len(errs) > 0 {
+		if !fs.CollectAllErrors {
+			return errs[0]
+		}
+		return errors.Join(errs...)
+	}
+
+	// assign the positional arguments to the named positional arguments
+	if errs := fs.assignPositionals(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// run the validators now that all the flags have been assigned
+	for _, validate := range fs.validators {
+		if err := validate(fs); err != nil {
+			if !fs.CollectAllErrors {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// permuteDisabled returns whether we should not permute the arguments, which
+// happens when DisablePermute or PosixlyCorrect is true or when the
+// POSIXLY_CORRECT environment variable is set.
+func (fs *FlagSet) permuteDisabled() bool {
+	_, posixlyCorrect := os.LookupEnv("POSIXLY_CORRECT")
+	return fs.DisablePermute || fs.PosixlyCorrect || posixlyCorrect
+}
+
+// standaloneOptionType returns the standalone [flagparser.OptionType] equivalent
+// to the type of the given groupable option, or its type if it is not groupable.
+func standaloneOptionType(option *flagparser.Option) flagparser.OptionType {
+	switch {
+	case option.Type == flagparser.OptionTypeGroupableArgumentNone:
+		return flagparser.OptionTypeStandaloneArgumentNone
+	case option.Type == flagparser.OptionTypeGroupableArgumentRequired && option.DefaultValue != "":
+		return flagparser.OptionTypeStandaloneArgumentOptional
+	case option.Type == flagparser.OptionTypeGroupableArgumentRequired:
+		return flagparser.OptionTypeStandaloneArgumentRequired
+	default:
+		return option.Type
+	}
+}
+
+// dashPlaceholder replaces a bare `-` before parsing when DashIsPositional
+// is true. Because it does not start with `-`, the parser does not consider
+// it an option, and we restore the original `-` after parsing.
+const dashPlaceholder = "\x00-"
+
+// restoreDash undoes replacing a bare `-` with the [dashPlaceholder].
+func restoreDash(arg string) string {
+	if arg == dashPlaceholder {
+		return "-"
+	}
+	return arg
+}
+
+// positionalArgsError is a positional arguments count error using the
+// TooFewPositionalArgsMessage or TooManyPositionalArgsMessage message.
+type positionalArgsError struct {
+	err     error
+	message string
+}
+
+// Error implements error.
+func (e *positionalArgsError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original parser error.
+func (e *positionalArgsError) Unwrap() error {
+	return e.err
+}
+
+// withPositionalArgsMessage replaces the message of a positional arguments
+// count error, if configured. Otherwise, it returns the original error.
+func (fs *FlagSet) withPositionalArgsMessage(err error) error {
+	var (
+		tooFew  flagparser.ErrTooFewPositionalArguments
+		tooMany flagparser.ErrTooManyPositionalArguments
+		message string
+		have    int
+		minimum = fs.MinPositionalArgs
+		maximum = fs.MaxPositionalArgs
+	)
+	switch {
+	case errors.As(err, &tooFew):
+		message, have, minimum = fs.TooFewPositionalArgsMessage, tooFew.Have, tooFew.Min
+	case errors.As(err, &tooMany):
+		message, have, maximum = fs.TooManyPositionalArgsMessage, tooMany.Have, tooMany.Max
+	}
+	if message == "" {
+		return err
+	}
+	message = strings.NewReplacer(
+		"@MIN@", strconv.Itoa(minimum),
+		"@MAX@", strconv.Itoa(maximum),
+		"@HAVE@", strconv.Itoa(have),
+	).Replace(message)
+	return &positionalArgsError{err: err, message: message}
+}
+
+// tooManyOccurrences returns the error for an option exceeding the given limit.
+func tooManyOccurrences(option *flagparser.Option, limit int) error {
+	if limit == 1 {
+		return fmt.Errorf("option %s%s specified multiple times", option.Prefix, option.Name)
+	}
+	return fmt.Errorf("option %s%s specified more than %d times", option.Prefix, option.Name, limit)
+}
+
+// errorTokenIndex returns the index within the parsed arguments of the token
+// that caused the given [*flagparser.Parser] error, or -1 if the error is not
+// caused by a token (e.g., too many positional arguments).
+func errorTokenIndex(err error) int {
+	var (
+		unknown    flagparser.ErrUnknownOption
+		required   flagparser.ErrOptionRequiresArgument
+		noArgument flagparser.ErrOptionRequiresNoArgument
+	)
+	switch {
+	case errors.As(err, &unknown):
+		return unknown.Token.Index()
+	case errors.As(err, &required):
+		return required.Token.Index()
+	case errors.As(err, &noArgument):
+		return noArgument.Token.Index()
+	default:
+		return -1
+	}
 }
 
 func (fs *FlagSet) maybeHandleError(err error) error {
@@ -320,10 +1339,29 @@ func (fs *FlagSet) maybeHandleError(err error) error {
 	case fs.ErrorHandling == ContinueOnError:
 		return err
 
+	case fs.ErrorHandling == ExitOnError && errors.Is(err, ErrHelp) && fs.helpTopic != "":
+		fs.PrintFlagHelp(fs.Stdout, fs.helpTopic)
+		fs.Exit(0)
+
+	case fs.ErrorHandling == ExitOnError && errors.Is(err, ErrHelp) && fs.helpLevel == HelpShort:
+		fs.PrintShortUsage(fs.Stdout)
+		fs.Exit(0)
+
+	case fs.ErrorHandling == ExitOnError && errors.Is(err, ErrHelp) && fs.Usage != nil:
+		fs.Usage()
+		fs.Exit(0)
+
 	case fs.ErrorHandling == ExitOnError && errors.Is(err, ErrHelp):
 		fs.PrintUsageString(fs.Stdout)
 		fs.Exit(0)
 
+	case fs.ErrorHandling == ExitOnError && fs.Usage != nil:
+		for line := range strings.SplitSeq(err.Error(), "\n") {
+			must.Fprintf(fs.Stderr, "%s: %s\n", fs.ProgramName, line)
+		}
+		fs.Usage()
+		fs.Exit(2)
+
 	case fs.ErrorHandling == ExitOnError:
 		fs.PrintUsageError(fs.Stderr, err)
 		fs.Exit(2)
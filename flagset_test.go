@@ -7,9 +7,15 @@
 package vflag
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 
+	"github.com/bassosimone/flagparser"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFlagSetSetInvalidValue(t *testing.T) {
@@ -79,3 +85,743 @@ func TestFlagSetParseLongFlagPanicsOnEmptyPrefix(t *testing.T) {
 		fset.Parse([]string{})
 	})
 }
+
+func TestFlagSetChanged(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *int) {
+		fset := NewFlagSet("test", ContinueOnError)
+		var (
+			output string
+			count  int
+		)
+		fset.StringVar(&output, 'o', "output", "Write output to file.")
+		fset.IntVar(&count, 'n', "count", "Set count.")
+		fset.AddLongFlagAliases("output", "out")
+		return fset, &output, &count
+	}
+
+	t.Run("not changed before parsing", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		assert.False(t, fset.Changed("output"))
+		assert.False(t, fset.Changed("o"))
+		assert.False(t, fset.Changed("nonexistent"))
+	})
+
+	t.Run("short flag changes the long flag", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-o", "out.txt"}))
+		assert.True(t, fset.Changed("o"))
+		assert.True(t, fset.Changed("output"))
+		assert.True(t, fset.Changed("out"))
+		assert.False(t, fset.Changed("count"))
+		assert.False(t, fset.Changed("n"))
+		assert.True(t, fset.ShortFlags[0].WasSet())
+		assert.False(t, fset.LongFlags[0].WasSet())
+	})
+
+	t.Run("alias changes the short flag", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"--out", "out.txt"}))
+		assert.True(t, fset.Changed("o"))
+		assert.True(t, fset.Changed("output"))
+		assert.True(t, fset.LongFlags[0].WasSet())
+	})
+
+	t.Run("short flags with non-comparable values change the long flag", func(t *testing.T) {
+		var color string
+		fset := NewFlagSet("test", ContinueOnError)
+		fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		fset.Func('n', "name", "Set the name.", func(string) error { return nil })
+		require.NoError(t, fset.Parse([]string{"-c", "blue", "-n", "x", "--name", "y"}))
+		assert.True(t, fset.Changed("color"))
+		assert.Equal(t, 1, fset.Occurrences("color"))
+		assert.Equal(t, 2, fset.Occurrences("n"))
+		assert.Equal(t, 2, fset.Occurrences("name"))
+	})
+
+	t.Run("explicit zero value is a change", func(t *testing.T) {
+		fset, _, count := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"--count=0"}))
+		assert.True(t, fset.Changed("count"))
+		assert.Equal(t, 0, *count)
+	})
+
+	t.Run("config values are not a change", func(t *testing.T) {
+		fset, output, _ := newFlagSet()
+		cl := newTestConfigLoader(map[string]string{"config.json": `{"output": "config.txt"}`})
+		require.NoError(t, cl.Load(fset, "config.json"))
+		require.NoError(t, fset.Parse([]string{}))
+		assert.Equal(t, "config.txt", *output)
+		assert.False(t, fset.Changed("output"))
+	})
+
+	t.Run("negation is a change", func(t *testing.T) {
+		fset := NewFlagSet("test", ContinueOnError)
+		var color bool
+		fset.NegatableBoolVar(&color, 0, "color", "Colorize the output.")
+		require.NoError(t, fset.Parse([]string{"--no-color"}))
+		assert.True(t, fset.Changed("color"))
+	})
+}
+
+func TestFlagSetArgAccessors(t *testing.T) {
+	fset := NewFlagSet("test", ContinueOnError)
+	fset.SetMinMaxPositionalArgs(0, 4)
+	var (
+		count   int
+		output  string
+		verbose bool
+	)
+	fset.IntVar(&count, 'n', "count", "Set count.")
+	fset.StringVar(&output, 'o', "output", "Write output to file.")
+	fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+
+	assert.Equal(t, 0, fset.NArg())
+	assert.Equal(t, 0, fset.NFlag())
+	assert.Equal(t, "", fset.Arg(0))
+
+	require.NoError(t, fset.Parse([]string{"-v", "a", "--output", "x", "-o", "y", "--verbose", "b"}))
+
+	assert.Equal(t, 2, fset.NArg())
+	assert.Equal(t, "a", fset.Arg(0))
+	assert.Equal(t, "b", fset.Arg(1))
+	assert.Equal(t, "", fset.Arg(2))
+	assert.Equal(t, "", fset.Arg(-1))
+	assert.Equal(t, 2, fset.NFlag())
+
+	t.Run("short flags with non-comparable values", func(t *testing.T) {
+		var color string
+		fset := NewFlagSet("test", ContinueOnError)
+		fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		fset.Func('n', "name", "Set the name.", func(string) error { return nil })
+		require.NoError(t, fset.Parse([]string{"-c", "blue", "--color", "red", "--name", "x"}))
+		assert.Equal(t, 2, fset.NFlag())
+	})
+}
+
+func TestFlagSetLongFlagAliases(t *testing.T) {
+	t.Run("aliases set the same value", func(t *testing.T) {
+		fset := NewFlagSet("test", ContinueOnError)
+		var color string
+		fset.StringVar(&color, 0, "color", "Colorize the output.")
+		fset.AddLongFlagAliases("color", "colour")
+
+		require.NoError(t, fset.Parse([]string{"--colour", "always"}))
+		assert.Equal(t, "always", color)
+	})
+
+	t.Run("AddLongFlagAliases panics on unknown flag", func(t *testing.T) {
+		fset := NewFlagSet("test", ContinueOnError)
+		assert.Panics(t, func() {
+			fset.AddLongFlagAliases("color", "colour")
+		})
+	})
+
+	t.Run("Parse panics on alias clashing with another flag", func(t *testing.T) {
+		fset := NewFlagSet("test", ContinueOnError)
+		var color, colour string
+		fset.StringVar(&color, 0, "color", "Colorize the output.")
+		fset.StringVar(&colour, 0, "colour", "Colourise the output.")
+		fset.AddLongFlagAliases("color", "colour")
+
+		assert.Panics(t, func() {
+			fset.Parse([]string{})
+		})
+	})
+}
+
+func TestFlagSetCollectAllErrors(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *int, *string) {
+		var (
+			count  int
+			output string
+		)
+		fset := NewFlagSet("test", ContinueOnError)
+		fset.CollectAllErrors = true
+		fset.SetMinMaxPositionalArgs(0, 1)
+		fset.IntVar(&count, 'n', "count", "Set count.")
+		fset.StringVar(&output, 'o', "output", "Write output to file.")
+		return fset, &count, &output
+	}
+
+	t.Run("reports every problem", func(t *testing.T) {
+		fset, count, output := newFlagSet()
+		err := fset.Parse([]string{"--verbose", "-n", "x", "--outptu", "a", "-o", "out.txt", "-n", "7", "--count"})
+		require.Error(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"unknown option: --verbose",
+			"unknown option: --outptu (did you mean --output?)",
+			"option requires an argument: --count",
+			`strconv.ParseInt: parsing "x": invalid syntax`,
+		}, "\n"), err.Error())
+
+		// the valid flags are assigned anyway
+		assert.Equal(t, 7, *count)
+		assert.Equal(t, "out.txt", *output)
+		assert.Equal(t, []string{"a"}, fset.Args())
+	})
+
+	t.Run("finds the tokens with a value", func(t *testing.T) {
+		fset, _, output := newFlagSet()
+		err := fset.Parse([]string{"--verbose=1", "-o", "out.txt", "--colour=auto"})
+		require.Error(t, err)
+		assert.Equal(t, "unknown option: --verbose\nunknown option: --colour", err.Error())
+		assert.Equal(t, "out.txt", *output)
+	})
+
+	t.Run("stops at errors that do not refer to a token", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		err := fset.Parse([]string{"--verbose", "a", "b"})
+		require.Error(t, err)
+		assert.Equal(t, 2, len(strings.Split(err.Error(), "\n")))
+	})
+
+	t.Run("no errors", func(t *testing.T) {
+		fset, count, _ := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-n", "1"}))
+		assert.Equal(t, 1, *count)
+	})
+
+	t.Run("ExitOnError prints every problem", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		fset.ErrorHandling = ExitOnError
+		var stderr strings.Builder
+		fset.Stderr = &stderr
+		fset.Exit = func(status int) { panic(status) }
+		assert.PanicsWithValue(t, 2, func() {
+			fset.Parse([]string{"--verbose", "-n", "x"})
+		})
+		assert.Equal(t, "test: unknown option: --verbose\n"+
+			`test: strconv.ParseInt: parsing "x": invalid syntax`+"\n", stderr.String())
+	})
+}
+
+func TestFlagSetCollectUnknownOptions(t *testing.T) {
+	var verbose bool
+	fset := NewFlagSet("wrapper", ContinueOnError)
+	fset.CollectUnknownOptions = true
+	fset.SetMinMaxPositionalArgs(0, 2)
+	fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+
+	err := fset.Parse([]string{"--jobs=4", "-v", "--color=always", "--dry-run", "file.txt", "-x", "--", "--literal"})
+	require.NoError(t, err)
+
+	assert.True(t, verbose)
+	assert.Equal(t, []string{"--jobs=4", "--color=always", "--dry-run", "-x"}, fset.UnknownArgs())
+	assert.Equal(t, []string{"file.txt", "--literal"}, fset.Args())
+}
+
+func TestFlagSetStopAtFirstPositional(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string) {
+		var dir string
+		fset := NewFlagSet("git", ContinueOnError)
+		fset.StopAtFirstPositional = true
+		fset.StringVar(&dir, 'C', "", "Run as if started in DIR.")
+		return fset, &dir
+	}
+
+	t.Run("stops at the first positional", func(t *testing.T) {
+		fset, dir := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-C", "dir", "commit", "-m", "msg", "--amend"}))
+		assert.Equal(t, "dir", *dir)
+		assert.Equal(t, []string{"commit", "-m", "msg", "--amend"}, fset.Remainder())
+		assert.Equal(t, fset.Args(), fset.Remainder())
+	})
+
+	t.Run("empty remainder", func(t *testing.T) {
+		fset, _ := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-C", "dir"}))
+		assert.Empty(t, fset.Remainder())
+	})
+
+	t.Run("ignores the positional arguments limits", func(t *testing.T) {
+		fset, _ := newFlagSet()
+		fset.SetMinMaxPositionalArgs(1, 1)
+		require.NoError(t, fset.Parse([]string{"remote", "add", "origin"}))
+		assert.Equal(t, []string{"remote", "add", "origin"}, fset.Remainder())
+	})
+
+	t.Run("nil when disabled", func(t *testing.T) {
+		fset, _ := newFlagSet()
+		fset.StopAtFirstPositional = false
+		fset.SetMinMaxPositionalArgs(0, 1)
+		require.NoError(t, fset.Parse([]string{"commit"}))
+		assert.Nil(t, fset.Remainder())
+	})
+}
+
+func TestFlagSetPosixlyCorrect(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *bool) {
+		var verbose bool
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.BoolVar(&verbose, 'v', "verbose", "Run verbosely.")
+		fset.SetMinMaxPositionalArgs(0, math.MaxInt)
+		return fset, &verbose
+	}
+
+	t.Run("permutes by default", func(t *testing.T) {
+		fset, verbose := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"file.txt", "-v"}))
+		assert.True(t, *verbose)
+		assert.Equal(t, []string{"file.txt"}, fset.Args())
+	})
+
+	t.Run("field", func(t *testing.T) {
+		fset, verbose := newFlagSet()
+		fset.PosixlyCorrect = true
+		require.NoError(t, fset.Parse([]string{"file.txt", "-v"}))
+		assert.False(t, *verbose)
+		assert.Equal(t, []string{"file.txt", "-v"}, fset.Args())
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv("POSIXLY_CORRECT", "")
+		fset, verbose := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"file.txt", "-v"}))
+		assert.False(t, *verbose)
+		assert.Equal(t, []string{"file.txt", "-v"}, fset.Args())
+	})
+}
+
+func TestFlagSetRetainOptionsArgumentsSeparator(t *testing.T) {
+	newFlagSet := func(retain bool) *FlagSet {
+		var verbose bool
+		fset := NewFlagSet("wrapper", ContinueOnError)
+		fset.RetainOptionsArgumentsSeparator = retain
+		fset.SetMinMaxPositionalArgs(0, math.MaxInt)
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		return fset
+	}
+
+	t.Run("retained", func(t *testing.T) {
+		fset := newFlagSet(true)
+		require.NoError(t, fset.Parse([]string{"-v", "--", "-x", "file"}))
+		assert.Equal(t, []string{"--", "-x", "file"}, fset.Args())
+	})
+
+	t.Run("not retained", func(t *testing.T) {
+		fset := newFlagSet(false)
+		require.NoError(t, fset.Parse([]string{"-v", "--", "-x", "file"}))
+		assert.Equal(t, []string{"-x", "file"}, fset.Args())
+	})
+
+	t.Run("no separator", func(t *testing.T) {
+		fset := newFlagSet(true)
+		require.NoError(t, fset.Parse([]string{"-v", "file"}))
+		assert.Equal(t, []string{"file"}, fset.Args())
+	})
+}
+
+func TestFlagSetAddValidator(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			tlsCert string
+			tlsKey  string
+		)
+		fset := NewFlagSet("server", ContinueOnError)
+		fset.StringVar(&tlsCert, 0, "tls-cert", "Use the given TLS certificate.")
+		fset.StringVar(&tlsKey, 0, "tls-key", "Use the given TLS key.")
+		fset.AddValidator(func(fs *FlagSet) error {
+			if fs.Changed("tls-cert") != fs.Changed("tls-key") {
+				return errors.New("--tls-cert and --tls-key must be used together")
+			}
+			return nil
+		})
+		return fset
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		fset := newFlagSet()
+		assert.NoError(t, fset.Parse([]string{"--tls-cert", "cert.pem", "--tls-key", "key.pem"}))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fset := newFlagSet()
+		err := fset.Parse([]string{"--tls-cert", "cert.pem"})
+		assert.EqualError(t, err, "--tls-cert and --tls-key must be used together")
+	})
+
+	t.Run("collect all errors", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.CollectAllErrors = true
+		fset.AddValidator(func(fs *FlagSet) error {
+			return errors.New("second validator")
+		})
+		err := fset.Parse([]string{"--tls-key", "key.pem"})
+		assert.EqualError(t, err, "--tls-cert and --tls-key must be used together\nsecond validator")
+	})
+
+	t.Run("not run on parse errors", func(t *testing.T) {
+		fset := newFlagSet()
+		err := fset.Parse([]string{"--tls-cert"})
+		assert.EqualError(t, err, "option requires an argument: --tls-cert")
+	})
+
+	t.Run("exit on error", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.ErrorHandling = ExitOnError
+		var stderr strings.Builder
+		fset.Stderr = &stderr
+		fset.Exit = func(status int) {
+			panic(status)
+		}
+		assert.PanicsWithValue(t, 2, func() {
+			fset.Parse([]string{"--tls-key", "key.pem"})
+		})
+		assert.Contains(t, stderr.String(), "--tls-cert and --tls-key must be used together")
+	})
+}
+
+func TestFlagSetMaxOccurrences(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			color   string
+			output  string
+			verbose int
+		)
+		fset := NewFlagSet("prog", ContinueOnError)
+		sf := fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		sf.ShortFlag.MaxOccurrences = 1
+		sf.LongFlag.MaxOccurrences = 1
+		fset.CountVar(&verbose, 'v', "verbose", "Increase verbosity.")
+		fset.ShortFlags[1].MaxOccurrences = 2
+		fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		fset.ShortFlags[2].MaxOccurrences = 1
+		fset.LongFlags[2].MaxOccurrences = 1
+		return fset
+	}
+
+	cases := []struct {
+		args   []string
+		expect string
+	}{
+		{[]string{"-o", "a", "-vv"}, ""},
+		{[]string{"--output", "a", "--output", "b"}, "option --output specified multiple times"},
+		{[]string{"-o", "a", "--output", "b"}, "option --output specified multiple times"},
+		{[]string{"-vvv"}, "option -v specified more than 2 times"},
+		{[]string{"-v", "--verbose", "-v"}, "option -v specified more than 2 times"},
+		{[]string{"-v", "--verbose", "--verbose"}, ""}, // the long flag has no limit
+		{[]string{"-c", "red"}, ""},
+		{[]string{"-c", "blue", "--color", "red"}, "option --color specified multiple times"},
+		{[]string{"--color", "blue", "-c", "red"}, "option -c specified multiple times"},
+	}
+	for _, tc := range cases {
+		err := newFlagSet().Parse(tc.args)
+		if tc.expect == "" {
+			assert.NoError(t, err, tc.args)
+			continue
+		}
+		assert.EqualError(t, err, tc.expect, tc.args)
+	}
+}
+
+func TestFlagSetOnDuplicate(t *testing.T) {
+	newFlagSet := func(policy DuplicatePolicy) (*FlagSet, *string) {
+		var output string
+		fset := NewFlagSet("prog", ContinueOnError)
+		sf := fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		sf.ShortFlag.OnDuplicate = policy
+		sf.LongFlag.OnDuplicate = policy
+		return fset, &output
+	}
+
+	t.Run("last wins", func(t *testing.T) {
+		fset, output := newFlagSet(DuplicateLastWins)
+		require.NoError(t, fset.Parse([]string{"-o", "a", "--output", "b"}))
+		assert.Equal(t, "b", *output)
+	})
+
+	t.Run("first wins", func(t *testing.T) {
+		fset, output := newFlagSet(DuplicateFirstWins)
+		require.NoError(t, fset.Parse([]string{"-o", "a", "--output", "b", "-o", "c"}))
+		assert.Equal(t, "a", *output)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		fset, output := newFlagSet(DuplicateError)
+		err := fset.Parse([]string{"-o", "a", "--output", "b"})
+		assert.EqualError(t, err, "option --output specified multiple times")
+		assert.Equal(t, "a", *output)
+	})
+
+	t.Run("error with non-comparable values", func(t *testing.T) {
+		var color string
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		fset.ShortFlags[0].OnDuplicate = DuplicateError
+		fset.LongFlags[0].OnDuplicate = DuplicateError
+		err := fset.Parse([]string{"-c", "blue", "--color", "red"})
+		assert.EqualError(t, err, "option --color specified multiple times")
+		assert.Equal(t, "blue", color)
+	})
+}
+
+func TestFlagSetOccurrences(t *testing.T) {
+	var (
+		headers []string
+		output  string
+		verbose bool
+	)
+	fset := NewFlagSet("prog", ContinueOnError)
+	fset.StringSliceVar(&headers, 'H', "header", "Add the given header.")
+	fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+	fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+	fset.AddLongFlagAliases("header", "hdr")
+
+	args := []string{"-H", "a: b", "--header", "c: d", "--hdr", "e: f", "-o", "x", "-o", "y"}
+	require.NoError(t, fset.Parse(args))
+
+	assert.Equal(t, 3, fset.Occurrences("header"))
+	assert.Equal(t, 3, fset.Occurrences("hdr"))
+	assert.Equal(t, 3, fset.Occurrences("H"))
+	assert.Equal(t, 2, fset.Occurrences("output"))
+	assert.Equal(t, 0, fset.Occurrences("verbose"))
+	assert.Equal(t, 0, fset.Occurrences("nonexistent"))
+}
+
+func TestFlagSetDashIsPositional(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string) {
+		var output string
+		fset := NewFlagSet("cat", ContinueOnError)
+		fset.DashIsPositional = true
+		fset.SetMinMaxPositionalArgs(0, math.MaxInt)
+		fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		return fset, &output
+	}
+
+	t.Run("positional", func(t *testing.T) {
+		fset, output := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"a.txt", "-", "-o", "out.txt", "-"}))
+		assert.Equal(t, []string{"a.txt", "-", "-"}, fset.Args())
+		assert.Equal(t, "out.txt", *output)
+	})
+
+	t.Run("flag value", func(t *testing.T) {
+		fset, output := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-o", "-", "-"}))
+		assert.Equal(t, []string{"-"}, fset.Args())
+		assert.Equal(t, "-", *output)
+	})
+
+	t.Run("does not modify args", func(t *testing.T) {
+		fset, _ := newFlagSet()
+		args := []string{"-"}
+		require.NoError(t, fset.Parse(args))
+		assert.Equal(t, []string{"-"}, args)
+	})
+}
+
+func TestNewJavaStyleFlagSet(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *bool, *bool) {
+		var (
+			classpath string
+			verbose   bool
+			server    bool
+		)
+		fset := NewJavaStyleFlagSet("java", ContinueOnError)
+		fset.AutoHelp('h', "help", "Show this help.")
+		fset.StringVar(&classpath, 0, "cp", "Set the class search `PATH`.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		fset.BoolVar(&server, 's', "server", "Select the server VM.")
+		fset.SetMinMaxPositionalArgs(0, 1)
+		return fset, &classpath, &verbose, &server
+	}
+
+	t.Run("single dash long flags", func(t *testing.T) {
+		fset, classpath, verbose, server := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-cp", "a.jar:b.jar", "-verbose", "-s", "Main"}))
+		assert.Equal(t, "a.jar:b.jar", *classpath)
+		assert.True(t, *verbose)
+		assert.True(t, *server)
+		assert.Equal(t, []string{"Main"}, fset.Args())
+	})
+
+	t.Run("optional equal sign", func(t *testing.T) {
+		fset, classpath, _, _ := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-cp=a.jar"}))
+		assert.Equal(t, "a.jar", *classpath)
+	})
+
+	t.Run("no grouping", func(t *testing.T) {
+		fset, _, _, _ := newFlagSet()
+		assert.Error(t, fset.Parse([]string{"-vs"}))
+	})
+
+	t.Run("help", func(t *testing.T) {
+		fset, _, _, _ := newFlagSet()
+		var sb strings.Builder
+		fset.SetOutput(&sb)
+		assert.ErrorIs(t, fset.Parse([]string{"-help"}), ErrHelp)
+		fset.PrintDefaults()
+		assert.Contains(t, sb.String(), "  -v, -verbose[=true|false]\n")
+		assert.Contains(t, sb.String(), "  -cp PATH\n")
+	})
+}
+
+func TestFlagSetParseOSArgs(t *testing.T) {
+	withCommandLine(t, "/usr/local/bin/curl", "-o", "out.txt", "https://example.com")
+
+	t.Run("fills the empty program name", func(t *testing.T) {
+		var output string
+		fset := NewFlagSet("", ContinueOnError)
+		fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fset.SetMinMaxPositionalArgs(1, 1)
+		require.NoError(t, fset.ParseOSArgs())
+		assert.Equal(t, "curl", fset.ProgramName)
+		assert.Equal(t, "out.txt", output)
+		assert.Equal(t, []string{"https://example.com"}, fset.Args())
+	})
+
+	t.Run("keeps the program name", func(t *testing.T) {
+		fset := NewFlagSet("fetch", ContinueOnError)
+		fset.StringVar(new(string), 'o', "output", "Write output to FILE.")
+		fset.SetMinMaxPositionalArgs(1, 1)
+		require.NoError(t, fset.ParseOSArgs())
+		assert.Equal(t, "fetch", fset.ProgramName)
+	})
+}
+
+func TestFlagSetParsePartial(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *int, *bool, *[]string) {
+		var (
+			retry   int
+			verbose bool
+			headers []string
+		)
+		fset := NewFlagSet("curl", ContinueOnError)
+		fset.IntVar(&retry, 0, "retry", "Retry NUM times.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Run verbosely.")
+		fset.StringSliceVar(&headers, 'H', "header", "Add a header.")
+		fset.SetMinMaxPositionalArgs(1, 2)
+		return fset, &retry, &verbose, &headers
+	}
+
+	t.Run("later batches override earlier ones", func(t *testing.T) {
+		fset, retry, verbose, headers := newFlagSet()
+		require.NoError(t, fset.ParsePartial([]string{"--retry", "3", "-v", "-H", "A: 1"}))
+		require.NoError(t, fset.Parse([]string{"--retry", "5", "-H", "B: 2", "https://example.com"}))
+		assert.Equal(t, 5, *retry)
+		assert.True(t, *verbose)
+		assert.Equal(t, []string{"A: 1", "B: 2"}, *headers)
+		assert.Equal(t, []string{"https://example.com"}, fset.Args())
+	})
+
+	t.Run("positional arguments accumulate", func(t *testing.T) {
+		fset, _, _, _ := newFlagSet()
+		require.NoError(t, fset.ParsePartial([]string{"a"}))
+		require.NoError(t, fset.Parse([]string{"b"}))
+		assert.Equal(t, []string{"a", "b"}, fset.Args())
+
+		fset, _, _, _ = newFlagSet()
+		require.NoError(t, fset.ParsePartial([]string{"a", "b"}))
+		assert.Error(t, fset.Parse([]string{"c"}))
+
+		fset, _, _, _ = newFlagSet()
+		require.NoError(t, fset.ParsePartial([]string{"-v"}))
+		assert.Error(t, fset.Parse([]string{}))
+	})
+
+	t.Run("occurrences limits apply within each batch", func(t *testing.T) {
+		fset, retry, _, _ := newFlagSet()
+		fset.LongFlags[0].MaxOccurrences = 1
+		require.NoError(t, fset.ParsePartial([]string{"--retry", "3"}))
+		require.NoError(t, fset.Parse([]string{"--retry", "5", "a"}))
+		assert.Equal(t, 5, *retry)
+		assert.Equal(t, 2, fset.Occurrences("retry"))
+
+		fset, _, _, _ = newFlagSet()
+		fset.LongFlags[0].MaxOccurrences = 1
+		require.NoError(t, fset.ParsePartial([]string{"--retry", "3"}))
+		assert.EqualError(t, fset.Parse([]string{"--retry", "5", "--retry", "7", "a"}),
+			"option --retry specified multiple times")
+	})
+
+	t.Run("required flags consider all the batches", func(t *testing.T) {
+		fset, _, _, _ := newFlagSet()
+		fset.MarkRequired("retry")
+		require.NoError(t, fset.ParsePartial([]string{"--retry", "3"}))
+		require.NoError(t, fset.Parse([]string{"a"}))
+	})
+
+	t.Run("environment does not override earlier batches", func(t *testing.T) {
+		t.Setenv("CURL_RETRY", "7")
+		fset, retry, _, _ := newFlagSet()
+		fset.SetEnvVar("retry", "CURL_RETRY")
+		require.NoError(t, fset.ParsePartial([]string{"--retry", "3"}))
+		require.NoError(t, fset.Parse([]string{"a"}))
+		assert.Equal(t, 3, *retry)
+	})
+}
+
+func TestFlagSetPositionalArgsMessages(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		fset := NewFlagSet("curl", ContinueOnError)
+		fset.SetMinMaxPositionalArgs(1, 1)
+		fset.TooFewPositionalArgsMessage = "expected exactly one URL"
+		fset.TooManyPositionalArgsMessage = "expected at most @MAX@ URL, got @HAVE@"
+		return fset
+	}
+
+	t.Run("too few", func(t *testing.T) {
+		err := newFlagSet().Parse(nil)
+		assert.EqualError(t, err, "expected exactly one URL")
+		var perr *positionalArgsError
+		require.ErrorAs(t, err, &perr)
+		var tooFew flagparser.ErrTooFewPositionalArguments
+		require.ErrorAs(t, perr.Unwrap(), &tooFew)
+		assert.Equal(t, 1, tooFew.Min)
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		err := newFlagSet().Parse([]string{"https://a/", "https://b/"})
+		assert.EqualError(t, err, "expected at most 1 URL, got 2")
+		var tooMany flagparser.ErrTooManyPositionalArguments
+		require.ErrorAs(t, err, &tooMany)
+		assert.Equal(t, 2, tooMany.Have)
+	})
+
+	t.Run("default message", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.TooFewPositionalArgsMessage = ""
+		err := fset.Parse(nil)
+		assert.ErrorContains(t, err, "too few positional arguments")
+	})
+}
+
+func TestFlagSetUsageFunc(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *strings.Builder, *int) {
+		var (
+			calls   int
+			stderr  strings.Builder
+			verbose bool
+		)
+		fset := NewFlagSet("prog", ExitOnError)
+		fset.AutoHelp('h', "help", "Show this help message and exit.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		fset.Stderr = &stderr
+		fset.Stdout = &stderr
+		fset.Exit = func(status int) { panic(status) }
+		fset.Usage = func() {
+			calls++
+			fmt.Fprintf(fset.Output(), "usage: prog [-v]\n")
+		}
+		return fset, &stderr, &calls
+	}
+
+	t.Run("help", func(t *testing.T) {
+		fset, stderr, calls := newFlagSet()
+		assert.PanicsWithValue(t, 0, func() {
+			fset.Parse([]string{"--help"})
+		})
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, "usage: prog [-v]\n", stderr.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		fset, stderr, calls := newFlagSet()
+		assert.PanicsWithValue(t, 2, func() {
+			fset.Parse([]string{"--quiet"})
+		})
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, "prog: unknown option: --quiet\nusage: prog [-v]\n", stderr.String())
+	})
+}
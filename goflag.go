@@ -0,0 +1,213 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/bassosimone/flagparser"
+)
+
+// goBoolFlag is the interface implemented by stdlib [flag] boolean values.
+type goBoolFlag interface {
+	IsBoolFlag() bool
+}
+
+// ImportGoFlagSet adds the flags registered in the given stdlib [*flag.FlagSet]
+// as long flags using the `-` prefix, such that `-v` and `-v=3` keep working as
+// they do with the stdlib. This allows absorbing the flags that libraries (e.g.,
+// glog or klog) register using the stdlib into a [*FlagSet]. For example:
+//
+//	klog.InitFlags(nil)
+//	fset.ImportGoFlagSet(flag.CommandLine)
+//
+// The imported flags share their [flag.Value] with the stdlib flags. Boolean
+// flags, whose [flag.Value] has an `IsBoolFlag() bool` method returning true,
+// accept an optional `=value`. All the other flags require a value.
+//
+// The imported flags coexist with GNU-style short flags sharing the `-` prefix,
+// such that `-o FILE` and `-qo FILE` keep working alongside `-log_dir DIR`.
+// Like other long flags, [*FlagSet.Parse] panics if an imported flag has the
+// same name as an existing short or long flag.
+func (fs *FlagSet) ImportGoFlagSet(std *flag.FlagSet) {
+	std.VisitAll(func(gf *flag.Flag) {
+		fs.AddLongFlag(newLongFlagFromGoFlag(gf))
+	})
+}
+
+// newLongFlagFromGoFlag constructs a [*LongFlag] using the `-` prefix wrapping a stdlib [*flag.Flag].
+func newLongFlagFromGoFlag(gf *flag.Flag) *LongFlag {
+	argname, usage := flag.UnquoteUsage(gf)
+	fx := &LongFlag{
+		ArgumentName: " " + argname,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Name:         gf.Name,
+		Prefix:       "-",
+		Value:        gf.Value,
+	}
+	if usage != "" {
+		fx.Description = []string{usage}
+	}
+	if bf, ok := gf.Value.(goBoolFlag); ok && bf.IsBoolFlag() {
+		fx.ArgumentName = "[=true|false]"
+		fx.MakeOption = LongFlagMakeOptionBool
+	}
+	return fx
+}
+
+// typedValue is the interface implemented by values that know their
+// type name, such as the values of the `github.com/spf13/pflag` package.
+type typedValue interface {
+	Type() string
+}
+
+// Var registers flags bound to the given [Value] using GNU conventions.
+//
+// Since [Value] has the same methods of [flag.Value], this method allows to
+// register values implemented for the stdlib [flag] package and for the
+// `github.com/spf13/pflag` package. We treat the value as boolean, such that
+// the flags do not require a value, if it has an `IsBoolFlag() bool` method
+// returning true or a `Type() string` method returning `bool`. When the value
+// has a `Type() string` method, we use the uppercase type as ArgumentName.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) Var(value Value, shortName rune, longName string, helpText ...string) {
+	argname, isBool := " VALUE", false
+	if tv, ok := value.(typedValue); ok {
+		argname = " " + strings.ToUpper(tv.Type())
+		isBool = tv.Type() == "bool"
+	}
+	if bf, ok := value.(goBoolFlag); ok && bf.IsBoolFlag() {
+		isBool = true
+	}
+	if isBool {
+		value = valueBoolDefault{value}
+	}
+
+	if shortName != 0 {
+		fx := &ShortFlag{
+			Description:  helpText,
+			ArgumentName: argname,
+			Name:         shortName,
+			MakeOption:   ShortFlagMakeOptionWithValue,
+			Prefix:       "-",
+			Value:        value,
+		}
+		if isBool {
+			fx.ArgumentName = ""
+			fx.MakeOption = ShortFlagMakeOptionBool
+		}
+		fs.AddShortFlag(fx)
+	}
+
+	if longName != "" {
+		fx := &LongFlag{
+			Description:  helpText,
+			ArgumentName: argname,
+			Name:         longName,
+			MakeOption:   LongFlagMakeOptionWithRequiredValue,
+			Prefix:       "--",
+			Value:        value,
+		}
+		if isBool {
+			fx.ArgumentName = "[=true|false]"
+			fx.MakeOption = LongFlagMakeOptionBool
+		}
+		fs.addLongFlag(fx, shortName)
+	}
+}
+
+// valueBoolDefault wraps a boolean [Value] not accepting the empty string,
+// such as the stdlib [flag] boolean values, and sets `true` in such a case,
+// which is what happens for short flags without a value (e.g., `-v`).
+type valueBoolDefault struct {
+	Value
+}
+
+// Set implements [Value].
+func (v valueBoolDefault) Set(value string) error {
+	if value == "" {
+		value = "true"
+	}
+	return v.Value.Set(value)
+}
+
+// goFlagValue adapts a [Value] to [flag.Value] exposing whether it is boolean.
+type goFlagValue struct {
+	Value
+	isBool bool
+}
+
+// IsBoolFlag implements the stdlib [flag] boolean flags interface.
+func (v goFlagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// goFlagCount adapts a [ValueCount] to a stdlib [flag] boolean value, which
+// the stdlib sets to `true` when the flag has no value (e.g., `-v`), such that
+// `-v -v` increments the counter twice, while `-v=3` keeps setting it.
+type goFlagCount struct {
+	ValueCount
+}
+
+// IsBoolFlag implements the stdlib [flag] boolean flags interface.
+func (v goFlagCount) IsBoolFlag() bool {
+	return true
+}
+
+// Set implements [flag.Value].
+func (v goFlagCount) Set(value string) error {
+	if value == "true" {
+		value = ""
+	}
+	return v.ValueCount.Set(value)
+}
+
+// exportGoFlagValue returns the [flag.Value] to use for exporting a [Value].
+func exportGoFlagValue(value Value, isBool bool) flag.Value {
+	if count, ok := value.(ValueCount); ok {
+		return goFlagCount{count}
+	}
+	return goFlagValue{value, isBool}
+}
+
+// ExportGoFlagSet adds the short and long flags of the [*FlagSet] to the given
+// stdlib [*flag.FlagSet], sharing their [Value]. This helps migrating from
+// `github.com/spf13/pflag` (e.g., when using cobra) incrementally, since
+// pflag imports stdlib flags. For example:
+//
+//	std := flag.NewFlagSet(fset.ProgramName, flag.ContinueOnError)
+//	fset.ExportGoFlagSet(std)
+//	cmd.Flags().AddGoFlagSet(std)
+//
+// pflag turns single-character stdlib flags into flags with a shorthand, such
+// that both `-v` and `--v` work. Flags that do not require a value, such as the
+// ones registered by [*FlagSet.BoolVar], become stdlib boolean flags. Counters,
+// such as the ones registered by [*FlagSet.CountVar], also become stdlib boolean
+// flags, where each `-v` increments the counter and `-v=3` sets it. We skip
+// the flags registered by [*FlagSet.AutoHelp], the long flag aliases, and
+// the negated long flags, since the stdlib does not support them.
+//
+// This method panics, like [flag.FlagSet.Var], if a flag name is already
+// registered in the stdlib [*flag.FlagSet].
+func (fs *FlagSet) ExportGoFlagSet(std *flag.FlagSet) {
+	for _, fx := range fs.ShortFlags {
+		if _, ok := fx.Value.(ValueAutoHelp); ok {
+			continue
+		}
+		isBool := fx.MakeOption(fx).Type == flagparser.OptionTypeGroupableArgumentNone
+		std.Var(exportGoFlagValue(fx.Value, isBool), string(fx.Name), completionDescription(fx.docs()))
+	}
+	for _, fx := range fs.LongFlags {
+		if _, ok := fx.Value.(ValueAutoHelp); ok {
+			continue
+		}
+		opt := fx.MakeOption(fx)
+		isBool := opt.Type == flagparser.OptionTypeStandaloneArgumentOptional && opt.DefaultValue == "true"
+		std.Var(exportGoFlagValue(fx.Value, isBool), fx.Name, completionDescription(fx.docs()))
+	}
+}
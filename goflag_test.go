@@ -0,0 +1,186 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagSetImportGoFlagSet(t *testing.T) {
+	std := flag.NewFlagSet("std", flag.ContinueOnError)
+	logtostderr := std.Bool("logtostderr", false, "log to standard error instead of files")
+	verbosity := std.Int("v", 0, "number for the log level verbosity")
+	logdir := std.String("log_dir", "", "If non-empty, write log files in this `directory`")
+	interval := std.Duration("interval", time.Second, "")
+
+	var output string
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.StringVar(&output, 'o', "output", "Write output to file.")
+	fs.ImportGoFlagSet(std)
+
+	require.Len(t, fs.LongFlags, 5)
+
+	// Verify that we import flags in lexicographical order
+	lfInterval, lfLogdir, lfLogtostderr, lfVerbosity := fs.LongFlags[1], fs.LongFlags[2], fs.LongFlags[3], fs.LongFlags[4]
+	assert.Equal(t, "interval", lfInterval.Name)
+	assert.Equal(t, "log_dir", lfLogdir.Name)
+	assert.Equal(t, "logtostderr", lfLogtostderr.Name)
+	assert.Equal(t, "v", lfVerbosity.Name)
+
+	// Verify prefixes, argument names, and descriptions
+	assert.Equal(t, "-", lfVerbosity.Prefix)
+	assert.Equal(t, " int", lfVerbosity.ArgumentName)
+	assert.Equal(t, " directory", lfLogdir.ArgumentName)
+	assert.Equal(t, []string{"If non-empty, write log files in this directory"}, lfLogdir.Description)
+	assert.Equal(t, "[=true|false]", lfLogtostderr.ArgumentName)
+	assert.Empty(t, lfInterval.Description)
+
+	// Verify that parsing sets the stdlib values
+	require.NoError(t, fs.Parse([]string{
+		"-logtostderr", "-v", "3", "-log_dir=/tmp", "--output", "out.txt", "-interval", "5s",
+	}))
+	assert.True(t, *logtostderr)
+	assert.Equal(t, 3, *verbosity)
+	assert.Equal(t, "/tmp", *logdir)
+	assert.Equal(t, 5*time.Second, *interval)
+	assert.Equal(t, "out.txt", output)
+
+	// Verify that boolean flags accept an explicit value
+	require.NoError(t, fs.Parse([]string{"-logtostderr=false"}))
+	assert.False(t, *logtostderr)
+
+	// Verify that the GNU-style short flags, including groups, keep working
+	var quiet bool
+	fs.BoolVar(&quiet, 'q', "quiet", "Run quietly.")
+	require.NoError(t, fs.Parse([]string{"-o", "a.txt", "-qo", "b.txt", "-v=4"}))
+	assert.True(t, quiet)
+	assert.Equal(t, "b.txt", output)
+	assert.Equal(t, 4, *verbosity)
+}
+
+// pflagValue mimics a `github.com/spf13/pflag` value.
+type pflagValue struct {
+	ValueStringSlice
+}
+
+// Type implements the pflag value interface.
+func (v pflagValue) Type() string {
+	return "stringSlice"
+}
+
+// pflagBoolValue mimics a `github.com/spf13/pflag` boolean value.
+type pflagBoolValue struct {
+	ValueBool
+}
+
+// Type implements the pflag value interface.
+func (v pflagBoolValue) Type() string {
+	return "bool"
+}
+
+func TestFlagSetVar(t *testing.T) {
+	t.Run("stdlib values", func(t *testing.T) {
+		std := flag.NewFlagSet("std", flag.ContinueOnError)
+		var (
+			level   int
+			verbose bool
+		)
+		std.IntVar(&level, "level", 0, "")
+		std.BoolVar(&verbose, "verbose", false, "")
+
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.Var(std.Lookup("level").Value, 'l', "level", "Set the level.")
+		fs.Var(std.Lookup("verbose").Value, 'v', "verbose", "Enable verbose output.")
+
+		require.Len(t, fs.ShortFlags, 2)
+		require.Len(t, fs.LongFlags, 2)
+		assert.Equal(t, " VALUE", fs.LongFlags[0].ArgumentName)
+		assert.Equal(t, "", fs.ShortFlags[1].ArgumentName)
+		assert.Equal(t, "[=true|false]", fs.LongFlags[1].ArgumentName)
+
+		require.NoError(t, fs.Parse([]string{"-v", "--level", "3"}))
+		assert.True(t, verbose)
+		assert.Equal(t, 3, level)
+	})
+
+	t.Run("pflag values", func(t *testing.T) {
+		var (
+			headers []string
+			verbose bool
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.Var(pflagValue{NewValueStringSlice(&headers)}, 'H', "header", "Add header.")
+		fs.Var(pflagBoolValue{NewValueBool(&verbose)}, 'v', "verbose", "Enable verbose output.")
+
+		assert.Equal(t, " STRINGSLICE", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, "", fs.ShortFlags[1].ArgumentName)
+
+		require.NoError(t, fs.Parse([]string{"-vH", "A: 1", "--header", "B: 2"}))
+		assert.True(t, verbose)
+		assert.Equal(t, []string{"A: 1", "B: 2"}, headers)
+	})
+}
+
+func TestFlagSetExportGoFlagSet(t *testing.T) {
+	var (
+		count   int
+		output  string
+		verbose bool
+	)
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.AutoHelp('h', "help", "Show help.")
+	fs.IntVar(&count, 'n', "count", "Set count (default: @DEFAULT_VALUE@).")
+	fs.StringVar(&output, 0, "output", "Write output to file.")
+	fs.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+
+	std := flag.NewFlagSet("std", flag.ContinueOnError)
+	fs.ExportGoFlagSet(std)
+
+	var names []string
+	std.VisitAll(func(gf *flag.Flag) {
+		names = append(names, gf.Name)
+	})
+	assert.Equal(t, []string{"count", "n", "output", "v", "verbose"}, names)
+	assert.Equal(t, "Set count (default: 0).", std.Lookup("count").Usage)
+
+	isBool := func(name string) bool {
+		bf, ok := std.Lookup(name).Value.(goBoolFlag)
+		return ok && bf.IsBoolFlag()
+	}
+	assert.True(t, isBool("v"))
+	assert.True(t, isBool("verbose"))
+	assert.False(t, isBool("n"))
+	assert.False(t, isBool("output"))
+
+	// Verify that the stdlib flags share the values
+	require.NoError(t, std.Parse([]string{"-v", "-n", "3", "-output", "out.txt"}))
+	assert.True(t, verbose)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, "out.txt", output)
+}
+
+func TestFlagSetExportGoFlagSetCount(t *testing.T) {
+	var verbosity int
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.CountVar(&verbosity, 'v', "verbose", "Increase verbosity.")
+
+	std := flag.NewFlagSet("std", flag.ContinueOnError)
+	fs.ExportGoFlagSet(std)
+
+	bf, ok := std.Lookup("v").Value.(goBoolFlag)
+	require.True(t, ok)
+	assert.True(t, bf.IsBoolFlag())
+
+	require.NoError(t, std.Parse([]string{"-v", "-verbose", "-v"}))
+	assert.Equal(t, 3, verbosity)
+
+	require.NoError(t, std.Parse([]string{"-verbose=7"}))
+	assert.Equal(t, 7, verbosity)
+}
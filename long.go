@@ -8,6 +8,9 @@ package vflag
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/bassosimone/flagparser"
 	"github.com/bassosimone/runtimex"
@@ -23,40 +26,206 @@ import (
 // "Write to `FILE`.") overrides the default ArgumentName in help output.
 //
 // The placeholder @DEFAULT_VALUE@ in Description entries is replaced with the
-// current default value (via Value.String()) when printing help.
+// current default value (via Value.String(), or DefaultText) when printing help.
 //
 // Construct using [NewLongFlagBool], [NewLongFlagString], etc.
 type LongFlag struct {
+	// Aliases contains additional long names bound to the same [Value] (e.g.,
+	// `colour` as an alias of `color`). Aliases use the same Prefix.
+	Aliases []string
+
+	// AllowDashValue allows the flag to take a separate argument starting with
+	// `-` as its value (e.g., `--message -n`) when the [*FlagSet] RejectDashValues
+	// is true. See [*FlagSet.AllowDashValue] for more information.
+	AllowDashValue bool
+
 	// Description contains the flag description paragraphs to use in the help.
+	//
+	// Like the [*DefaultUsagePrinter] Description, a paragraph starting with
+	// 4 spaces or with a "```" fence is a verbatim block that we do not wrap.
 	Description []string
 
 	// ArgumentName is the name of the argument to use in the help.
 	ArgumentName string
 
+	// Group is the name of the group under which the help lists the flag.
+	//
+	// See [*FlagSet.Group] for more information.
+	Group string
+
+	// HideAliases prevents listing the Aliases in the help.
+	HideAliases bool
+
+	// HideDefault prevents showing the default value in the help, which
+	// is useful when the default is meaningless, huge, or sensitive.
+	HideDefault bool
+
+	// DefaultText, when not empty, replaces the default value shown in the help
+	// (e.g., `autodetected` or `$HOME/.config`), which is useful when the
+	// literal Value.String() output would be confusing or misleading.
+	//
+	// See [*FlagSet.SetDefaultText] for more information.
+	DefaultText string
+
 	// DefaultValue is the default value to use when the flag is present but no
 	// value is provided. This is only used by [LongFlagMakeOptionWithOptionalValue].
 	// The value is captured at construction time from the bound variable.
 	DefaultValue string
 
+	// EnvVar is the environment variable providing the flag value when the
+	// flag does not appear on the command line (e.g., `CURL_OUTPUT`).
+	//
+	// See [*FlagSet.SetEnvVar] for more information.
+	EnvVar string
+
 	// MakeOption constructs the [*flagparser.Option] to use.
 	MakeOption func(fx *LongFlag) *flagparser.Option
 
+	// MaxOccurrences is the maximum number of times the flag may appear on
+	// the command line, counting its short form (see ShortName). When
+	// it is zero, which is the default, the flag may appear any number of times.
+	//
+	// Set this field to 1 for scalar flags that must not be repeated, such
+	// that repeating them is an error rather than silently using the last value.
+	MaxOccurrences int
+
 	// Name is the flag long name.
 	Name string
 
+	// Negatable causes [*FlagSet.Parse] to also recognize the negated flag
+	// (e.g., `--no-verbose`), which sets the Value to false. This is only
+	// meaningful for flags bound to a [ValueBool].
+	Negatable bool
+
+	// OnDuplicate is the [DuplicatePolicy] to use when the flag, or its
+	// short form, appears multiple times on the command line.
+	//
+	// The zero value is [DuplicateLastWins].
+	OnDuplicate DuplicatePolicy
+
 	// Prefix is the flag long prefix.
 	Prefix string
 
+	// Required indicates that the flag, or its short form, must be set.
+	// See [*FlagSet.MarkRequired] for more information.
+	Required bool
+
+	// ShortName is the name of the [*ShortFlag] sharing the same [Value], if
+	// any, which the GNU-style methods such as [*FlagSet.StringVar] set. We use
+	// it to treat the short and long forms as the same flag (e.g., to count the
+	// occurrences or to print the help). When zero, there is no such flag.
+	ShortName rune
+
 	// Value is the flag [Value].
 	Value Value
+
+	// ValueSeparator separates the flag name and its value within the same
+	// argument (e.g., `:` for `-encoding:UTF-8`). When empty, we use `=`.
+	//
+	// The `=` separator and passing the value as a separate argument (e.g.,
+	// `-encoding UTF-8`) keep working regardless of this field.
+	ValueSeparator string
+
+	// occurrences is how many times [*FlagSet.Parse] found the flag, one of
+	// its aliases, or its negation on the command line.
+	occurrences int
+
+	// configured is true when [*FlagSet.Parse] assigned the flag
+	// using a config file value or an environment variable.
+	configured bool
 }
 
 // Usage returns the usage string for the [*LongFlag].
 //
 // For example: `--verbose` or `--output FILE`.
 func (fx *LongFlag) Usage() string {
+	return fmt.Sprintf("%s%s%s", fx.Prefix, fx.Name, fx.argumentName())
+}
+
+// AliasesUsage returns the usage strings for the [*LongFlag] Aliases.
+//
+// For example: `--colour` or `--colour WHEN`.
+func (fx *LongFlag) AliasesUsage() []string {
+	argumentName := fx.argumentName()
+	output := make([]string, 0, len(fx.Aliases))
+	for _, alias := range fx.Aliases {
+		output = append(output, fmt.Sprintf("%s%s%s", fx.Prefix, alias, argumentName))
+	}
+	return output
+}
+
+// argumentName returns the argument name to use in the help using the
+// ValueSeparator for optional values (e.g., `[:LEVEL]` rather than `[=LEVEL]`).
+func (fx *LongFlag) argumentName() string {
 	argumentName := argumentNameFromDocsOrDefault(fx.Description, fx.ArgumentName)
-	return fmt.Sprintf("%s%s%s", fx.Prefix, fx.Name, argumentName)
+	if fx.ValueSeparator != "" && strings.HasPrefix(argumentName, "[=") {
+		argumentName = "[" + fx.ValueSeparator + strings.TrimPrefix(argumentName, "[=")
+	}
+	return argumentName
+}
+
+// NegatedName returns the name of the negated flag (e.g., `no-verbose`).
+//
+// The negated flag is only recognized when Negatable is true.
+func (fx *LongFlag) NegatedName() string {
+	return "no-" + fx.Name
+}
+
+// WasSet returns whether [*FlagSet.Parse] found the [*LongFlag], one of its
+// Aliases, or its negated name on the command line.
+//
+// Use [*FlagSet.Changed] to also consider the short form of the flag.
+func (fx *LongFlag) WasSet() bool {
+	return fx.occurrences > 0
+}
+
+// longFlagOption is a [*flagparser.Option] built for a [*LongFlag] along
+// with the [Value] to set when the option is found on the command line.
+type longFlagOption struct {
+	option *flagparser.Option
+	value  Value
+}
+
+// makeOptions constructs the options for the flag name, its Aliases,
+// and, when the flag is Negatable, for its negated name.
+func (fx *LongFlag) makeOptions() []longFlagOption {
+	opt := fx.MakeOption(fx)
+	output := []longFlagOption{{option: opt, value: fx.Value}}
+	for _, alias := range fx.Aliases {
+		runtimex.Assert(alias != "")
+		aliasOpt := *opt
+		aliasOpt.Name = alias
+		output = append(output, longFlagOption{option: &aliasOpt, value: fx.Value})
+	}
+	if fx.Negatable {
+		output = append(output, longFlagOption{
+			option: &flagparser.Option{
+				Type:         flagparser.OptionTypeStandaloneArgumentOptional,
+				Prefix:       fx.Prefix,
+				Name:         fx.NegatedName(),
+				DefaultValue: "true",
+			},
+			value: valueNegated{fx.Value},
+		})
+	}
+	return output
+}
+
+// valueNegated wraps a boolean [Value] and sets the opposite value.
+type valueNegated struct {
+	Value
+}
+
+// Set implements [Value].
+func (v valueNegated) Set(value string) error {
+	if value == "" {
+		value = "true"
+	}
+	parsed, err := parseBool(value)
+	if err != nil {
+		return err
+	}
+	return v.Value.Set(strconv.FormatBool(!parsed))
 }
 
 // LongFlagMakeOptionAutoHelp returns the [*flagparser.Option] to use for auto help.
@@ -75,6 +244,11 @@ func LongFlagMakeOptionAutoHelp(fx *LongFlag) *flagparser.Option {
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
 // update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// To have a single flag owning several names (e.g., `-h` and `-help`), which the
+// help lists in a single entry (e.g., `-h, -help`), set the `Aliases` field. Likewise,
+// set the `ShortName` field to link the flag to a short auto-help flag (e.g., `-h`),
+// which the help lists in the same entry even when their descriptions differ.
 func NewLongFlagAutoHelp(value ValueAutoHelp, name string, helpText ...string) *LongFlag {
 	return &LongFlag{
 		Description:  helpText,
@@ -103,6 +277,40 @@ func LongFlagMakeOptionBool(fx *LongFlag) *flagparser.Option {
 	}
 }
 
+// NewLongFlagBigFloat constructs a new [*LongFlag] bound to a [ValueBigFloat].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` BIGFLOAT` by default.
+func NewLongFlagBigFloat(value ValueBigFloat, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " BIGFLOAT",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagBigInt constructs a new [*LongFlag] bound to a [ValueBigInt].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` BIGINT` by default.
+func NewLongFlagBigInt(value ValueBigInt, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " BIGINT",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagBool constructs a new [*LongFlag] bound to a [ValueBool].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -120,6 +328,106 @@ func NewLongFlagBool(value ValueBool, name string, helpText ...string) *LongFlag
 	}
 }
 
+// LongFlagMakeOptionCount returns the [*flagparser.Option] to use for counters.
+//
+// Long count flags are standalone and take an optional argument (e.g., `--verbose`,
+// `--verbose=3`). When no argument is provided, the value is empty, which causes
+// [ValueCount] to increment the counter.
+//
+// This method panics if the name or prefix are empty.
+func LongFlagMakeOptionCount(fx *LongFlag) *flagparser.Option {
+	runtimex.Assert(fx.Prefix != "" && fx.Name != "")
+	return &flagparser.Option{
+		Type:         flagparser.OptionTypeStandaloneArgumentOptional,
+		Prefix:       fx.Prefix,
+		Name:         fx.Name,
+		DefaultValue: "",
+	}
+}
+
+// NewLongFlagBoolFunc constructs a new [*LongFlag] bound to a [ValueBoolFunc].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+func NewLongFlagBoolFunc(value ValueBoolFunc, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: "",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionBool,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagBoolPtr constructs a new [*LongFlag] bound to a [ValueBoolPtr].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to `[=true|false]` by default.
+func NewLongFlagBoolPtr(value ValueBoolPtr, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: "[=true|false]",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionBool,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagBytesBase64 constructs a new [*LongFlag] bound to a [ValueBytesBase64].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` BASE64` by default.
+func NewLongFlagBytesBase64(value ValueBytesBase64, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " BASE64",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagBytesHex constructs a new [*LongFlag] bound to a [ValueBytesHex].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` HEX` by default.
+func NewLongFlagBytesHex(value ValueBytesHex, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " HEX",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagCount constructs a new [*LongFlag] bound to a [ValueCount].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to `[=INT]` by default.
+func NewLongFlagCount(value ValueCount, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: "[=INT]",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionCount,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // LongFlagMakeOptionWithRequiredValue returns the [*flagparser.Option] to use for
 // flags that require a value.
 //
@@ -171,6 +479,74 @@ func NewLongFlagDuration(value ValueDuration, name string, helpText ...string) *
 	}
 }
 
+// NewLongFlagDurationSlice constructs a new [*LongFlag] bound to a [ValueDurationSlice].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` DURATION` by default.
+func NewLongFlagDurationSlice(value ValueDurationSlice, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " DURATION",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagEnum constructs a new [*LongFlag] bound to a [ValueEnum].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to the choices separated by `|` (e.g., ` json|yaml`).
+func NewLongFlagEnum(value ValueEnum, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " " + strings.Join(value.Choices(), "|"),
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagFileMode constructs a new [*LongFlag] bound to a [ValueFileMode].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` MODE` by default.
+func NewLongFlagFileMode(value ValueFileMode, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " MODE",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagFloat32 constructs a new [*LongFlag] bound to a [ValueFloat32].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` FLOAT32` by default.
+func NewLongFlagFloat32(value ValueFloat32, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " FLOAT32",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagFloat64 constructs a new [*LongFlag] bound to a [ValueFloat64].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -188,6 +564,57 @@ func NewLongFlagFloat64(value ValueFloat64, name string, helpText ...string) *Lo
 	}
 }
 
+// NewLongFlagFloat64Slice constructs a new [*LongFlag] bound to a [ValueFloat64Slice].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` FLOAT64` by default.
+func NewLongFlagFloat64Slice(value ValueFloat64Slice, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " FLOAT64",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagFunc constructs a new [*LongFlag] bound to a [ValueFunc].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` VALUE` by default.
+func NewLongFlagFunc(value ValueFunc, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " VALUE",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagGlob constructs a new [*LongFlag] bound to a [ValueGlob].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` PATTERN` by default.
+func NewLongFlagGlob(value ValueGlob, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " PATTERN",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagInt constructs a new [*LongFlag] bound to a [ValueInt].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -205,6 +632,23 @@ func NewLongFlagInt(value ValueInt, name string, helpText ...string) *LongFlag {
 	}
 }
 
+// NewLongFlagIntSlice constructs a new [*LongFlag] bound to a [ValueIntSlice].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` INT` by default.
+func NewLongFlagIntSlice(value ValueIntSlice, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " INT",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagInt8 constructs a new [*LongFlag] bound to a [ValueInt8].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -273,6 +717,142 @@ func NewLongFlagInt64(value ValueInt64, name string, helpText ...string) *LongFl
 	}
 }
 
+// NewLongFlagInt64Slice constructs a new [*LongFlag] bound to a [ValueInt64Slice].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` INT64` by default.
+func NewLongFlagInt64Slice(value ValueInt64Slice, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " INT64",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagIPSlice constructs a new [*LongFlag] bound to a [ValueIPSlice].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` ADDR[,ADDR...]` by default.
+func NewLongFlagIPSlice(value ValueIPSlice, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " ADDR[,ADDR...]",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagJSON constructs a new [*LongFlag] bound to a [ValueJSON].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` JSON` by default.
+func NewLongFlagJSON(value ValueJSON, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " JSON",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagLogLevel constructs a new [*LongFlag] bound to a [ValueLogLevel].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` LEVEL` by default.
+func NewLongFlagLogLevel(value ValueLogLevel, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " LEVEL",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagNetipAddr constructs a new [*LongFlag] bound to a [ValueNetipAddr].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` ADDR` by default.
+func NewLongFlagNetipAddr(value ValueNetipAddr, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " ADDR",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagPercent constructs a new [*LongFlag] bound to a [ValuePercent].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` PERCENT` by default.
+func NewLongFlagPercent(value ValuePercent, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " PERCENT",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagRune constructs a new [*LongFlag] bound to a [ValueRune].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` CHAR` by default.
+func NewLongFlagRune(value ValueRune, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " CHAR",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// NewLongFlagSecretString constructs a new [*LongFlag] bound to a [ValueSecretString].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` SECRET` by default.
+func NewLongFlagSecretString(value ValueSecretString, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " SECRET",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagString constructs a new [*LongFlag] bound to a [ValueString].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -307,6 +887,23 @@ func NewLongFlagStringSlice(value ValueStringSlice, name string, helpText ...str
 	}
 }
 
+// NewLongFlagStringToString constructs a new [*LongFlag] bound to a [ValueStringToString].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` KEY=VALUE` by default.
+func NewLongFlagStringToString(value ValueStringToString, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " KEY=VALUE",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagUint constructs a new [*LongFlag] bound to a [ValueUint].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -324,6 +921,23 @@ func NewLongFlagUint(value ValueUint, name string, helpText ...string) *LongFlag
 	}
 }
 
+// NewLongFlagUintSlice constructs a new [*LongFlag] bound to a [ValueUintSlice].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` UINT` by default.
+func NewLongFlagUintSlice(value ValueUintSlice, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " UINT",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
 // NewLongFlagUint8 constructs a new [*LongFlag] bound to a [ValueUint8].
 //
 // This constructor sets the flag prefix to `--`. If you need a different prefix,
@@ -391,3 +1005,30 @@ func NewLongFlagUint64(value ValueUint64, name string, helpText ...string) *Long
 		Value:        value,
 	}
 }
+
+// NewLongFlagUUID constructs a new [*LongFlag] bound to a [ValueUUID].
+//
+// This constructor sets the flag prefix to `--`. If you need a different prefix,
+// update the `Prefix` field in the returned [*LongFlag] structure.
+//
+// The ArgumentName is set to ` UUID` by default.
+func NewLongFlagUUID(value ValueUUID, name string, helpText ...string) *LongFlag {
+	return &LongFlag{
+		Description:  helpText,
+		ArgumentName: " UUID",
+		Name:         name,
+		MakeOption:   LongFlagMakeOptionWithRequiredValue,
+		Prefix:       "--",
+		Value:        value,
+	}
+}
+
+// lookupLongFlag returns the long flag with the given name or alias, or nil.
+func (fs *FlagSet) lookupLongFlag(name string) *LongFlag {
+	for _, fx := range fs.LongFlags {
+		if fx.Name == name || slices.Contains(fx.Aliases, name) {
+			return fx
+		}
+	}
+	return nil
+}
@@ -3,6 +3,10 @@
 package vflag
 
 import (
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"os"
 	"testing"
 	"time"
 
@@ -60,6 +64,18 @@ func TestLongFlagMakeOptionBool(t *testing.T) {
 	assert.Equal(t, "true", opt.DefaultValue)
 }
 
+func TestLongFlagMakeOptionCount(t *testing.T) {
+	var v int
+	lf := NewLongFlagCount(NewValueCount(&v), "verbose", "Increase verbosity.")
+	opt := lf.MakeOption(lf)
+
+	require.NotNil(t, opt)
+	assert.Equal(t, flagparser.OptionTypeStandaloneArgumentOptional, opt.Type)
+	assert.Equal(t, "--", opt.Prefix)
+	assert.Equal(t, "verbose", opt.Name)
+	assert.Equal(t, "", opt.DefaultValue)
+}
+
 func TestLongFlagMakeOptionWithRequiredValue(t *testing.T) {
 	var v string
 	lf := NewLongFlagString(NewValueString(&v), "output", "Output file.")
@@ -103,6 +119,24 @@ func TestNewLongFlagAutoHelp(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestNewLongFlagBigFloat(t *testing.T) {
+	var v big.Float
+	lf := NewLongFlagBigFloat(NewValueBigFloat(&v), "amount", "Set the amount.")
+
+	assert.Equal(t, "amount", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " BIGFLOAT", lf.ArgumentName)
+}
+
+func TestNewLongFlagBigInt(t *testing.T) {
+	var v big.Int
+	lf := NewLongFlagBigInt(NewValueBigInt(&v), "exponent", "Set the exponent.")
+
+	assert.Equal(t, "exponent", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " BIGINT", lf.ArgumentName)
+}
+
 func TestNewLongFlagBool(t *testing.T) {
 	var v bool
 	lf := NewLongFlagBool(NewValueBool(&v), "verbose", "Enable verbose.")
@@ -112,6 +146,52 @@ func TestNewLongFlagBool(t *testing.T) {
 	assert.Equal(t, "[=true|false]", lf.ArgumentName)
 }
 
+func TestNewLongFlagBoolFunc(t *testing.T) {
+	lf := NewLongFlagBoolFunc(NewValueBoolFunc(func(string) error { return nil }), "trace", "Enable tracing.")
+
+	assert.Equal(t, "trace", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, "", lf.ArgumentName)
+	assert.Equal(t, flagparser.OptionTypeStandaloneArgumentOptional, lf.MakeOption(lf).Type)
+}
+
+func TestNewLongFlagBoolPtr(t *testing.T) {
+	var v *bool
+	lf := NewLongFlagBoolPtr(NewValueBoolPtr(&v), "feature", "Enable the feature.")
+
+	assert.Equal(t, "feature", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, "[=true|false]", lf.ArgumentName)
+	assert.Equal(t, flagparser.OptionTypeStandaloneArgumentOptional, lf.MakeOption(lf).Type)
+}
+
+func TestNewLongFlagBytesBase64(t *testing.T) {
+	var v []byte
+	lf := NewLongFlagBytesBase64(NewValueBytesBase64(&v), "key", "Set the key.")
+
+	assert.Equal(t, "key", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " BASE64", lf.ArgumentName)
+}
+
+func TestNewLongFlagBytesHex(t *testing.T) {
+	var v []byte
+	lf := NewLongFlagBytesHex(NewValueBytesHex(&v), "key", "Set the key.")
+
+	assert.Equal(t, "key", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " HEX", lf.ArgumentName)
+}
+
+func TestNewLongFlagCount(t *testing.T) {
+	var v int
+	lf := NewLongFlagCount(NewValueCount(&v), "verbose", "Increase verbosity.")
+
+	assert.Equal(t, "verbose", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, "[=INT]", lf.ArgumentName)
+}
+
 func TestNewLongFlagDuration(t *testing.T) {
 	var v time.Duration
 	lf := NewLongFlagDuration(NewValueDuration(&v), "timeout", "Set timeout.")
@@ -121,6 +201,43 @@ func TestNewLongFlagDuration(t *testing.T) {
 	assert.Equal(t, " DURATION", lf.ArgumentName)
 }
 
+func TestNewLongFlagDurationSlice(t *testing.T) {
+	var v []time.Duration
+	lf := NewLongFlagDurationSlice(NewValueDurationSlice(&v), "retry-after", "Retry after the given delay.")
+
+	assert.Equal(t, "retry-after", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " DURATION", lf.ArgumentName)
+}
+
+func TestNewLongFlagEnum(t *testing.T) {
+	var v string
+	lf := NewLongFlagEnum(NewValueEnum(&v, "json", "yaml"), "format", "Set format.")
+
+	assert.Equal(t, "format", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " json|yaml", lf.ArgumentName)
+	assert.Equal(t, "--format json|yaml", lf.Usage())
+}
+
+func TestNewLongFlagFileMode(t *testing.T) {
+	var v os.FileMode
+	lf := NewLongFlagFileMode(NewValueFileMode(&v), "mode", "Set the file mode.")
+
+	assert.Equal(t, "mode", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " MODE", lf.ArgumentName)
+}
+
+func TestNewLongFlagFloat32(t *testing.T) {
+	var v float32
+	lf := NewLongFlagFloat32(NewValueFloat32(&v), "learning-rate", "Set learning rate.")
+
+	assert.Equal(t, "learning-rate", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " FLOAT32", lf.ArgumentName)
+}
+
 func TestNewLongFlagFloat64(t *testing.T) {
 	var v float64
 	lf := NewLongFlagFloat64(NewValueFloat64(&v), "ratio", "Set ratio.")
@@ -129,6 +246,32 @@ func TestNewLongFlagFloat64(t *testing.T) {
 	assert.Equal(t, " FLOAT64", lf.ArgumentName)
 }
 
+func TestNewLongFlagFloat64Slice(t *testing.T) {
+	var v []float64
+	lf := NewLongFlagFloat64Slice(NewValueFloat64Slice(&v), "ratio", "Add ratio.")
+
+	assert.Equal(t, "ratio", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " FLOAT64", lf.ArgumentName)
+}
+
+func TestNewLongFlagFunc(t *testing.T) {
+	lf := NewLongFlagFunc(NewValueFunc(func(string) error { return nil }), "header", "Add a header.")
+
+	assert.Equal(t, "header", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " VALUE", lf.ArgumentName)
+}
+
+func TestNewLongFlagGlob(t *testing.T) {
+	var v []string
+	lf := NewLongFlagGlob(NewValueGlob(&v), "include", "Include files matching PATTERN.")
+
+	assert.Equal(t, "include", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " PATTERN", lf.ArgumentName)
+}
+
 func TestNewLongFlagInt(t *testing.T) {
 	var v int
 	lf := NewLongFlagInt(NewValueInt(&v), "count", "Set count.")
@@ -137,6 +280,15 @@ func TestNewLongFlagInt(t *testing.T) {
 	assert.Equal(t, " INT", lf.ArgumentName)
 }
 
+func TestNewLongFlagIntSlice(t *testing.T) {
+	var v []int
+	lf := NewLongFlagIntSlice(NewValueIntSlice(&v), "number", "Add number.")
+
+	assert.Equal(t, "number", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " INT", lf.ArgumentName)
+}
+
 func TestNewLongFlagInt8(t *testing.T) {
 	var v int8
 	lf := NewLongFlagInt8(NewValueInt8(&v), "batch", "Set batch.")
@@ -169,6 +321,78 @@ func TestNewLongFlagInt64(t *testing.T) {
 	assert.Equal(t, " INT64", lf.ArgumentName)
 }
 
+func TestNewLongFlagInt64Slice(t *testing.T) {
+	var v []int64
+	lf := NewLongFlagInt64Slice(NewValueInt64Slice(&v), "size", "Add size.")
+
+	assert.Equal(t, "size", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " INT64", lf.ArgumentName)
+}
+
+func TestNewLongFlagIPSlice(t *testing.T) {
+	var v []netip.Addr
+	lf := NewLongFlagIPSlice(NewValueIPSlice(&v), "dns-server", "Use the given DNS servers.")
+
+	assert.Equal(t, "dns-server", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " ADDR[,ADDR...]", lf.ArgumentName)
+}
+
+func TestNewLongFlagJSON(t *testing.T) {
+	var v map[string]any
+	lf := NewLongFlagJSON(NewValueJSON(&v), "filter", "Filter the results.")
+
+	assert.Equal(t, "filter", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " JSON", lf.ArgumentName)
+}
+
+func TestNewLongFlagLogLevel(t *testing.T) {
+	var v slog.Level
+	lf := NewLongFlagLogLevel(NewValueLogLevel(&v), "log-level", "Set the log level.")
+
+	assert.Equal(t, "log-level", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " LEVEL", lf.ArgumentName)
+}
+
+func TestNewLongFlagNetipAddr(t *testing.T) {
+	var v netip.Addr
+	lf := NewLongFlagNetipAddr(NewValueNetipAddr(&v), "bind", "Bind to the given address.")
+
+	assert.Equal(t, "bind", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " ADDR", lf.ArgumentName)
+}
+
+func TestNewLongFlagPercent(t *testing.T) {
+	var v float64
+	lf := NewLongFlagPercent(NewValuePercent(&v), "threshold", "Set the threshold.")
+
+	assert.Equal(t, "threshold", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " PERCENT", lf.ArgumentName)
+}
+
+func TestNewLongFlagRune(t *testing.T) {
+	var v rune
+	lf := NewLongFlagRune(NewValueRune(&v), "delimiter", "Use the given delimiter.")
+
+	assert.Equal(t, "delimiter", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " CHAR", lf.ArgumentName)
+}
+
+func TestNewLongFlagSecretString(t *testing.T) {
+	var v SecretString
+	lf := NewLongFlagSecretString(NewValueSecretString(&v), "password", "Use the given password.")
+
+	assert.Equal(t, "password", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " SECRET", lf.ArgumentName)
+}
+
 func TestNewLongFlagString(t *testing.T) {
 	var v string
 	lf := NewLongFlagString(NewValueString(&v), "output", "Set output.")
@@ -185,6 +409,15 @@ func TestNewLongFlagStringSlice(t *testing.T) {
 	assert.Equal(t, " STRING", lf.ArgumentName)
 }
 
+func TestNewLongFlagStringToString(t *testing.T) {
+	var v map[string]string
+	lf := NewLongFlagStringToString(NewValueStringToString(&v), "define", "Define a macro.")
+
+	assert.Equal(t, "define", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " KEY=VALUE", lf.ArgumentName)
+}
+
 func TestNewLongFlagUint(t *testing.T) {
 	var v uint
 	lf := NewLongFlagUint(NewValueUint(&v), "users", "Set users.")
@@ -193,6 +426,15 @@ func TestNewLongFlagUint(t *testing.T) {
 	assert.Equal(t, " UINT", lf.ArgumentName)
 }
 
+func TestNewLongFlagUintSlice(t *testing.T) {
+	var v []uint
+	lf := NewLongFlagUintSlice(NewValueUintSlice(&v), "user", "Add user.")
+
+	assert.Equal(t, "user", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " UINT", lf.ArgumentName)
+}
+
 func TestNewLongFlagUint8(t *testing.T) {
 	var v uint8
 	lf := NewLongFlagUint8(NewValueUint8(&v), "queue", "Set queue.")
@@ -225,6 +467,15 @@ func TestNewLongFlagUint64(t *testing.T) {
 	assert.Equal(t, " UINT64", lf.ArgumentName)
 }
 
+func TestNewLongFlagUUID(t *testing.T) {
+	var v string
+	lf := NewLongFlagUUID(NewValueUUID(&v), "id", "Select the resource.")
+
+	assert.Equal(t, "id", lf.Name)
+	assert.Equal(t, "--", lf.Prefix)
+	assert.Equal(t, " UUID", lf.ArgumentName)
+}
+
 func TestLongFlagMakeOptionPanicsOnEmptyPrefix(t *testing.T) {
 	var v bool
 	lf := NewLongFlagBool(NewValueBool(&v), "verbose", "Verbose.")
@@ -244,3 +495,46 @@ func TestLongFlagMakeOptionPanicsOnEmptyName(t *testing.T) {
 		lf.MakeOption(lf)
 	})
 }
+
+func TestLongFlagAliasesUsage(t *testing.T) {
+	var v string
+	lf := NewLongFlagString(NewValueString(&v), "color", "Colorize the output.")
+	lf.Aliases = []string{"colour", "colr"}
+	assert.Equal(t, []string{"--colour STRING", "--colr STRING"}, lf.AliasesUsage())
+}
+
+func TestLongFlagMakeOptions(t *testing.T) {
+	var v bool
+	lf := NewLongFlagBool(NewValueBool(&v), "color", "Colorize the output.")
+	lf.Aliases = []string{"colour"}
+	lf.Negatable = true
+	opts := lf.makeOptions()
+
+	require.Len(t, opts, 3)
+	assert.Equal(t, "color", opts[0].option.Name)
+	assert.Equal(t, "colour", opts[1].option.Name)
+	assert.Equal(t, opts[0].option.Type, opts[1].option.Type)
+	assert.Equal(t, opts[0].option.Prefix, opts[1].option.Prefix)
+	assert.Equal(t, opts[0].option.DefaultValue, opts[1].option.DefaultValue)
+	assert.Equal(t, lf.Value, opts[1].value)
+
+	assert.Equal(t, "no-color", opts[2].option.Name)
+	assert.Equal(t, flagparser.OptionTypeStandaloneArgumentOptional, opts[2].option.Type)
+	assert.Equal(t, "true", opts[2].option.DefaultValue)
+	assert.Equal(t, valueNegated{lf.Value}, opts[2].value)
+}
+
+func TestValueNegated(t *testing.T) {
+	raw := true
+	value := valueNegated{NewValueBool(&raw)}
+
+	require.NoError(t, value.Set(""))
+	assert.False(t, raw)
+	require.NoError(t, value.Set("false"))
+	assert.True(t, raw)
+	require.NoError(t, value.Set("true"))
+	assert.False(t, raw)
+
+	require.Error(t, value.Set("nope"))
+	assert.False(t, raw)
+}
@@ -0,0 +1,230 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bassosimone/must"
+)
+
+// GenManPage writes a man page for the [*FlagSet] to the given [io.Writer]
+// using the roff format and the given manual section (e.g., 1 for commands).
+//
+// The man page contains the NAME, SYNOPSIS, DESCRIPTION, ARGUMENTS, OPTIONS,
+// COMMANDS, ENVIRONMENT, FILES, EXAMPLES, AUTHORS, BUGS, and SEE ALSO sections,
+// omitting the empty ones. We obtain the
+// description, the examples, and the commands from the [*FlagSet] UsagePrinter
+// when it is a [*DefaultUsagePrinter], a [*CompactUsagePrinter], or a
+// [*MarkdownUsagePrinter]. The NAME section uses the first description
+// paragraph, if any, as the program summary.
+//
+// Install the man page by saving it as `program.1` inside a directory
+// in the `$MANPATH` (e.g., `/usr/local/share/man/man1`).
+//
+// This function panics if writing to the [io.Writer] fails.
+func GenManPage(fs *FlagSet, section int, w io.Writer) {
+	description, example, commands := usagePrinterDocs(fs.UsagePrinter)
+
+	// .TH
+	must.Fprintf(w, ".TH %s %s\n", manQuote(strings.ToUpper(fs.ProgramName)), strconv.Itoa(section))
+
+	// NAME
+	must.Fprintf(w, ".SH NAME\n%s", manEscape(fs.ProgramName))
+	if len(description) > 0 && !strings.HasPrefix(description[0], indent4) {
+		summary := usageStyle{}.markup(strings.Join(strings.Fields(description[0]), " "))
+		must.Fprintf(w, " \\- %s", manEscape(summary))
+	}
+	must.Fprintf(w, "\n")
+
+	// SYNOPSIS
+	up := synopsisUsagePrinter(fs.UsagePrinter)
+	must.Fprintf(w, ".SH SYNOPSIS\n.B %s\n", manEscape(fs.ProgramName))
+	if synopsis := strings.TrimSpace(up.flagsName(fs) + up.positionalArgumentsUsage(fs)); synopsis != "" {
+		must.Fprintf(w, "%s\n", manEscape(synopsis))
+	}
+
+	// DESCRIPTION
+	if len(description) > 0 {
+		must.Fprintf(w, ".SH DESCRIPTION\n")
+		manParagraphs(w, description)
+	}
+
+	// ARGUMENTS
+	if len(fs.PositionalArguments) > 0 {
+		must.Fprintf(w, ".SH ARGUMENTS\n")
+		for idx, arg := range fs.PositionalArguments {
+			text := strings.Join(arg.Description, " ")
+			text = strings.ReplaceAll(text, "@DEFAULT_VALUE@", arg.Value.String())
+			if idx >= fs.MinPositionalArgs {
+				text = strings.TrimSpace("(optional) " + text)
+			}
+			must.Fprintf(w, ".TP\n\\fI%s\\fR\n%s\n", manEscape(arg.usageName()), manEscape(text))
+		}
+	}
+
+	// OPTIONS
+	if dflags := docsFlags(fs); len(dflags) > 0 {
+		must.Fprintf(w, ".SH OPTIONS\n")
+		groups := make([]string, 0, len(dflags))
+		for _, dflag := range dflags {
+			groups = append(groups, dflag.group)
+		}
+		for _, group := range orderFlagGroups(groups) {
+			if group != "" {
+				must.Fprintf(w, ".SS %s\n", manEscape(group))
+			}
+			for _, dflag := range dflags {
+				if dflag.group != group {
+					continue
+				}
+				synopsis := make([]string, 0, len(dflag.synopsis))
+				for _, entry := range dflag.synopsis {
+					name, placeholder := splitSynopsis(entry)
+					item := "\\fB" + manEscape(name) + "\\fR"
+					if placeholder != "" {
+						item += "\\fI" + manEscape(placeholder) + "\\fR"
+					}
+					synopsis = append(synopsis, item)
+				}
+				must.Fprintf(w, ".TP\n%s\n", strings.Join(synopsis, ", "))
+				if dflag.description != "" {
+					must.Fprintf(w, "%s\n", manEscape(dflag.description))
+				}
+			}
+		}
+	}
+
+	// COMMANDS
+	if len(commands) > 0 {
+		must.Fprintf(w, ".SH COMMANDS\n")
+		for _, cmd := range commands {
+			must.Fprintf(w, ".TP\n\\fB%s\\fR\n", manEscape(cmd.Name))
+			if cmd.Summary != "" {
+				must.Fprintf(w, "%s\n", manEscape(cmd.Summary))
+			}
+		}
+	}
+
+	// ENVIRONMENT and FILES
+	manDocEntries(w, "ENVIRONMENT", fs.Environment)
+	manDocEntries(w, "FILES", fs.Files)
+
+	// EXAMPLES
+	if len(example) > 0 {
+		must.Fprintf(w, ".SH EXAMPLES\n")
+		manParagraphs(w, example)
+	}
+
+	// AUTHORS and BUGS
+	if len(fs.Authors) > 0 {
+		must.Fprintf(w, ".SH AUTHORS\n")
+		manParagraphs(w, fs.Authors)
+	}
+	if len(fs.Bugs) > 0 {
+		must.Fprintf(w, ".SH BUGS\n")
+		manParagraphs(w, fs.Bugs)
+	}
+
+	// SEE ALSO
+	if len(fs.SeeAlso) > 0 {
+		must.Fprintf(w, ".SH SEE ALSO\n%s\n", manEscape(strings.Join(fs.SeeAlso, ", ")))
+	}
+}
+
+// manDocEntries writes the given [*DocEntry] list in the given section, if not empty.
+func manDocEntries(w io.Writer, section string, entries []*DocEntry) {
+	if len(entries) <= 0 {
+		return
+	}
+	must.Fprintf(w, ".SH %s\n", section)
+	for _, entry := range entries {
+		must.Fprintf(w, ".TP\n\\fB%s\\fR\n", manEscape(entry.Name))
+		if text := strings.Join(strings.Fields(strings.Join(entry.Description, " ")), " "); text != "" {
+			must.Fprintf(w, "%s\n", manEscape(text))
+		}
+	}
+}
+
+// usagePrinterDocs returns the description, the examples, and the commands
+// configured in the given [UsagePrinter], if it is one of ours.
+func usagePrinterDocs(printer UsagePrinter) (description, example []string, commands []*Command) {
+	switch up := printer.(type) {
+	case *DefaultUsagePrinter:
+		return up.Description, up.Example, up.Commands
+	case *CompactUsagePrinter:
+		return up.Description, up.Example, nil
+	case *MarkdownUsagePrinter:
+		return up.Description, up.Example, up.Commands
+	default:
+		return nil, nil, nil
+	}
+}
+
+// synopsisUsagePrinter returns the [*DefaultUsagePrinter] to use for generating the
+// synopsis, which honours the PositionalArgumentsUsage of the given printer.
+func synopsisUsagePrinter(printer UsagePrinter) *DefaultUsagePrinter {
+	switch up := printer.(type) {
+	case *DefaultUsagePrinter:
+		return up
+	case *CompactUsagePrinter:
+		return &DefaultUsagePrinter{PositionalArgumentsUsage: up.PositionalArgumentsUsage}
+	case *MarkdownUsagePrinter:
+		return &DefaultUsagePrinter{PositionalArgumentsUsage: up.PositionalArgumentsUsage}
+	default:
+		return &DefaultUsagePrinter{}
+	}
+}
+
+// manParagraphs writes the given paragraphs rendering verbatim blocks, i.e.,
+// consecutive verbatim paragraphs (see [verbatimLines]), as indented no-fill blocks.
+func manParagraphs(w io.Writer, entries []string) {
+	verbatim := false
+	for _, entry := range entries {
+		lines, isVerbatim := verbatimLines(entry)
+		switch {
+		case isVerbatim && !verbatim:
+			must.Fprintf(w, ".PP\n.RS 4\n.nf\n")
+			verbatim = true
+		case !isVerbatim && verbatim:
+			must.Fprintf(w, ".fi\n.RE\n")
+			verbatim = false
+		}
+		if verbatim {
+			for _, line := range lines {
+				must.Fprintf(w, "%s\n", manEscape(strings.TrimPrefix(line, indent4)))
+			}
+			continue
+		}
+		must.Fprintf(w, ".PP\n%s\n", manMarkup(manEscape(strings.Join(strings.Fields(entry), " "))))
+	}
+	if verbatim {
+		must.Fprintf(w, ".fi\n.RE\n")
+	}
+}
+
+// manMarkup renders the `code` spans in bold and the *emphasis* in italic.
+func manMarkup(value string) string {
+	value = markupCode.ReplaceAllString(value, `\fB$1\fR`)
+	return markupEmphasis.ReplaceAllString(value, `\fI$1\fR`)
+}
+
+// manEscape escapes backslashes and dashes and prevents a leading
+// period or apostrophe from being interpreted as a roff request.
+func manEscape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\e`)
+	value = strings.ReplaceAll(value, "-", `\-`)
+	if strings.HasPrefix(value, ".") || strings.HasPrefix(value, "'") {
+		value = `\&` + value
+	}
+	return value
+}
+
+// manQuote returns value as a roff request argument within double quotes.
+func manQuote(value string) string {
+	return `"` + strings.ReplaceAll(manEscape(value), `"`, `\(dq`) + `"`
+}
@@ -0,0 +1,79 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenManPage(t *testing.T) {
+	var (
+		output  = "-"
+		silent  bool
+		logfile string
+	)
+	fs := NewFlagSet("curl", ContinueOnError)
+	fs.PositionalStringVar(new(string), "URL", "The URL to transfer.")
+	fs.AutoHelp('h', "help", "Show this help message and exit.")
+	fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+	fs.BoolVar(&silent, 's', "", "Disable emitting output.")
+	fs.Group("Logging options").StringVar(&logfile, 0, "log-file", "Write logs to FILE.")
+
+	up := NewDefaultUsagePrinter()
+	up.AddDescription("Transfer a URL using the HTTP/HTTPS protocol.")
+	up.AddExamples("Fetch the homepage:", "    curl -o index.html https://www.example.com/")
+	fs.UsagePrinter = up
+
+	var sb strings.Builder
+	GenManPage(fs, 1, &sb)
+
+	expect := strings.Join([]string{
+		`.TH "CURL" 1`,
+		`.SH NAME`,
+		`curl \- Transfer a URL using the HTTP/HTTPS protocol.`,
+		`.SH SYNOPSIS`,
+		`.B curl`,
+		`[flags] URL`,
+		`.SH DESCRIPTION`,
+		`.PP`,
+		`Transfer a URL using the HTTP/HTTPS protocol.`,
+		`.SH ARGUMENTS`,
+		`.TP`,
+		`\fIURL\fR`,
+		`The URL to transfer.`,
+		`.SH OPTIONS`,
+		`.TP`,
+		`\fB\-h\fR, \fB\-\-help\fR`,
+		`Show this help message and exit.`,
+		`.TP`,
+		`\fB\-o\fR\fI STRING\fR, \fB\-\-output\fR\fI STRING\fR`,
+		`Write output to FILE. (default: \-)`,
+		`.TP`,
+		`\fB\-s\fR`,
+		`Disable emitting output.`,
+		`.SS Logging options`,
+		`.TP`,
+		`\fB\-\-log\-file\fR\fI STRING\fR`,
+		`Write logs to FILE.`,
+		`.SH EXAMPLES`,
+		`.PP`,
+		`Fetch the homepage:`,
+		`.PP`,
+		`.RS 4`,
+		`.nf`,
+		`curl \-o index.html https://www.example.com/`,
+		`.fi`,
+		`.RE`,
+		``,
+	}, "\n")
+	assert.Equal(t, expect, sb.String())
+
+	t.Run("escaping", func(t *testing.T) {
+		assert.Equal(t, `\&.hidden \e \-x`, manEscape(`.hidden \ -x`))
+	})
+}
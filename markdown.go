@@ -0,0 +1,286 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bassosimone/must"
+)
+
+// MarkdownUsagePrinter is a [UsagePrinter] rendering the usage as a Markdown
+// document suitable for documentation websites and README files. For example:
+//
+//	# curl
+//
+//	Transfer a URL using the HTTP/HTTPS protocol.
+//
+//	## Usage
+//
+//	```
+//	curl [flags] URL
+//	```
+//
+//	## Flags
+//
+//	| Flag | Description |
+//	| --- | --- |
+//	| `-o STRING`, `--output STRING` | Write output to FILE. (default: -) |
+//
+//	## Examples
+//
+//	```
+//	curl -o index.html https://www.example.com/
+//	```
+//
+// Description and examples paragraphs starting with 4 spaces or with a "```"
+// fence are verbatim blocks, which we render as fenced code blocks. Like [*FlagSet.PrintDefaults],
+// we list flags with the same description in the same table row and append
+// the default value to the description unless it is a zero value or the
+// description already contains it.
+//
+// Construct using [NewMarkdownUsagePrinter].
+type MarkdownUsagePrinter struct {
+	// Commands contains the subcommands listed when printing the usage.
+	//
+	// [NewMarkdownUsagePrinter] initializes this field to an empty slice.
+	//
+	// [*Command.AddCommand] appends to this field.
+	Commands []*Command
+
+	// Description contains the program description paragraphs.
+	//
+	// [NewMarkdownUsagePrinter] initializes this field to an empty slice.
+	Description []string
+
+	// Example contains the examples paragraphs.
+	//
+	// [NewMarkdownUsagePrinter] initializes this field to an empty slice.
+	Example []string
+
+	// PositionalArgumentsUsage is the usage string for postional arguments.
+	//
+	// [NewMarkdownUsagePrinter] initializes this field to "". See the
+	// [*DefaultUsagePrinter] field with the same name for more information.
+	PositionalArgumentsUsage string
+
+	// linkCommands causes the commands table to link the documents
+	// written by [GenMarkdownTree] for the subcommands.
+	linkCommands bool
+}
+
+// NewMarkdownUsagePrinter constructs a new [*MarkdownUsagePrinter].
+func NewMarkdownUsagePrinter() *MarkdownUsagePrinter {
+	return &MarkdownUsagePrinter{}
+}
+
+var _ UsagePrinter = &MarkdownUsagePrinter{}
+
+// PrintUsageString implements [UsagePrinter].
+//
+// This method panics on I/O error.
+func (mp *MarkdownUsagePrinter) PrintUsageString(fset *FlagSet, w io.Writer) {
+	// # Program
+	must.Fprintf(w, "# %s\n", fset.ProgramName)
+	mp.paragraphs(w, mp.Description)
+
+	// ## Usage
+	up := &DefaultUsagePrinter{PositionalArgumentsUsage: mp.PositionalArgumentsUsage}
+	must.Fprintf(w, "\n## Usage\n\n```\n%s%s%s\n```\n",
+		fset.ProgramName, up.flagsName(fset), up.positionalArgumentsUsage(fset))
+
+	// ## Arguments
+	if len(fset.PositionalArguments) > 0 {
+		must.Fprintf(w, "\n## Arguments\n\n| Argument | Description |\n| --- | --- |\n")
+		for idx, arg := range fset.PositionalArguments {
+			description := strings.Join(arg.Description, " ")
+			description = strings.ReplaceAll(description, "@DEFAULT_VALUE@", arg.Value.String())
+			if idx >= fset.MinPositionalArgs {
+				description = strings.TrimSpace("(optional) " + description)
+			}
+			must.Fprintf(w, "| %s | %s |\n", markdownCode(arg.usageName()), markdownCell(description))
+		}
+	}
+
+	// ## Flags
+	mflags := docsFlags(fset)
+	groups := make([]string, 0, len(mflags))
+	for _, mflag := range mflags {
+		groups = append(groups, mflag.group)
+	}
+	for _, group := range orderFlagGroups(groups) {
+		heading := group
+		if heading == "" {
+			heading = "Flags"
+		}
+		must.Fprintf(w, "\n## %s\n\n| Flag | Description |\n| --- | --- |\n", heading)
+		for _, mflag := range mflags {
+			if mflag.group != group {
+				continue
+			}
+			synopsis := make([]string, 0, len(mflag.synopsis))
+			for _, entry := range mflag.synopsis {
+				synopsis = append(synopsis, markdownCode(entry))
+			}
+			must.Fprintf(w, "| %s | %s |\n", strings.Join(synopsis, ", "), markdownCell(mflag.description))
+		}
+	}
+
+	// ## Commands
+	if len(mp.Commands) > 0 {
+		must.Fprintf(w, "\n## Commands\n\n| Command | Description |\n| --- | --- |\n")
+		for _, cmd := range mp.Commands {
+			name := markdownCode(cmd.Name)
+			if mp.linkCommands {
+				name = markdownLink(name, fset.ProgramName+" "+cmd.Name)
+			}
+			must.Fprintf(w, "| %s | %s |\n", name, markdownCell(cmd.Summary))
+		}
+	}
+
+	// ## Examples
+	if len(mp.Example) > 0 {
+		must.Fprintf(w, "\n## Examples\n")
+		mp.paragraphs(w, mp.Example)
+	}
+}
+
+// paragraphs prints the given paragraphs rendering verbatim blocks, i.e.,
+// consecutive verbatim paragraphs (see [verbatimLines]), as fenced code blocks.
+func (mp *MarkdownUsagePrinter) paragraphs(w io.Writer, entries []string) {
+	verbatim := false
+	for _, entry := range entries {
+		lines, isVerbatim := verbatimLines(entry)
+		switch {
+		case isVerbatim && !verbatim:
+			must.Fprintf(w, "\n```\n")
+			verbatim = true
+		case !isVerbatim && verbatim:
+			must.Fprintf(w, "```\n")
+			verbatim = false
+		}
+		if verbatim {
+			for _, line := range lines {
+				must.Fprintf(w, "%s\n", strings.TrimPrefix(line, indent4))
+			}
+			continue
+		}
+		must.Fprintf(w, "\n%s\n", strings.Join(strings.Fields(entry), " "))
+	}
+	if verbatim {
+		must.Fprintf(w, "```\n")
+	}
+}
+
+// PrintUsageError implements [UsagePrinter].
+//
+// This method panics on I/O error.
+func (mp *MarkdownUsagePrinter) PrintUsageError(fset *FlagSet, w io.Writer, err error) {
+	(&DefaultUsagePrinter{}).PrintUsageError(fset, w, err)
+}
+
+// AddDescription adds a paragraph to the current description.
+func (mp *MarkdownUsagePrinter) AddDescription(values ...string) {
+	mp.Description = append(mp.Description, values...)
+}
+
+// AddExamples adds a paragraph to the current examples.
+func (mp *MarkdownUsagePrinter) AddExamples(values ...string) {
+	mp.Example = append(mp.Example, values...)
+}
+
+// GenMarkdownTree writes a Markdown document for the given [*Command] and for each
+// of its subcommands, recursively, inside the given directory, which we create if
+// needed. Each document contains the usage, the flags, the commands, and the examples
+// as rendered by the [*MarkdownUsagePrinter], where the commands link their documents.
+//
+// We name each document after the full invocation path of the command, joining the
+// command names with underscores (e.g., `git_remote_add.md` for `git remote add`).
+// We obtain the description and the examples from the [*FlagSet] UsagePrinter of
+// each command like [GenManPage] does.
+//
+// When the [*Command] has subcommands, we also write an `index.md` document
+// containing a table linking the documents of all the commands along with
+// their Summary, such that large programs get navigable documentation.
+//
+// This function returns an error if creating the directory or writing a file fails.
+func GenMarkdownTree(cmd *Command, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := cmd.FlagSet.ProgramName
+	if len(cmd.Commands) > 0 {
+		var buf bytes.Buffer
+		must.Fprintf(&buf, "# %s\n\n| Command | Description |\n| --- | --- |\n", path)
+		genMarkdownIndex(&buf, cmd, path)
+		if err := os.WriteFile(filepath.Join(dir, "index.md"), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return genMarkdownTree(cmd, path, dir)
+}
+
+// genMarkdownIndex writes the index table rows for the command with
+// the given invocation path and for its subcommands, recursively.
+func genMarkdownIndex(w io.Writer, cmd *Command, path string) {
+	must.Fprintf(w, "| %s | %s |\n", markdownLink(markdownCode(path), path), markdownCell(cmd.Summary))
+	for _, sub := range cmd.Commands {
+		genMarkdownIndex(w, sub, path+" "+sub.Name)
+	}
+}
+
+// genMarkdownTree implements [GenMarkdownTree] for the command with the given invocation path.
+func genMarkdownTree(cmd *Command, path, dir string) error {
+	fset := cmd.FlagSet
+	description, example, _ := usagePrinterDocs(fset.UsagePrinter)
+	mp := &MarkdownUsagePrinter{
+		Commands:                 cmd.Commands,
+		Description:              description,
+		Example:                  example,
+		PositionalArgumentsUsage: synopsisUsagePrinter(fset.UsagePrinter).PositionalArgumentsUsage,
+		linkCommands:             true,
+	}
+
+	var buf bytes.Buffer
+	saved := fset.ProgramName
+	fset.ProgramName = path
+	mp.PrintUsageString(fset, &buf)
+	fset.ProgramName = saved
+
+	filename := filepath.Join(dir, markdownFileName(path))
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	for _, sub := range cmd.Commands {
+		if err := genMarkdownTree(sub, path+" "+sub.Name, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownLink returns a link to the Markdown document for the given invocation path.
+func markdownLink(text, path string) string {
+	return "[" + text + "](" + markdownFileName(path) + ")"
+}
+
+// markdownFileName returns the Markdown file name for the given invocation path.
+func markdownFileName(path string) string {
+	return strings.Join(strings.Fields(path), "_") + ".md"
+}
+
+// markdownCode returns value as a table cell code span.
+func markdownCode(value string) string {
+	return "`" + markdownCell(value) + "`"
+}
+
+// markdownCell escapes the pipe characters that would otherwise split the table cell.
+func markdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,155 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownUsagePrinter(t *testing.T) {
+	var (
+		output  = "-"
+		silent  bool
+		logfile string
+	)
+	fs := NewFlagSet("curl", ContinueOnError)
+	fs.PositionalStringVar(new(string), "URL", "The URL to transfer.")
+	fs.AutoHelp('h', "help", "Show this help message and exit.")
+	fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+	fs.BoolVar(&silent, 's', "silent", "Disable emitting output.")
+	fs.Group("Logging options").StringVar(&logfile, 0, "log-file", "Write logs to FILE.")
+
+	mp := NewMarkdownUsagePrinter()
+	mp.AddDescription("Transfer a URL using the", "HTTP/HTTPS protocol.")
+	mp.AddExamples("Fetch the homepage:", "    curl -o index.html \\", "      https://www.example.com/")
+	fs.UsagePrinter = mp
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+
+	expect := strings.Join([]string{
+		"# curl",
+		"",
+		"Transfer a URL using the",
+		"",
+		"HTTP/HTTPS protocol.",
+		"",
+		"## Usage",
+		"",
+		"```",
+		"curl [flags] URL",
+		"```",
+		"",
+		"## Arguments",
+		"",
+		"| Argument | Description |",
+		"| --- | --- |",
+		"| `URL` | The URL to transfer. |",
+		"",
+		"## Flags",
+		"",
+		"| Flag | Description |",
+		"| --- | --- |",
+		"| `-h`, `--help` | Show this help message and exit. |",
+		"| `-o STRING`, `--output STRING` | Write output to FILE. (default: -) |",
+		"| `-s`, `--silent[=true\\|false]` | Disable emitting output. |",
+		"",
+		"## Logging options",
+		"",
+		"| Flag | Description |",
+		"| --- | --- |",
+		"| `--log-file STRING` | Write logs to FILE. |",
+		"",
+		"## Examples",
+		"",
+		"Fetch the homepage:",
+		"",
+		"```",
+		"curl -o index.html \\",
+		"  https://www.example.com/",
+		"```",
+		"",
+	}, "\n")
+	assert.Equal(t, expect, sb.String())
+
+	t.Run("commands", func(t *testing.T) {
+		root := NewCommand("git", ContinueOnError)
+		root.FlagSet.UsagePrinter = NewMarkdownUsagePrinter()
+		cmd := NewCommand("commit", ContinueOnError)
+		cmd.Summary = "Record changes to the repository."
+		root.AddCommand(cmd)
+
+		var sb strings.Builder
+		root.FlagSet.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "```\ngit COMMAND [args ...]\n```\n")
+		assert.Contains(t, sb.String(), "| `commit` | Record changes to the repository. |\n")
+	})
+}
+
+func TestMarkdownUsagePrinterFencedBlocks(t *testing.T) {
+	fs := NewFlagSet("prog", ContinueOnError)
+	mp := NewMarkdownUsagePrinter()
+	mp.AddExamples("```\nprog a\n\nprog b\n```")
+	fs.UsagePrinter = mp
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+	assert.Contains(t, sb.String(), "## Examples\n\n```\nprog a\n\nprog b\n```\n")
+}
+
+func TestGenMarkdownTree(t *testing.T) {
+	var verbose bool
+	root := NewCommand("git", ContinueOnError)
+	root.FlagSet.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+	remote := NewCommand("remote", ContinueOnError)
+	remote.Summary = "Manage the remotes."
+	root.AddCommand(remote)
+	add := NewCommand("add", ContinueOnError)
+	add.FlagSet.SetMinMaxPositionalArgs(2, 2)
+	add.FlagSet.UsagePrinter.(*DefaultUsagePrinter).PositionalArgumentsUsage = "NAME URL"
+	add.FlagSet.UsagePrinter.(*DefaultUsagePrinter).AddExamples("    git remote add origin https://example.com/repo")
+	remote.AddCommand(add)
+
+	dir := filepath.Join(t.TempDir(), "docs")
+	require.NoError(t, GenMarkdownTree(root, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "git.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# git\n")
+	assert.Contains(t, string(data), "```\ngit [flags] COMMAND [args ...]\n```\n")
+	assert.Contains(t, string(data), "| [`remote`](git_remote.md) | Manage the remotes. |\n")
+
+	data, err = os.ReadFile(filepath.Join(dir, "git_remote_add.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# git remote add\n")
+	assert.Contains(t, string(data), "```\ngit remote add NAME URL\n```\n")
+	assert.Contains(t, string(data), "## Examples\n\n```\ngit remote add origin https://example.com/repo\n```\n")
+	assert.Equal(t, "add", add.FlagSet.ProgramName)
+
+	_, err = os.Stat(filepath.Join(dir, "git_remote.md"))
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(filepath.Join(dir, "index.md"))
+	require.NoError(t, err)
+	expect := strings.Join([]string{
+		"# git",
+		"",
+		"| Command | Description |",
+		"| --- | --- |",
+		"| [`git`](git.md) |  |",
+		"| [`git remote`](git_remote.md) | Manage the remotes. |",
+		"| [`git remote add`](git_remote_add.md) |  |",
+		"",
+	}, "\n")
+	assert.Equal(t, expect, string(data))
+
+	require.Error(t, GenMarkdownTree(root, filepath.Join(dir, "git.md")))
+}
@@ -0,0 +1,231 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bassosimone/runtimex"
+)
+
+// PositionalArgument is a named positional argument bound to a [Value].
+//
+// [*FlagSet.Parse] assigns the positional arguments to the declared
+// [*PositionalArgument] entries in order, using [Value.Set], such that
+// positional arguments get the same parsing and validation as flags.
+//
+// Construct using [NewPositionalArgument].
+type PositionalArgument struct {
+	// Completion controls how the completion scripts, such as the ones
+	// written by [*FlagSet.GenBashCompletion], complete the argument.
+	Completion Completion
+
+	// Description contains the argument description paragraphs to use in the help.
+	Description []string
+
+	// Name is the argument name to use in the help and in errors (e.g., `HOST`).
+	Name string
+
+	// Value is the argument [Value].
+	Value Value
+
+	// Variadic indicates that the argument captures one or more positional
+	// arguments by calling [Value.Set] for each of them, which requires
+	// a [Value] accumulating values, such as [ValueStringSlice].
+	Variadic bool
+}
+
+// NewPositionalArgument constructs a new [*PositionalArgument] bound to the given [Value].
+func NewPositionalArgument(value Value, name string, helpText ...string) *PositionalArgument {
+	return &PositionalArgument{
+		Description: helpText,
+		Name:        name,
+		Value:       value,
+	}
+}
+
+// usageName returns the name to use in the help, which is followed
+// by `...` for Variadic arguments (e.g., `FILE...`).
+func (arg *PositionalArgument) usageName() string {
+	if arg.Variadic {
+		return arg.Name + "..."
+	}
+	return arg.Name
+}
+
+// AddPositionalArgument adds a [*PositionalArgument] to PositionalArguments.
+//
+// Each declared positional argument is required, therefore, this method increments
+// MinPositionalArgs and, if needed, MaxPositionalArgs. Call [*FlagSet.SetMinMaxPositionalArgs]
+// afterwards to make the trailing positional arguments optional or to accept
+// additional positional arguments, which are available using [*FlagSet.Args].
+//
+// A Variadic argument also sets MaxPositionalArgs to [math.MaxInt] and captures
+// the positional arguments between the ones declared before and after it, which
+// allows to declare shapes such as `cp SRC... DST`.
+//
+// This method panics if the argument name is empty or if a Variadic argument
+// has already been declared and the given argument is also Variadic.
+func (fs *FlagSet) AddPositionalArgument(arg *PositionalArgument) {
+	runtimex.Assert(arg.Name != "")
+	runtimex.Assert(!arg.Variadic || fs.variadicPositionalIndex() < 0)
+	fs.PositionalArguments = append(fs.PositionalArguments, arg)
+	fs.MinPositionalArgs++
+	fs.MaxPositionalArgs = max(fs.MaxPositionalArgs, fs.MinPositionalArgs)
+	if arg.Variadic {
+		fs.MaxPositionalArgs = math.MaxInt
+	}
+}
+
+// PositionalVar declares a positional argument bound to the given [Value].
+//
+// See [*FlagSet.AddPositionalArgument] for more information.
+func (fs *FlagSet) PositionalVar(value Value, name string, helpText ...string) {
+	fs.AddPositionalArgument(NewPositionalArgument(value, name, helpText...))
+}
+
+// PositionalIntVar declares a positional argument bound to an int.
+//
+// See [*FlagSet.AddPositionalArgument] for more information.
+func (fs *FlagSet) PositionalIntVar(vp *int, name string, helpText ...string) {
+	fs.PositionalVar(NewValueInt(vp), name, helpText...)
+}
+
+// PositionalStringVar declares a positional argument bound to a string.
+//
+// See [*FlagSet.AddPositionalArgument] for more information.
+func (fs *FlagSet) PositionalStringVar(vp *string, name string, helpText ...string) {
+	fs.PositionalVar(NewValueString(vp), name, helpText...)
+}
+
+// PositionalRestVar declares a Variadic positional argument bound to a string slice.
+//
+// See [*FlagSet.AddPositionalArgument] for more information.
+func (fs *FlagSet) PositionalRestVar(vp *[]string, name string, helpText ...string) {
+	arg := NewPositionalArgument(NewValueStringSlice(vp), name, helpText...)
+	arg.Variadic = true
+	fs.AddPositionalArgument(arg)
+}
+
+// variadicPositionalIndex returns the index of the Variadic argument or -1.
+func (fs *FlagSet) variadicPositionalIndex() int {
+	for idx, arg := range fs.PositionalArguments {
+		if arg.Variadic {
+			return idx
+		}
+	}
+	return -1
+}
+
+// SetPositionalPattern sets MinPositionalArgs, MaxPositionalArgs, and
+// PositionalPattern according to the given pattern, which ensures that
+// the usage string and the actual constraints agree. For example:
+//
+//	fs.SetPositionalPattern("SRC... DST")
+//	fs.SetPositionalPattern("[USER@]HOST CMD [ARG...]")
+//
+// The pattern contains whitespace-separated arguments. An argument entirely
+// within brackets (e.g., `[ARG]`) is optional and an argument ending with `...`
+// (e.g., `SRC...` or `[ARG...]`) is variadic, i.e., it accepts one or more
+// positional arguments, or zero or more when it is also optional.
+//
+// This method panics if the pattern is empty, contains unbalanced brackets,
+// or contains more than one variadic argument.
+func (fs *FlagSet) SetPositionalPattern(pattern string) {
+	minArgs, maxArgs, variadic := 0, 0, false
+	fields := strings.Fields(pattern)
+	runtimex.Assert(len(fields) > 0)
+	for _, field := range fields {
+		name, optional := positionalPatternField(field)
+		runtimex.Assert(name != "")
+		if strings.HasSuffix(name, "...") {
+			runtimex.Assert(!variadic)
+			variadic = true
+		}
+		if !optional {
+			minArgs++
+		}
+		maxArgs++
+	}
+	if variadic {
+		maxArgs = math.MaxInt
+	}
+	fs.SetMinMaxPositionalArgs(minArgs, maxArgs)
+	fs.PositionalPattern = strings.Join(fields, " ")
+}
+
+// positionalPatternField returns the name of a [*FlagSet.SetPositionalPattern]
+// argument without the surrounding brackets and whether it is optional.
+//
+// This function panics if the field contains unbalanced brackets.
+func positionalPatternField(field string) (string, bool) {
+	depth, closedAt := 0, -1
+	for idx, ch := range field {
+		switch ch {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			runtimex.Assert(depth >= 0)
+			if depth == 0 && closedAt < 0 {
+				closedAt = idx
+			}
+		}
+	}
+	runtimex.Assert(depth == 0)
+	if field[0] == '[' && closedAt == len(field)-1 {
+		return field[1 : len(field)-1], true
+	}
+	return field, false
+}
+
+// assignPositionals assigns the positional arguments to PositionalArguments
+// and returns the errors that occurred. The arguments declared after the Variadic
+// argument take the last positional arguments and the Variadic argument takes
+// the ones in between. Positional arguments beyond the declared ones are not
+// assigned and declared arguments without a corresponding positional
+// argument keep their default value.
+func (fs *FlagSet) assignPositionals() (errs []error) {
+	// map the positional arguments to the declared arguments
+	type assignment struct {
+		arg    *PositionalArgument
+		values []string
+	}
+	var assignments []assignment
+	variadic := fs.variadicPositionalIndex()
+	before, after, avail := fs.PositionalArguments, []*PositionalArgument(nil), fs.positionals
+	if variadic >= 0 {
+		before, after = fs.PositionalArguments[:variadic], fs.PositionalArguments[variadic+1:]
+	}
+	for idx, arg := range before {
+		if idx < len(avail) {
+			assignments = append(assignments, assignment{arg, avail[idx : idx+1]})
+		}
+	}
+	avail = avail[min(len(before), len(avail)):]
+	nafter := min(len(after), len(avail))
+	if variadic >= 0 && len(avail) > nafter {
+		assignments = append(assignments, assignment{fs.PositionalArguments[variadic], avail[:len(avail)-nafter]})
+	}
+	for idx, arg := range after[:nafter] {
+		offset := len(avail) - nafter + idx
+		assignments = append(assignments, assignment{arg, avail[offset : offset+1]})
+	}
+
+	// assign the values to the declared arguments
+	for _, entry := range assignments {
+		for _, value := range entry.values {
+			if err := entry.arg.Value.Set(value); err != nil {
+				errs = append(errs, fmt.Errorf("argument %s: %w", entry.arg.Name, err))
+				if !fs.CollectAllErrors {
+					return
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,186 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagSetPositionalArguments(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *int) {
+		var (
+			host string
+			port = 22
+		)
+		fset := NewFlagSet("connect", ContinueOnError)
+		fset.PositionalStringVar(&host, "HOST", "The server host.")
+		fset.PositionalIntVar(&port, "PORT", "The server port.")
+		return fset, &host, &port
+	}
+
+	t.Run("declaration", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		require.Len(t, fset.PositionalArguments, 2)
+		assert.Equal(t, "HOST", fset.PositionalArguments[0].Name)
+		assert.Equal(t, []string{"The server port."}, fset.PositionalArguments[1].Description)
+		assert.Equal(t, 2, fset.MinPositionalArgs)
+		assert.Equal(t, 2, fset.MaxPositionalArgs)
+	})
+
+	t.Run("assignment", func(t *testing.T) {
+		fset, host, port := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"example.com", "2222"}))
+		assert.Equal(t, "example.com", *host)
+		assert.Equal(t, 2222, *port)
+		assert.Equal(t, []string{"example.com", "2222"}, fset.Args())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		err := fset.Parse([]string{"example.com", "ssh"})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "argument PORT: ")
+	})
+
+	t.Run("too few arguments", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		assert.Error(t, fset.Parse([]string{"example.com"}))
+	})
+
+	t.Run("optional trailing argument", func(t *testing.T) {
+		fset, host, port := newFlagSet()
+		fset.SetMinMaxPositionalArgs(1, 2)
+		require.NoError(t, fset.Parse([]string{"example.com"}))
+		assert.Equal(t, "example.com", *host)
+		assert.Equal(t, 22, *port)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		fset := NewFlagSet("connect", ContinueOnError)
+		var host string
+		assert.Panics(t, func() {
+			fset.PositionalStringVar(&host, "")
+		})
+	})
+}
+
+func TestFlagSetPositionalRestVar(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *[]string, *string) {
+		var (
+			sources     []string
+			destination string
+		)
+		fset := NewFlagSet("cp", ContinueOnError)
+		fset.PositionalRestVar(&sources, "SRC", "The files to copy.")
+		fset.PositionalStringVar(&destination, "DST", "The destination.")
+		return fset, &sources, &destination
+	}
+
+	t.Run("declaration", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		assert.Equal(t, 2, fset.MinPositionalArgs)
+		assert.Equal(t, math.MaxInt, fset.MaxPositionalArgs)
+		assert.True(t, fset.PositionalArguments[0].Variadic)
+		usage := NewDefaultUsagePrinter()
+		assert.Equal(t, " SRC... DST", usage.positionalArgumentsUsage(fset))
+	})
+
+	t.Run("many sources", func(t *testing.T) {
+		fset, sources, destination := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"a", "b", "c", "dir"}))
+		assert.Equal(t, []string{"a", "b", "c"}, *sources)
+		assert.Equal(t, "dir", *destination)
+	})
+
+	t.Run("single source", func(t *testing.T) {
+		fset, sources, destination := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"a", "dir"}))
+		assert.Equal(t, []string{"a"}, *sources)
+		assert.Equal(t, "dir", *destination)
+	})
+
+	t.Run("too few arguments", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		assert.Error(t, fset.Parse([]string{"dir"}))
+	})
+
+	t.Run("fixed arguments before", func(t *testing.T) {
+		var (
+			command string
+			args    []string
+		)
+		fset := NewFlagSet("xargs", ContinueOnError)
+		fset.PositionalStringVar(&command, "CMD", "The command.")
+		fset.PositionalRestVar(&args, "ARG", "The arguments.")
+		require.NoError(t, fset.Parse([]string{"ls", "/tmp", "/var"}))
+		assert.Equal(t, "ls", command)
+		assert.Equal(t, []string{"/tmp", "/var"}, args)
+	})
+
+	t.Run("typed slice", func(t *testing.T) {
+		var ports []int
+		fset := NewFlagSet("scan", ContinueOnError)
+		fset.AddPositionalArgument(&PositionalArgument{
+			Name:     "PORT",
+			Value:    NewValueIntSlice(&ports),
+			Variadic: true,
+		})
+		require.NoError(t, fset.Parse([]string{"80", "443"}))
+		assert.Equal(t, []int{80, 443}, ports)
+	})
+
+	t.Run("multiple variadic arguments", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		var other []string
+		assert.Panics(t, func() {
+			fset.PositionalRestVar(&other, "OTHER")
+		})
+	})
+}
+
+func TestFlagSetSetPositionalPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		minArgs int
+		maxArgs int
+		usage   string
+	}{
+		{"SRC... DST", 2, math.MaxInt, " SRC... DST"},
+		{"[USER@]HOST CMD [ARG...]", 2, math.MaxInt, " [USER@]HOST CMD [ARG...]"},
+		{"URL", 1, 1, " URL"},
+		{"  FILE   [FILE] ", 1, 2, " FILE [FILE]"},
+		{"[[USER@]HOST]", 0, 1, " [[USER@]HOST]"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			fset := NewFlagSet("prog", ContinueOnError)
+			fset.SetPositionalPattern(tc.pattern)
+			assert.Equal(t, tc.minArgs, fset.MinPositionalArgs)
+			assert.Equal(t, tc.maxArgs, fset.MaxPositionalArgs)
+			usage := NewDefaultUsagePrinter()
+			assert.Equal(t, tc.usage, usage.positionalArgumentsUsage(fset))
+		})
+	}
+
+	for _, pattern := range []string{"", "[ARG", "ARG]", "A... B...", "[]"} {
+		t.Run("invalid "+pattern, func(t *testing.T) {
+			fset := NewFlagSet("prog", ContinueOnError)
+			assert.Panics(t, func() {
+				fset.SetPositionalPattern(pattern)
+			})
+		})
+	}
+
+	t.Run("parsing", func(t *testing.T) {
+		fset := NewFlagSet("ssh", ContinueOnError)
+		fset.SetPositionalPattern("[USER@]HOST CMD [ARG...]")
+		assert.Error(t, fset.Parse([]string{"example.com"}))
+		require.NoError(t, fset.Parse([]string{"example.com", "ls", "/tmp"}))
+	})
+}
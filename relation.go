@@ -0,0 +1,129 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bassosimone/runtimex"
+)
+
+// MarkConflicts records that the flags with the given names cannot be set
+// together on the command line. For example:
+//
+//	fs.MarkConflicts("quiet", "verbose")
+//
+// Names are either long flag names or aliases, or short flag names, like
+// in [*FlagSet.Changed]. Violating the constraint causes [*FlagSet.Parse] to
+// fail with an error naming both flags (e.g., `--quiet conflicts with --verbose`).
+//
+// This method panics if either name does not refer to a registered flag.
+func (fs *FlagSet) MarkConflicts(name, other string) {
+	flag, otherFlag := fs.flagDisplayName(name), fs.flagDisplayName(other)
+	fs.AddValidator(func(fs *FlagSet) error {
+		if fs.Changed(name) && fs.Changed(other) {
+			return fmt.Errorf("%s conflicts with %s", flag, otherFlag)
+		}
+		return nil
+	})
+}
+
+// MarkRequires records that, if the flag with the given name is set on the
+// command line, the flag with the required name must be set as well. For example:
+//
+//	fs.MarkRequires("tls-cert", "tls-key")
+//
+// Names are either long flag names or aliases, or short flag names, like
+// in [*FlagSet.Changed]. Violating the requirement causes [*FlagSet.Parse]
+// to fail with an error naming both flags (e.g., `--tls-cert requires --tls-key`).
+//
+// This method panics if either name does not refer to a registered flag.
+func (fs *FlagSet) MarkRequires(name, required string) {
+	flag, requiredFlag := fs.flagDisplayName(name), fs.flagDisplayName(required)
+	fs.AddValidator(func(fs *FlagSet) error {
+		if fs.Changed(name) && !fs.Changed(required) {
+			return fmt.Errorf("%s requires %s", flag, requiredFlag)
+		}
+		return nil
+	})
+}
+
+// MarkRequired marks the flag with the given name, and its short or long form,
+// as required (see the Required field), such that [*FlagSet.Parse] fails with
+// an error like `missing required flag: --output` when neither the flag nor its
+// short or long form is set on the command line, using a config file, or using
+// an environment variable.
+//
+// Names are either long flag names or aliases, or short flag names, like in
+// [*FlagSet.Changed]. The help annotates required flags with `(required)`
+// and lists them explicitly in the usage line (e.g., `curl --output FILE URL`).
+//
+// This method panics if the name does not refer to a registered flag.
+func (fs *FlagSet) MarkRequired(name string) {
+	shorts, longs := fs.boundFlags(name)
+	for _, fx := range shorts {
+		fx.Required = true
+	}
+	for _, fx := range longs {
+		fx.Required = true
+	}
+}
+
+// boundFlags returns the flag with the given name and its short or long form (see [sameFlag]).
+//
+// This method panics if the name does not refer to a registered flag.
+func (fs *FlagSet) boundFlags(name string) (shorts []*ShortFlag, longs []*LongFlag) {
+	sfx, lfx, found := fs.lookupFlag(name)
+	runtimex.Assert(found)
+	for _, fx := range fs.ShortFlags {
+		if fx == sfx || (lfx != nil && sameFlag(fx, lfx)) {
+			shorts = append(shorts, fx)
+		}
+	}
+	for _, fx := range fs.LongFlags {
+		if fx == lfx || (sfx != nil && sameFlag(sfx, fx)) {
+			longs = append(longs, fx)
+		}
+	}
+	return
+}
+
+// checkRequired returns an error for each required flag that is not set, where
+// we report a single error for a required flag and its short or long form.
+func (fs *FlagSet) checkRequired() (errs []error) {
+	var reported []*LongFlag
+	for _, fx := range fs.LongFlags {
+		if fx.Required && !fx.configured && !fs.longFlagGiven(fx) {
+			reported = append(reported, fx)
+			errs = append(errs, fmt.Errorf("missing required flag: %s%s", fx.Prefix, fx.Name))
+		}
+	}
+	for _, fx := range fs.ShortFlags {
+		if !fx.Required || fx.occurrences > 0 {
+			continue
+		}
+		if slices.ContainsFunc(fs.LongFlags, func(lfx *LongFlag) bool {
+			return sameFlag(fx, lfx) && (lfx.configured || lfx.occurrences > 0 || slices.Contains(reported, lfx))
+		}) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("missing required flag: %s%s", fx.Prefix, string(fx.Name)))
+	}
+	return
+}
+
+// flagDisplayName returns the name of the flag with the given name including
+// its prefix (e.g., `--output`), preferring long flags over short flags.
+//
+// This method panics if the name does not refer to a registered flag.
+func (fs *FlagSet) flagDisplayName(name string) string {
+	if fx := fs.lookupLongFlag(name); fx != nil {
+		return fx.Prefix + name
+	}
+	idx := slices.IndexFunc(fs.ShortFlags, func(fx *ShortFlag) bool { return string(fx.Name) == name })
+	runtimex.Assert(idx >= 0)
+	return fs.ShortFlags[idx].Prefix + name
+}
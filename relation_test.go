@@ -0,0 +1,201 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagSetMarkRequires(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			tlsCert string
+			tlsKey  string
+		)
+		fset := NewFlagSet("server", ContinueOnError)
+		fset.StringVar(&tlsCert, 'c', "tls-cert", "Use the given TLS certificate.")
+		fset.StringVar(&tlsKey, 'k', "tls-key", "Use the given TLS key.")
+		fset.MarkRequires("tls-cert", "k")
+		return fset
+	}
+
+	cases := []struct {
+		args   []string
+		expect string
+	}{
+		{nil, ""},
+		{[]string{"--tls-key", "key.pem"}, ""},
+		{[]string{"--tls-cert", "cert.pem", "-k", "key.pem"}, ""},
+		{[]string{"-c", "cert.pem"}, "--tls-cert requires -k"},
+	}
+	for _, tc := range cases {
+		err := newFlagSet().Parse(tc.args)
+		if tc.expect == "" {
+			assert.NoError(t, err, tc.args)
+			continue
+		}
+		assert.EqualError(t, err, tc.expect, tc.args)
+	}
+
+	t.Run("short flags with non-comparable values", func(t *testing.T) {
+		newFlagSet := func() *FlagSet {
+			var color string
+			fset := NewFlagSet("prog", ContinueOnError)
+			fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+			fset.Func('f', "format", "Set the format.", func(string) error { return nil })
+			fset.MarkRequires("format", "color")
+			return fset
+		}
+		assert.NoError(t, newFlagSet().Parse([]string{"-f", "x", "-c", "red"}))
+		assert.EqualError(t, newFlagSet().Parse([]string{"-f", "x"}), "--format requires --color")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		fset := NewFlagSet("server", ContinueOnError)
+		assert.Panics(t, func() {
+			fset.MarkRequires("tls-cert", "tls-key")
+		})
+	})
+}
+
+func TestFlagSetMarkConflicts(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			quiet   bool
+			verbose bool
+		)
+		fset := NewFlagSet("prog", ContinueOnError)
+		fset.BoolVar(&quiet, 'q', "quiet", "Suppress output.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		fset.MarkConflicts("quiet", "verbose")
+		return fset
+	}
+
+	cases := []struct {
+		args   []string
+		expect string
+	}{
+		{nil, ""},
+		{[]string{"-q"}, ""},
+		{[]string{"--verbose"}, ""},
+		{[]string{"-qv"}, "--quiet conflicts with --verbose"},
+		{[]string{"--verbose", "--quiet"}, "--quiet conflicts with --verbose"},
+	}
+	for _, tc := range cases {
+		err := newFlagSet().Parse(tc.args)
+		if tc.expect == "" {
+			assert.NoError(t, err, tc.args)
+			continue
+		}
+		assert.EqualError(t, err, tc.expect, tc.args)
+	}
+
+	t.Run("short flags with non-comparable values", func(t *testing.T) {
+		newFlagSet := func() *FlagSet {
+			var (
+				color string
+				quiet bool
+			)
+			fset := NewFlagSet("prog", ContinueOnError)
+			fset.BoolVar(&quiet, 'q', "quiet", "Suppress output.")
+			fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+			fset.Func('f', "filter", "Add a filter.", func(string) error { return nil })
+			fset.MarkConflicts("quiet", "filter")
+			fset.MarkConflicts("color", "quiet")
+			return fset
+		}
+		assert.NoError(t, newFlagSet().Parse([]string{"-f", "x"}))
+		assert.EqualError(t, newFlagSet().Parse([]string{"-q", "-f", "x"}), "--quiet conflicts with --filter")
+		assert.EqualError(t, newFlagSet().Parse([]string{"-c", "red", "-q"}), "--color conflicts with --quiet")
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		fset := NewFlagSet("prog", ContinueOnError)
+		assert.Panics(t, func() {
+			fset.MarkConflicts("quiet", "verbose")
+		})
+	})
+}
+
+func TestFlagSetMarkRequired(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			output  string
+			verbose bool
+		)
+		fset := NewFlagSet("curl", ContinueOnError)
+		fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		fset.MarkRequired("output")
+		return fset
+	}
+
+	cases := []struct {
+		args   []string
+		expect string
+	}{
+		{nil, "missing required flag: --output"},
+		{[]string{"-v"}, "missing required flag: --output"},
+		{[]string{"-o", "index.html"}, ""},
+		{[]string{"--output", "index.html"}, ""},
+	}
+	for _, tc := range cases {
+		err := newFlagSet().Parse(tc.args)
+		if tc.expect == "" {
+			assert.NoError(t, err, tc.args)
+			continue
+		}
+		assert.EqualError(t, err, tc.expect, tc.args)
+	}
+
+	t.Run("non-comparable values", func(t *testing.T) {
+		newFlagSet := func() *FlagSet {
+			var color string
+			fset := NewFlagSet("prog", ContinueOnError)
+			fset.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+			fset.MarkRequired("color")
+			return fset
+		}
+		fset := newFlagSet()
+		assert.True(t, fset.ShortFlags[0].Required)
+		assert.True(t, fset.LongFlags[0].Required)
+		assert.NoError(t, fset.Parse([]string{"-c", "blue"}))
+		assert.NoError(t, newFlagSet().Parse([]string{"--color", "blue"}))
+		assert.EqualError(t, newFlagSet().Parse(nil), "missing required flag: --color")
+
+		t.Setenv("PROG_COLOR", "red")
+		fset = newFlagSet()
+		fset.SetEnvVar("color", "PROG_COLOR")
+		assert.NoError(t, fset.Parse(nil))
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv("CURL_OUTPUT", "index.html")
+		fset := newFlagSet()
+		fset.SetEnvVar("output", "CURL_OUTPUT")
+		assert.NoError(t, fset.Parse(nil))
+	})
+
+	t.Run("help", func(t *testing.T) {
+		fset := newFlagSet()
+		var sb strings.Builder
+		fset.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "\n    curl [flags] --output STRING\n")
+		assert.Contains(t, sb.String(), "\n    -o STRING, --output STRING (required)\n")
+
+		fset.UsagePrinter = NewCompactUsagePrinter()
+		sb.Reset()
+		fset.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "Usage: curl [flags] --output STRING\n")
+		assert.Contains(t, sb.String(), "  -o, --output STRING         Write output to FILE. (required)\n")
+	})
+
+	t.Run("no such flag", func(t *testing.T) {
+		assert.Panics(t, func() { newFlagSet().MarkRequired("quiet") })
+	})
+}
@@ -0,0 +1,54 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bassosimone/flagparser"
+)
+
+// expandResponseFiles replaces each `@FILE` argument preceding the
+// OptionsArgumentsSeparator with the arguments contained in FILE, which we
+// split using the POSIX shell quoting rules, recursively, up to the
+// MaxResponseFileDepth nesting depth. We do not expand the arguments that
+// the given options consume as their value (e.g., `@alice` in `--user @alice`).
+func (fs *FlagSet) expandResponseFiles(args []string, options []*flagparser.Option, depth int) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if fs.OptionsArgumentsSeparator != "" && arg == fs.OptionsArgumentsSeparator {
+			expanded = append(expanded, args[idx:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '@' {
+			expanded = append(expanded, arg)
+			if _, valueOption := classifyArg(arg, options); valueOption != nil && idx+1 < len(args) {
+				idx++
+				expanded = append(expanded, args[idx])
+			}
+			continue
+		}
+		filename := arg[1:]
+		if depth >= fs.MaxResponseFileDepth {
+			return nil, fmt.Errorf("response file nesting too deep: %s", filename)
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		words, err := splitShellWords(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		entries, err := fs.expandResponseFiles(words, options, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, entries...)
+	}
+	return expanded, nil
+}
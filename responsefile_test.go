@@ -0,0 +1,99 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagSetExpandResponseFiles(t *testing.T) {
+	dir := t.TempDir()
+	outer := filepath.Join(dir, "outer.txt")
+	inner := filepath.Join(dir, "inner.txt")
+	require.NoError(t, os.WriteFile(outer, []byte("-v\n--output out.o @"+inner+"\n"), 0600))
+	require.NoError(t, os.WriteFile(inner, []byte("a.o  b.o\tc.o\n"), 0600))
+
+	newFlagSet := func() (*FlagSet, *bool, *string) {
+		var (
+			output  string
+			verbose bool
+		)
+		fset := NewFlagSet("ld", ContinueOnError)
+		fset.ExpandResponseFiles = true
+		fset.SetMinMaxPositionalArgs(0, math.MaxInt)
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+		return fset, &verbose, &output
+	}
+
+	t.Run("nested expansion", func(t *testing.T) {
+		fset, verbose, output := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"@" + outer, "d.o"}))
+		assert.True(t, *verbose)
+		assert.Equal(t, "out.o", *output)
+		assert.Equal(t, []string{"a.o", "b.o", "c.o", "d.o"}, fset.Args())
+	})
+
+	t.Run("quoted arguments", func(t *testing.T) {
+		quoted := filepath.Join(dir, "quoted.txt")
+		require.NoError(t, os.WriteFile(quoted, []byte("-o 'my file.o' \"a b.o\" c\\ d.o\n"), 0600))
+		fset, _, output := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"@" + quoted}))
+		assert.Equal(t, "my file.o", *output)
+		assert.Equal(t, []string{"a b.o", "c d.o"}, fset.Args())
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		quoted := filepath.Join(dir, "unterminated.txt")
+		require.NoError(t, os.WriteFile(quoted, []byte("'a.o\n"), 0600))
+		fset, _, _ := newFlagSet()
+		assert.Error(t, fset.Parse([]string{"@" + quoted}))
+	})
+
+	t.Run("no expansion of option values", func(t *testing.T) {
+		fset, _, output := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-o", "@" + inner, "--output", "@out", "@" + inner}))
+		assert.Equal(t, "@out", *output)
+		assert.Equal(t, []string{"a.o", "b.o", "c.o"}, fset.Args())
+
+		fset, _, output = newFlagSet()
+		require.NoError(t, fset.Parse([]string{"-vo", "@" + inner}))
+		assert.Equal(t, "@"+inner, *output)
+		assert.Empty(t, fset.Args())
+	})
+
+	t.Run("no expansion after the separator", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		require.NoError(t, fset.Parse([]string{"--", "@" + inner, "@"}))
+		assert.Equal(t, []string{"@" + inner, "@"}, fset.Args())
+	})
+
+	t.Run("no expansion when disabled", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		fset.ExpandResponseFiles = false
+		require.NoError(t, fset.Parse([]string{"@" + inner}))
+		assert.Equal(t, []string{"@" + inner}, fset.Args())
+	})
+
+	t.Run("nesting too deep", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		fset.MaxResponseFileDepth = 1
+		err := fset.Parse([]string{"@" + outer})
+		require.Error(t, err)
+		assert.Equal(t, "response file nesting too deep: "+inner, err.Error())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		fset, _, _ := newFlagSet()
+		err := fset.Parse([]string{"@" + filepath.Join(dir, "missing.txt")})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,595 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bassosimone/flagparser"
+)
+
+// rewriteArgs returns a copy of the arguments where we apply the given rewrite
+// function to each argument that the parser could interpret as an option, which
+// allows supporting syntaxes that the parser does not natively support.
+//
+// We skip the values of the options taking a separate argument (e.g., `FILE` in
+// `-o FILE`), and we stop at the OptionsArgumentsSeparator and, when permutation
+// is disabled, at the first positional argument. We apply the rewrite function
+// before classifying the argument, such that the rewritten argument determines
+// whether the following argument is the option value.
+func (fs *FlagSet) rewriteArgs(args []string, options []*flagparser.Option, rewrite func(arg string) string) []string {
+	output := slices.Clone(args)
+	fs.visitArgs(output, options, func(idx int) {
+		output[idx] = rewrite(output[idx])
+	})
+	return output
+}
+
+// expandArgs is like [*FlagSet.rewriteArgs] but the expand function may replace
+// each argument with several arguments, where the last one determines whether
+// the following argument is the option value.
+func (fs *FlagSet) expandArgs(args []string, options []*flagparser.Option, expand func(arg string) []string) []string {
+	var (
+		input  = slices.Clone(args)
+		next   int
+		output []string
+	)
+	fs.visitArgs(input, options, func(idx int) {
+		expanded := expand(input[idx])
+		output = append(append(output, input[next:idx]...), expanded...)
+		input[idx], next = expanded[len(expanded)-1], idx+1
+	})
+	return append(output, input[next:]...)
+}
+
+// visitArgs calls visit with the index of each argument that the parser could
+// interpret as an option, skipping the same arguments as [*FlagSet.rewriteArgs].
+// Because we classify the argument after calling visit, visit may modify it.
+func (fs *FlagSet) visitArgs(args []string, options []*flagparser.Option, visit func(idx int)) {
+	for idx := 0; idx < len(args); idx++ {
+		if fs.OptionsArgumentsSeparator != "" && args[idx] == fs.OptionsArgumentsSeparator {
+			break
+		}
+		visit(idx)
+		isOption, valueOption := classifyArg(args[idx], options)
+		switch {
+		case !isOption && (fs.permuteDisabled() || fs.StopAtFirstPositional):
+			return
+		case valueOption != nil:
+			idx++
+		}
+	}
+}
+
+// rewritePrefixAliases rewrites the arguments using the PrefixAliases of the
+// short flags to use their Prefix instead (e.g., `/4` becomes `-4`).
+func (fs *FlagSet) rewritePrefixAliases(args []string, options []*flagparser.Option) []string {
+	if !slices.ContainsFunc(fs.ShortFlags, func(fx *ShortFlag) bool { return len(fx.PrefixAliases) > 0 }) {
+		return args
+	}
+	return fs.rewriteArgs(args, options, func(arg string) string {
+		if optionPrefix(arg, options) != "" {
+			return arg
+		}
+		for _, fx := range fs.ShortFlags {
+			for _, alias := range fx.PrefixAliases {
+				if alias != "" && strings.HasPrefix(arg, alias+string(fx.Name)) {
+					return fx.Prefix + strings.TrimPrefix(arg, alias)
+				}
+			}
+		}
+		return arg
+	})
+}
+
+// rewriteCaseInsensitive rewrites the arguments matching a long flag when
+// ignoring the case to use the flag name as registered (e.g., `--Output=x`
+// becomes `--output=x`) when CaseInsensitive is true.
+func (fs *FlagSet) rewriteCaseInsensitive(args []string, options []*flagparser.Option) []string {
+	if !fs.CaseInsensitive {
+		return args
+	}
+	return fs.rewriteArgs(args, options, func(arg string) string {
+		prefix := optionPrefix(arg, options)
+		name, value, found := strings.Cut(arg[len(prefix):], "=")
+		var match *flagparser.Option
+		for _, option := range options {
+			if option.Prefix != prefix || findGroupableOption(prefix, option.Name, options) != nil {
+				continue
+			}
+			if option.Name == name {
+				return arg
+			}
+			if match == nil && strings.EqualFold(option.Name, name) {
+				match = option
+			}
+		}
+		switch {
+		case match == nil:
+			return arg
+		case found:
+			return prefix + match.Name + "=" + value
+		default:
+			return prefix + match.Name
+		}
+	})
+}
+
+// rewriteAbbreviations rewrites the arguments using an unambiguous prefix of a
+// long flag name to use the flag name (e.g., `--verb` becomes `--verbose`) when
+// AllowAbbreviations is true. We return an error for each ambiguous prefix,
+// stopping at the first error unless CollectAllErrors is true. Like we do in
+// [*FlagSet.checkAttachedValues], we ignore the errors when the user requests
+// the help, which may also be abbreviated.
+func (fs *FlagSet) rewriteAbbreviations(args []string, options []*flagparser.Option) ([]string, error) {
+	if !fs.AllowAbbreviations {
+		return args, nil
+	}
+	var (
+		errs  []error
+		help  bool
+		helps = fs.helpFlags()
+	)
+	output := fs.rewriteArgs(args, options, func(arg string) string {
+		prefix := optionPrefix(arg, options)
+		name, value, found := strings.Cut(arg[len(prefix):], "=")
+		candidates := fs.abbreviationCandidates(prefix, name, options)
+		switch {
+		case len(candidates) == 1 && found:
+			arg = prefix + candidates[0] + "=" + value
+		case len(candidates) == 1:
+			arg = prefix + candidates[0]
+		case len(candidates) > 1 && (len(errs) <= 0 || fs.CollectAllErrors):
+			for idx := range candidates {
+				candidates[idx] = prefix + candidates[idx]
+			}
+			errs = append(errs, fmt.Errorf("ambiguous option: %s (could be %s)", arg, strings.Join(candidates, " or ")))
+		}
+		help = help || slices.Contains(helps, arg)
+		return arg
+	})
+	if help {
+		return output, nil
+	}
+	return output, errors.Join(errs...)
+}
+
+// abbreviationCandidates returns the names of the standalone options with the
+// given prefix whose name starts with the given name, ignoring the case when
+// CaseInsensitive is true, or nil if an option has exactly the given name.
+func (fs *FlagSet) abbreviationCandidates(prefix, name string, options []*flagparser.Option) (candidates []string) {
+	for _, option := range options {
+		if prefix == "" || option.Prefix != prefix || findGroupableOption(prefix, option.Name, options) != nil {
+			continue
+		}
+		if option.Name == name {
+			return nil
+		}
+		if strings.HasPrefix(option.Name, name) ||
+			(fs.CaseInsensitive && strings.HasPrefix(strings.ToLower(option.Name), strings.ToLower(name))) {
+			candidates = append(candidates, option.Name)
+		}
+	}
+	return
+}
+
+// rewriteNumericShorthands rewrites the numeric shorthand arguments to set
+// the flag named by NumericShorthand (e.g., `-5` becomes `--lines=5`).
+//
+// This method returns an error if NumericShorthand does not name a registered flag.
+func (fs *FlagSet) rewriteNumericShorthands(args []string, options []*flagparser.Option) ([]string, error) {
+	if fs.NumericShorthand == "" {
+		return args, nil
+	}
+	sfx, lfx, found := fs.lookupFlag(fs.NumericShorthand)
+	if !found {
+		return nil, fmt.Errorf("vflag: NumericShorthand: no such flag: %s", fs.NumericShorthand)
+	}
+	return fs.rewriteArgs(args, options, func(arg string) string {
+		digits, ok := strings.CutPrefix(arg, "-")
+		if !ok || digits == "" || strings.Trim(digits, "0123456789") != "" {
+			return arg
+		}
+		if findGroupableOption("-", digits[:1], options) != nil {
+			return arg
+		}
+		if lfx != nil {
+			return lfx.Prefix + lfx.Name + "=" + digits
+		}
+		return sfx.Prefix + string(sfx.Name) + digits
+	}), nil
+}
+
+// rewriteValueSeparators rewrites the arguments using the ValueSeparator of
+// the long flags to use `=` instead (e.g., `-encoding:UTF-8` becomes `-encoding=UTF-8`).
+func (fs *FlagSet) rewriteValueSeparators(args []string, options []*flagparser.Option) []string {
+	if !slices.ContainsFunc(fs.LongFlags, func(fx *LongFlag) bool { return fx.ValueSeparator != "" }) {
+		return args
+	}
+	return fs.rewriteArgs(args, options, func(arg string) string {
+		for _, fx := range fs.LongFlags {
+			if fx.ValueSeparator == "" || fx.ValueSeparator == "=" {
+				continue
+			}
+			for _, name := range append([]string{fx.Name}, fx.Aliases...) {
+				if value, found := strings.CutPrefix(arg, fx.Prefix+name+fx.ValueSeparator); found {
+					return fx.Prefix + name + "=" + value
+				}
+			}
+		}
+		return arg
+	})
+}
+
+// rewriteOptionalValues appends the default value to the short flags using
+// [ShortFlagMakeOptionWithOptionalValue] that appear without an attached
+// value (e.g., `-O` becomes `-O1`), such that they do not consume the
+// following argument as their value.
+func (fs *FlagSet) rewriteOptionalValues(args []string, options []*flagparser.Option) []string {
+	return fs.rewriteArgs(args, options, func(arg string) string {
+		_, option := classifyArg(arg, options)
+		if option != nil && option.Type == flagparser.OptionTypeGroupableArgumentRequired && option.DefaultValue != "" {
+			return arg + option.DefaultValue
+		}
+		return arg
+	})
+}
+
+// checkAttachedValues returns an error for each short flag violating its
+// [AttachedValuePolicy] in the arguments, stopping at the first error
+// unless CollectAllErrors is true. Like the parser does for the other
+// errors, we ignore the violations when the user requests the help.
+func (fs *FlagSet) checkAttachedValues(args []string, options []*flagparser.Option) error {
+	policies := make(map[string]AttachedValuePolicy)
+	for _, fx := range fs.ShortFlags {
+		if fx.AttachedValue != AttachedValueAllowed {
+			policies[fx.Prefix+string(fx.Name)] = fx.AttachedValue
+		}
+	}
+	if len(policies) <= 0 {
+		return nil
+	}
+	var (
+		errs  []error
+		help  bool
+		helps = fs.helpFlags()
+	)
+	fs.rewriteArgs(args, options, func(arg string) string {
+		help = help || slices.Contains(helps, arg)
+		prefix := optionPrefix(arg, options)
+		option, attached := groupableValueOption(prefix, arg[len(prefix):], options)
+		if option == nil || (len(errs) > 0 && !fs.CollectAllErrors) {
+			return arg
+		}
+		switch policies[option.Prefix+option.Name] {
+		case AttachedValueRequired:
+			if !attached {
+				errs = append(errs, fmt.Errorf("option requires an attached value: %s", arg))
+			}
+		case AttachedValueRejected:
+			if attached {
+				errs = append(errs, fmt.Errorf("option does not accept an attached value: %s", arg))
+			}
+		}
+		return arg
+	})
+	if help {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// checkDashValues returns an error for each flag taking a separate argument
+// starting with `-` as its value when RejectDashValues is true, unless the flag
+// has AllowDashValue set, stopping at the first error unless CollectAllErrors
+// is true. Like [*FlagSet.checkAttachedValues], we ignore the errors when the
+// user requests the help.
+func (fs *FlagSet) checkDashValues(args []string, options []*flagparser.Option) error {
+	if !fs.RejectDashValues {
+		return nil
+	}
+	allowed := make(map[string]bool)
+	for _, fx := range fs.ShortFlags {
+		allowed[fx.Prefix+string(fx.Name)] = fx.AllowDashValue
+	}
+	for _, fx := range fs.LongFlags {
+		for _, name := range append([]string{fx.Name}, fx.Aliases...) {
+			allowed[fx.Prefix+name] = fx.AllowDashValue
+		}
+	}
+	var (
+		errs  []error
+		help  bool
+		helps = fs.helpFlags()
+	)
+	fs.visitArgs(args, options, func(idx int) {
+		help = help || slices.Contains(helps, args[idx])
+		_, option := classifyArg(args[idx], options)
+		if option == nil || idx+1 >= len(args) || allowed[option.Prefix+option.Name] {
+			return
+		}
+		value := args[idx+1]
+		if !strings.HasPrefix(value, "-") || value == "-" || (len(errs) > 0 && !fs.CollectAllErrors) {
+			return
+		}
+		attached := args[idx] + value
+		if option.Type == flagparser.OptionTypeStandaloneArgumentRequired {
+			attached = args[idx] + "=" + value
+		}
+		errs = append(errs, fmt.Errorf("option value starts with '-': %s %s (use %s if intended)", args[idx], value, attached))
+	})
+	if help {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// checkRequireEquals returns an error for each long flag taking a separate
+// argument as its value when RequireEquals is true, stopping at the first error
+// unless CollectAllErrors is true. Like [*FlagSet.checkAttachedValues], we
+// ignore the errors when the user requests the help.
+func (fs *FlagSet) checkRequireEquals(args []string, options []*flagparser.Option) error {
+	if !fs.RequireEquals {
+		return nil
+	}
+	var (
+		errs  []error
+		help  bool
+		helps = fs.helpFlags()
+	)
+	fs.rewriteArgs(args, options, func(arg string) string {
+		help = help || slices.Contains(helps, arg)
+		_, option := classifyArg(arg, options)
+		if option != nil && option.Type == flagparser.OptionTypeStandaloneArgumentRequired &&
+			(len(errs) <= 0 || fs.CollectAllErrors) {
+			errs = append(errs, fmt.Errorf("option requires a value using '=': %s", arg))
+		}
+		return arg
+	})
+	if help {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// classifyArg returns whether the parser would interpret arg as an option given the
+// options and, if such an option takes the following argument as its value, the option.
+func classifyArg(arg string, options []*flagparser.Option) (isOption bool, valueOption *flagparser.Option) {
+	prefix := optionPrefix(arg, options)
+	if prefix == "" {
+		return false, nil
+	}
+	rest := arg[len(prefix):]
+	for _, option := range options {
+		if option.Prefix == prefix && option.Name == rest && option.Type == flagparser.OptionTypeStandaloneArgumentRequired {
+			return true, option
+		}
+	}
+	if option, attached := groupableValueOption(prefix, rest, options); option != nil && !attached {
+		return true, option
+	}
+	return true, nil
+}
+
+// groupableValueOption returns the groupable option taking a value within the
+// group of options following the prefix, if any, and whether its value is
+// attached to the option (e.g., `vofile.txt` for the `-vofile.txt` argument).
+func groupableValueOption(prefix, group string, options []*flagparser.Option) (*flagparser.Option, bool) {
+	for len(group) > 0 {
+		_, size := utf8.DecodeRuneInString(group)
+		option := findGroupableOption(prefix, group[:size], options)
+		if option == nil {
+			break
+		}
+		group = group[size:]
+		if option.Type == flagparser.OptionTypeGroupableArgumentRequired {
+			return option, group != ""
+		}
+	}
+	return nil, false
+}
+
+// optionPrefix returns the longest prefix of the options with which arg starts
+// and which is followed by at least one character, or an empty string.
+func optionPrefix(arg string, options []*flagparser.Option) (prefix string) {
+	for _, option := range options {
+		if len(option.Prefix) > len(prefix) && len(arg) > len(option.Prefix) && strings.HasPrefix(arg, option.Prefix) {
+			prefix = option.Prefix
+		}
+	}
+	return
+}
+
+// findGroupableOption returns the groupable option with the given prefix and name, if any.
+func findGroupableOption(prefix, name string, options []*flagparser.Option) *flagparser.Option {
+	for _, option := range options {
+		switch option.Type {
+		case flagparser.OptionTypeGroupableArgumentNone, flagparser.OptionTypeGroupableArgumentRequired:
+			if option.Prefix == prefix && option.Name == name {
+				return option
+			}
+		}
+	}
+	return nil
+}
+
+// internalNames assigns internal names to the groupable options the parser
+// cannot represent, i.e., the options with a non-ASCII name, since groupable
+// names must be a single byte, and the negated short flags, since each name
+// must appear once. We build the options using their original names, such that
+// rewriting the arguments works as usual, and we rename the options and rewrite
+// the arguments right before parsing.
+//
+// The renamed options become standalone options using the [internalPrefixSuffix]
+// and an internal name containing their index between [internalNameDelimiter]s,
+// which users do not type as flags, so there is no limit to the number of renamed
+// options and we can restore the original names in the errors. When rewriting the
+// arguments, we split the groups containing renamed options into one argument
+// for each renamed option and one for each run of the other options (e.g., `-véx`
+// becomes `-v`, the `-é` internal argument, and `-x`).
+//
+// Likewise, since a prefix cannot be shared by standalone and groupable options,
+// we rename the prefix of the standalone options sharing their prefix with the
+// groupable options (e.g., `-name` and `-n`) using the [internalPrefixSuffix].
+type internalNames struct {
+	// options contains the options to rename.
+	options []*flagparser.Option
+
+	// internal contains the internal name of each option to rename.
+	internal []string
+
+	// original contains the original name of each option to rename.
+	original []string
+
+	// prefixes contains the original prefixes of the renamed options.
+	prefixes []string
+}
+
+// internalPrefixSuffix is the suffix we add to the prefix of the renamed options
+// and of the standalone options sharing their prefix with the groupable options.
+// The longer prefix allows the parser to tell apart the standalone options.
+const internalPrefixSuffix = "\x00"
+
+// internalNameDelimiter delimits the index within the internal names, such
+// that restoring the name of an option does not alter the other names.
+const internalNameDelimiter = "\x01"
+
+// add registers an option to rename and returns its internal name.
+func (in *internalNames) add(option *flagparser.Option) string {
+	name := internalNameDelimiter + strconv.Itoa(len(in.options)) + internalNameDelimiter
+	in.options = append(in.options, option)
+	in.internal = append(in.internal, name)
+	in.original = append(in.original, option.Name)
+	return name
+}
+
+// apply renames the options and returns a copy of the arguments where the
+// renamed options use their internal prefix and name and the standalone
+// options sharing their prefix with the groupable options use the internal prefix.
+func (in *internalNames) apply(fs *FlagSet, args []string, options []*flagparser.Option) []string {
+	var (
+		groupable = make(map[string]bool)
+		prefixes  []*flagparser.Option
+	)
+	for _, option := range options {
+		groupable[option.Prefix] = groupable[option.Prefix] || findGroupableOption(option.Prefix, option.Name, options) != nil
+		prefixes = append(prefixes, &flagparser.Option{Prefix: option.Prefix})
+	}
+	for _, option := range options {
+		if !groupable[option.Prefix] || findGroupableOption(option.Prefix, option.Name, options) != nil ||
+			option.Type == flagparser.OptionTypeEarlyArgumentNone {
+			continue
+		}
+		if !slices.Contains(in.prefixes, option.Prefix) {
+			in.prefixes = append(in.prefixes, option.Prefix)
+		}
+		option.Prefix += internalPrefixSuffix
+	}
+	if len(in.options) <= 0 && len(in.prefixes) <= 0 {
+		return args
+	}
+	renames := make(map[string]*flagparser.Option)
+	for idx, option := range in.options {
+		renames[option.Prefix+option.Name] = option
+		if !slices.Contains(in.prefixes, option.Prefix) {
+			in.prefixes = append(in.prefixes, option.Prefix)
+		}
+		option.Type = standaloneOptionType(option)
+		option.Prefix += internalPrefixSuffix
+		option.Name = in.internal[idx]
+	}
+	return fs.expandArgs(args, options, func(arg string) []string {
+		prefix := optionPrefix(arg, prefixes)
+		if prefix == "" || !groupable[prefix] {
+			return []string{arg}
+		}
+		if name, _, _ := strings.Cut(arg[len(prefix):], "="); slices.ContainsFunc(options, func(option *flagparser.Option) bool {
+			return option.Prefix == prefix+internalPrefixSuffix && option.Name == name
+		}) {
+			return []string{prefix + internalPrefixSuffix + arg[len(prefix):]}
+		}
+		var (
+			group  = arg[len(prefix):]
+			output []string
+			run    string
+		)
+		for len(group) > 0 {
+			_, size := utf8.DecodeRuneInString(group)
+			if option, found := renames[prefix+group[:size]]; found {
+				if run != "" {
+					output, run = append(output, prefix+run), ""
+				}
+				internal := option.Prefix + option.Name
+				if group = group[size:]; option.Type != flagparser.OptionTypeStandaloneArgumentNone && group != "" {
+					internal, group = internal+"="+group, ""
+				}
+				output = append(output, internal)
+				if option.Type != flagparser.OptionTypeStandaloneArgumentNone {
+					break
+				}
+				continue
+			}
+			option := findGroupableOption(prefix, group[:size], options)
+			if option == nil {
+				break
+			}
+			run, group = run+group[:size], group[size:]
+			if option.Type == flagparser.OptionTypeGroupableArgumentRequired {
+				run, group = run+group, ""
+			}
+		}
+		if run != "" || group != "" {
+			// when the prefix has no options left, use the internal prefix such
+			// that the parser reports the unknown option rather than a positional
+			if run == "" && !slices.ContainsFunc(options, func(option *flagparser.Option) bool { return option.Prefix == prefix }) {
+				prefix += internalPrefixSuffix
+			}
+			output = append(output, prefix+run+group)
+		}
+		return output
+	})
+}
+
+// restore returns a copy of value where we replace the internal names and
+// prefixes with the original names and prefixes of the corresponding options.
+func (in *internalNames) restore(value string) string {
+	for _, prefix := range in.prefixes {
+		value = strings.ReplaceAll(value, prefix+internalPrefixSuffix, prefix)
+	}
+	for idx, internal := range in.internal {
+		value = strings.ReplaceAll(value, internal, in.original[idx])
+	}
+	return value
+}
+
+// restoreError returns err or, if its message contains internal names, an
+// error wrapping err whose message contains the original names instead.
+func (in *internalNames) restoreError(err error) error {
+	if message := in.restore(err.Error()); message != err.Error() {
+		return &restoredError{err: err, message: message}
+	}
+	return err
+}
+
+// restoredError is the error returned by [*internalNames.restoreError].
+type restoredError struct {
+	err     error
+	message string
+}
+
+// Error implements error.
+func (err *restoredError) Error() string {
+	return err.message
+}
+
+// Unwrap returns the underlying error.
+func (err *restoredError) Unwrap() error {
+	return err.err
+}
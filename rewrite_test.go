@@ -0,0 +1,656 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bassosimone/flagparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyArg(t *testing.T) {
+	options := []*flagparser.Option{
+		{Prefix: "-", Name: "v", Type: flagparser.OptionTypeGroupableArgumentNone},
+		{Prefix: "-", Name: "o", Type: flagparser.OptionTypeGroupableArgumentRequired},
+		{Prefix: "--", Name: "output", Type: flagparser.OptionTypeStandaloneArgumentRequired},
+		{Prefix: "--", Name: "color", Type: flagparser.OptionTypeStandaloneArgumentOptional},
+	}
+
+	cases := []struct {
+		arg        string
+		isOption   bool
+		takesValue bool
+	}{
+		{"-ov", true, false},
+		{"file.txt", false, false},
+		{"-", false, false},
+		{"-v", true, false},
+		{"-vo", true, true},
+		{"-vofile.txt", true, false},
+		{"--output", true, true},
+		{"--output=file.txt", true, false},
+		{"--color", true, false},
+		{"--unknown", true, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.arg, func(t *testing.T) {
+			isOption, valueOption := classifyArg(tc.arg, options)
+			assert.Equal(t, tc.isOption, isOption)
+			assert.Equal(t, tc.takesValue, valueOption != nil)
+		})
+	}
+}
+
+func TestFlagSetPrefixAliases(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *bool, *bool, *string) {
+		var (
+			ipv4, verbose bool
+			output        string
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.BoolVar(&ipv4, '4', "", "Use IPv4.")
+		fs.BoolVar(&verbose, 'v', "", "Verbose output.")
+		fs.StringVar(&output, 'o', "", "Write output to FILE.")
+		fs.AddShortFlagPrefixAliases('4', "/")
+		fs.AddShortFlagPrefixAliases('o', "/")
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &ipv4, &verbose, &output
+	}
+
+	t.Run("both prefixes work", func(t *testing.T) {
+		for _, args := range [][]string{{"-4"}, {"/4"}, {"/4v"}} {
+			fs, ipv4, _, _ := newFlagSet()
+			require.NoError(t, fs.Parse(args))
+			assert.True(t, *ipv4)
+		}
+	})
+
+	t.Run("option values are not rewritten", func(t *testing.T) {
+		fs, ipv4, _, output := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"/o", "/4", "x"}))
+		assert.False(t, *ipv4)
+		assert.Equal(t, "/4", *output)
+		assert.Equal(t, []string{"x"}, fs.Args())
+	})
+
+	t.Run("positional arguments after the separator are not rewritten", func(t *testing.T) {
+		fs, ipv4, _, _ := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"--", "/4"}))
+		assert.False(t, *ipv4)
+		assert.Equal(t, []string{"/4"}, fs.Args())
+	})
+
+	assert.Panics(t, func() {
+		fs, _, _, _ := newFlagSet()
+		fs.AddShortFlagPrefixAliases('x', "/")
+	})
+}
+
+func TestFlagSetValueSeparator(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *string) {
+		var encoding, level = "", "none"
+		fs := NewFlagSet("javac", ContinueOnError)
+		fs.ValueSeparator = ":"
+		encodingFlag := NewLongFlagString(NewValueString(&encoding), "encoding", "Use the given `CHARSET`.")
+		encodingFlag.Prefix = "-"
+		fs.AddLongFlag(encodingFlag)
+		levelFlag := NewLongFlagString(NewValueString(&level), "g", "Generate debugging info.")
+		levelFlag.Prefix = "-"
+		levelFlag.MakeOption = LongFlagMakeOptionWithOptionalValue
+		levelFlag.ArgumentName = "[=LEVEL]"
+		fs.AddLongFlag(levelFlag)
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &encoding, &level
+	}
+
+	t.Run("custom separator", func(t *testing.T) {
+		fs, encoding, level := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"-encoding:UTF-8", "-g:source", "Main.java"}))
+		assert.Equal(t, "UTF-8", *encoding)
+		assert.Equal(t, "source", *level)
+		assert.Equal(t, []string{"Main.java"}, fs.Args())
+	})
+
+	t.Run("equal sign and separate argument", func(t *testing.T) {
+		fs, encoding, _ := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"-encoding", "-encoding:x"}))
+		assert.Equal(t, "-encoding:x", *encoding)
+
+		fs, encoding, _ = newFlagSet()
+		require.NoError(t, fs.Parse([]string{"-encoding=UTF-8"}))
+		assert.Equal(t, "UTF-8", *encoding)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		assert.Equal(t, "-encoding CHARSET", fs.LongFlags[0].Usage())
+		assert.Equal(t, "-g[:LEVEL]", fs.LongFlags[1].Usage())
+	})
+}
+
+func TestFlagSetAttachedStringVar(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *bool) {
+		var (
+			level   = "0"
+			verbose bool
+		)
+		fs := NewFlagSet("cc", ContinueOnError)
+		fs.AttachedStringVar(&level, 'O', "1", "Optimize at the given `LEVEL`.")
+		fs.BoolVar(&verbose, 'v', "", "Verbose output.")
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &level, &verbose
+	}
+
+	cases := []struct {
+		args    []string
+		level   string
+		verbose bool
+		rest    []string
+	}{
+		{[]string{"main.c"}, "0", false, []string{"main.c"}},
+		{[]string{"-O", "main.c"}, "1", false, []string{"main.c"}},
+		{[]string{"-O2", "main.c"}, "2", false, []string{"main.c"}},
+		{[]string{"-vO"}, "1", true, nil},
+		{[]string{"-vOs"}, "s", true, nil},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, level, verbose := newFlagSet()
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.level, *level)
+			assert.Equal(t, tc.verbose, *verbose)
+			assert.Equal(t, len(tc.rest), len(fs.Args()))
+		})
+	}
+
+	fs, _, _ := newFlagSet()
+	assert.Equal(t, "-O[LEVEL]", fs.ShortFlags[0].Usage())
+}
+
+func TestFlagSetAttachedValuePolicy(t *testing.T) {
+	newFlagSet := func(policy AttachedValuePolicy) (*FlagSet, *string) {
+		var output string
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.AutoHelp('h', "help", "Show help.")
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.ShortFlags[1].AttachedValue = policy
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &output
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		for _, args := range [][]string{{"-ofile.txt"}, {"-o", "file.txt"}} {
+			fs, output := newFlagSet(AttachedValueAllowed)
+			require.NoError(t, fs.Parse(args))
+			assert.Equal(t, "file.txt", *output)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		fs, output := newFlagSet(AttachedValueRequired)
+		require.NoError(t, fs.Parse([]string{"-ofile.txt"}))
+		assert.Equal(t, "file.txt", *output)
+
+		fs, _ = newFlagSet(AttachedValueRequired)
+		err := fs.Parse([]string{"-o", "file.txt"})
+		require.EqualError(t, err, "option requires an attached value: -o")
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		fs, output := newFlagSet(AttachedValueRejected)
+		require.NoError(t, fs.Parse([]string{"-o", "-ofile.txt", "--output=x.txt"}))
+		assert.Equal(t, "x.txt", *output)
+
+		fs, _ = newFlagSet(AttachedValueRejected)
+		err := fs.Parse([]string{"-ofile.txt"})
+		require.EqualError(t, err, "option does not accept an attached value: -ofile.txt")
+	})
+
+	t.Run("help wins", func(t *testing.T) {
+		fs, _ := newFlagSet(AttachedValueRejected)
+		require.ErrorIs(t, fs.Parse([]string{"-ofile.txt", "-h"}), ErrHelp)
+	})
+}
+
+func TestFlagSetRequireEquals(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *bool) {
+		var (
+			output string
+			force  bool
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.AutoHelp('h', "help", "Show help.")
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.BoolVar(&force, 'f', "force", "Overwrite existing files.")
+		fs.RequireEquals = true
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &output, &force
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		fs, output, force := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"--output=file.txt", "--force", "-o", "x.txt"}))
+		assert.Equal(t, "x.txt", *output)
+		assert.True(t, *force)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		err := fs.Parse([]string{"--output", "--force"})
+		require.EqualError(t, err, "option requires a value using '=': --output")
+	})
+
+	t.Run("help wins", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		require.ErrorIs(t, fs.Parse([]string{"--output", "file.txt", "--help"}), ErrHelp)
+	})
+}
+
+func TestFlagSetNumericShorthand(t *testing.T) {
+	newFlagSet := func(longName string) (*FlagSet, *int) {
+		lines := 10
+		fs := NewFlagSet("head", ContinueOnError)
+		fs.NumericShorthandVar(&lines, 'n', longName, "Print the first `NUM` lines.")
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &lines
+	}
+
+	cases := []struct {
+		longName string
+		args     []string
+		lines    int
+	}{
+		{"lines", []string{"file.txt"}, 10},
+		{"lines", []string{"-5", "file.txt"}, 5},
+		{"lines", []string{"-n", "7", "-25"}, 25},
+		{"lines", []string{"--lines", "3"}, 3},
+		{"", []string{"-5"}, 5},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, lines := newFlagSet(tc.longName)
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.lines, *lines)
+		})
+	}
+
+	t.Run("positional arguments are not rewritten", func(t *testing.T) {
+		fs, lines := newFlagSet("lines")
+		require.NoError(t, fs.Parse([]string{"--", "-5"}))
+		assert.Equal(t, 10, *lines)
+		assert.Equal(t, []string{"-5"}, fs.Args())
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		fs, _ := newFlagSet("lines")
+		fs.NumericShorthand = "count"
+		assert.EqualError(t, fs.Parse([]string{"-5"}), "vflag: NumericShorthand: no such flag: count")
+	})
+}
+
+func TestFlagSetToggleBoolVar(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *bool, *bool, *bool) {
+		var (
+			exec    bool
+			write   = true
+			verbose bool
+		)
+		fs := NewFlagSet("chmod", ContinueOnError)
+		fs.ToggleBoolVar(&exec, 'x', "Toggle the execute permission.")
+		fs.ToggleBoolVar(&write, 'w', "Toggle the write permission.")
+		fs.BoolVar(&verbose, 'v', "verbose", "Run verbosely.")
+		return fs, &exec, &write, &verbose
+	}
+
+	cases := []struct {
+		args    []string
+		exec    bool
+		write   bool
+		verbose bool
+	}{
+		{[]string{}, false, true, false},
+		{[]string{"+x"}, true, true, false},
+		{[]string{"-w"}, false, false, false},
+		{[]string{"+xw"}, true, true, false},
+		{[]string{"+x", "-x"}, false, true, false},
+		{[]string{"-wv", "+x"}, true, false, true},
+		{[]string{"-vw"}, false, false, true},
+		{[]string{"--verbose", "-w", "+w"}, false, true, true},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, exec, write, verbose := newFlagSet()
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.exec, *exec)
+			assert.Equal(t, tc.write, *write)
+			assert.Equal(t, tc.verbose, *verbose)
+		})
+	}
+
+	t.Run("repeated negated flag", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		fs.ShortFlags[0].MaxOccurrences = 1
+		assert.EqualError(t, fs.Parse([]string{"-x", "-x"}), "option -x specified multiple times")
+		fs, _, _, _ = newFlagSet()
+		fs.ShortFlags[0].MaxOccurrences = 1
+		assert.EqualError(t, fs.Parse([]string{"-vx", "-wx"}), "option -x specified multiple times")
+	})
+
+	t.Run("unknown flag in a group", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		assert.EqualError(t, fs.Parse([]string{"-wz"}), "unknown option: -z")
+		fs, _, _, _ = newFlagSet()
+		assert.EqualError(t, fs.Parse([]string{"+xv"}), "unknown option: +v")
+	})
+
+	t.Run("help", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		var sb strings.Builder
+		fs.SetOutput(&sb)
+		fs.PrintDefaults()
+		assert.Contains(t, sb.String(), "  +x, -x\n")
+	})
+}
+
+func TestFlagSetUnicodeShortNames(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *bool, *string, *bool) {
+		var (
+			extended bool
+			name     string
+			verbose  bool
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.BoolVar(&extended, 'é', "", "Use the extended mode.")
+		fs.StringVar(&name, 'ñ', "nombre", "Set the `NAME`.")
+		fs.BoolVar(&verbose, 'v', "", "Run verbosely.")
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &extended, &name, &verbose
+	}
+
+	cases := []struct {
+		args     []string
+		extended bool
+		name     string
+		verbose  bool
+	}{
+		{[]string{"-é"}, true, "", false},
+		{[]string{"-év"}, true, "", true},
+		{[]string{"-vé"}, true, "", true},
+		{[]string{"-ñ", "señor"}, false, "señor", false},
+		{[]string{"-véñseñor"}, true, "señor", true},
+		{[]string{"--nombre=ñ"}, false, "ñ", false},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, extended, name, verbose := newFlagSet()
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.extended, *extended)
+			assert.Equal(t, tc.name, *name)
+			assert.Equal(t, tc.verbose, *verbose)
+		})
+	}
+
+	t.Run("positional arguments are not rewritten", func(t *testing.T) {
+		fs, extended, _, _ := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"--", "-é"}))
+		assert.False(t, *extended)
+		assert.Equal(t, []string{"-é"}, fs.Args())
+	})
+
+	t.Run("errors use the original names", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		err := fs.Parse([]string{"-é", "-ñ"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "-ñ")
+		assert.NotContains(t, err.Error(), "\x00")
+		assert.NotContains(t, err.Error(), "\x01")
+	})
+
+	t.Run("many flags", func(t *testing.T) {
+		fs, _, _, verbose := newFlagSet()
+		greek := make([]bool, 40)
+		for idx := range greek {
+			fs.BoolVar(&greek[idx], 'α'+rune(idx), "", "Enable the given letter.")
+		}
+		require.NoError(t, fs.Parse([]string{"-αvϘ", "-β"}))
+		assert.True(t, *verbose)
+		assert.True(t, greek[0])
+		assert.True(t, greek[1])
+		assert.True(t, greek[39])
+		assert.Equal(t, 4, fs.NFlag())
+	})
+
+	t.Run("many flags with an unknown flag", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		greek := make([]bool, 40)
+		for idx := range greek {
+			fs.BoolVar(&greek[idx], 'α'+rune(idx), "", "Enable the given letter.")
+		}
+		assert.EqualError(t, fs.Parse([]string{"-αz"}), "unknown option: -z")
+	})
+
+	t.Run("help", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		var sb strings.Builder
+		fs.SetOutput(&sb)
+		fs.PrintDefaults()
+		assert.Contains(t, sb.String(), "  -é\n")
+		assert.Contains(t, sb.String(), "  -ñ NAME, --nombre NAME\n")
+	})
+}
+
+func TestFlagSetAddLongFlagFind(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *int, *bool, *bool) {
+		var (
+			name    string
+			mtime   int
+			newer   bool
+			verbose bool
+		)
+		fs := NewFlagSet("find", ContinueOnError)
+		fs.AddLongFlagFind(NewLongFlagString(NewValueString(&name), "name", "Match the `PATTERN`."))
+		fs.AddLongFlagFind(NewLongFlagInt(NewValueInt(&mtime), "mtime", "Match the modification time."))
+		fs.BoolVar(&newer, 'n', "", "Only newer files.")
+		fs.BoolVar(&verbose, 'v', "", "Run verbosely.")
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &name, &mtime, &newer, &verbose
+	}
+
+	cases := []struct {
+		args    []string
+		name    string
+		mtime   int
+		newer   bool
+		verbose bool
+	}{
+		{[]string{"-name", "*.go"}, "*.go", 0, false, false},
+		{[]string{"-name=*.go", "-mtime", "7"}, "*.go", 7, false, false},
+		{[]string{"-nv", "-name", "*.go"}, "*.go", 0, true, true},
+		{[]string{"-n", "-v", "."}, "", 0, true, true},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, name, mtime, newer, verbose := newFlagSet()
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.name, *name)
+			assert.Equal(t, tc.mtime, *mtime)
+			assert.Equal(t, tc.newer, *newer)
+			assert.Equal(t, tc.verbose, *verbose)
+		})
+	}
+
+	t.Run("errors use the original prefix", func(t *testing.T) {
+		fs, _, _, _, _ := newFlagSet()
+		err := fs.Parse([]string{"-mtime"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "-mtime")
+		assert.NotContains(t, err.Error(), "\x00")
+	})
+
+	t.Run("help", func(t *testing.T) {
+		fs, _, _, _, _ := newFlagSet()
+		var sb strings.Builder
+		fs.SetOutput(&sb)
+		fs.PrintDefaults()
+		assert.Contains(t, sb.String(), "  -name PATTERN\n")
+	})
+}
+
+func TestFlagSetCaseInsensitive(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *bool, *bool) {
+		var (
+			output  string
+			color   = true
+			verbose bool
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.NegatableBoolVar(&color, 0, "color", "Colorize the output.")
+		fs.BoolVar(&verbose, 'v', "", "Run verbosely.")
+		fs.CaseInsensitive = true
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &output, &color, &verbose
+	}
+
+	cases := []struct {
+		args    []string
+		output  string
+		color   bool
+		verbose bool
+	}{
+		{[]string{"--output", "a.txt"}, "a.txt", true, false},
+		{[]string{"--Output", "a.txt"}, "a.txt", true, false},
+		{[]string{"--OUTPUT=a.txt"}, "a.txt", true, false},
+		{[]string{"--No-Color", "-v"}, "", false, true},
+		{[]string{"--output", "--Verbose.txt"}, "--Verbose.txt", true, false},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, output, color, verbose := newFlagSet()
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.output, *output)
+			assert.Equal(t, tc.color, *color)
+			assert.Equal(t, tc.verbose, *verbose)
+		})
+	}
+
+	t.Run("short flags are case sensitive", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		assert.Error(t, fs.Parse([]string{"-V"}))
+	})
+
+	t.Run("errors use the registered names", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		err := fs.Parse([]string{"--OUTPUT"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--output")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		fs.CaseInsensitive = false
+		assert.Error(t, fs.Parse([]string{"--Output", "a.txt"}))
+	})
+}
+
+func TestFlagSetAllowAbbreviations(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *bool, *string) {
+		var (
+			verbose bool
+			output  string
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.AutoHelp('h', "help", "Show help.")
+		fs.BoolVar(&verbose, 'v', "verbose", "Run verbosely.")
+		fs.BoolFunc(0, "version", "Show the version.", func(string) error { return nil })
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.AllowAbbreviations = true
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &verbose, &output
+	}
+
+	t.Run("unambiguous", func(t *testing.T) {
+		fs, verbose, output := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"--verb", "--out", "a.txt", "--o=b.txt"}))
+		assert.True(t, *verbose)
+		assert.Equal(t, "b.txt", *output)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		err := fs.Parse([]string{"--ver"})
+		require.EqualError(t, err, "ambiguous option: --ver (could be --verbose or --version)")
+	})
+
+	t.Run("abbreviated help", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		require.ErrorIs(t, fs.Parse([]string{"--ver", "--he"}), ErrHelp)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		fs, _, _ := newFlagSet()
+		fs.AllowAbbreviations = false
+		assert.Error(t, fs.Parse([]string{"--verb"}))
+	})
+}
+
+func TestFlagSetRejectDashValues(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *string, *string, *bool) {
+		var (
+			message string
+			output  string
+			force   bool
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.AutoHelp('h', "help", "Show help.")
+		fs.StringVar(&message, 'm', "message", "Use the given `MSG`.")
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.BoolVar(&force, 'f', "force", "Overwrite existing files.")
+		fs.RejectDashValues = true
+		fs.AllowDashValue("message")
+		fs.SetMinMaxPositionalArgs(0, 1)
+		return fs, &message, &output, &force
+	}
+
+	cases := []struct {
+		args    []string
+		message string
+		output  string
+	}{
+		{[]string{"--message", "-n was passed"}, "-n was passed", ""},
+		{[]string{"-m", "-5"}, "-5", ""},
+		{[]string{"--output=-x"}, "", "-x"},
+		{[]string{"-o-x"}, "", "-x"},
+		{[]string{"-o", "-"}, "", "-"},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			fs, message, output, _ := newFlagSet()
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.message, *message)
+			assert.Equal(t, tc.output, *output)
+		})
+	}
+
+	t.Run("rejected long flag", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		err := fs.Parse([]string{"--output", "--force"})
+		require.EqualError(t, err, "option value starts with '-': --output --force (use --output=--force if intended)")
+	})
+
+	t.Run("rejected short flag", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		err := fs.Parse([]string{"-fo", "-x"})
+		require.EqualError(t, err, "option value starts with '-': -fo -x (use -fo-x if intended)")
+	})
+
+	t.Run("help wins", func(t *testing.T) {
+		fs, _, _, _ := newFlagSet()
+		require.ErrorIs(t, fs.Parse([]string{"--output", "--force", "--help"}), ErrHelp)
+	})
+}
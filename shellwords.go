@@ -0,0 +1,79 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"strings"
+)
+
+// splitShellWords splits the given command line into words using the POSIX
+// shell quoting rules, without performing any expansion. Unquoted blanks
+// separate words, single quotes preserve the literal value of all the characters
+// they enclose, double quotes preserve the literal value of all the characters
+// they enclose except for the backslash escaping `$`, "`", `"`, `\`, and newline,
+// and an unquoted backslash preserves the literal value of the next character.
+// A backslash followed by a newline continues the line, so we remove both.
+func splitShellWords(cmdline string) ([]string, error) {
+	var (
+		current strings.Builder
+		inWord  bool
+		words   = []string{}
+	)
+	for idx := 0; idx < len(cmdline); idx++ {
+		ch := cmdline[idx]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+
+		case ch == '\\':
+			if idx+1 >= len(cmdline) {
+				return nil, errors.New("trailing backslash in command line")
+			}
+			idx++
+			if cmdline[idx] != '\n' {
+				current.WriteByte(cmdline[idx])
+				inWord = true
+			}
+
+		case ch == '\'':
+			end := strings.IndexByte(cmdline[idx+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("unterminated single quote in command line")
+			}
+			current.WriteString(cmdline[idx+1 : idx+1+end])
+			idx += end + 1
+			inWord = true
+
+		case ch == '"':
+			idx++
+			for ; idx < len(cmdline) && cmdline[idx] != '"'; idx++ {
+				if cmdline[idx] == '\\' && idx+1 < len(cmdline) && strings.IndexByte("$`\"\\\n", cmdline[idx+1]) >= 0 {
+					idx++
+					if cmdline[idx] == '\n' {
+						continue
+					}
+				}
+				current.WriteByte(cmdline[idx])
+			}
+			if idx >= len(cmdline) {
+				return nil, errors.New("unterminated double quote in command line")
+			}
+			inWord = true
+
+		default:
+			current.WriteByte(ch)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
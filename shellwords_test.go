@@ -0,0 +1,58 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitShellWords(t *testing.T) {
+	cases := []struct {
+		cmdline string
+		words   []string
+	}{
+		{"", []string{}},
+		{"  \t\n ", []string{}},
+		{"-v -o out.txt", []string{"-v", "-o", "out.txt"}},
+		{`-o "my file.txt"`, []string{"-o", "my file.txt"}},
+		{`'a "b" $c'`, []string{`a "b" $c`}},
+		{`"a \"b\" \$c \x"`, []string{`a "b" $c \x`}},
+		{`my\ file.txt \'`, []string{"my file.txt", "'"}},
+		{`--name=""`, []string{"--name="}},
+		{`"" ''`, []string{"", ""}},
+		{"a\\\nb", []string{"ab"}},
+		{`--message='it'"'"'s'`, []string{"--message=it's"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.cmdline, func(t *testing.T) {
+			words, err := splitShellWords(tc.cmdline)
+			require.NoError(t, err)
+			assert.Equal(t, tc.words, words)
+		})
+	}
+
+	for _, cmdline := range []string{`'abc`, `"abc`, `"abc\"`, `abc\`} {
+		t.Run(cmdline, func(t *testing.T) {
+			_, err := splitShellWords(cmdline)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFlagSetParseString(t *testing.T) {
+	var output string
+	fset := NewFlagSet("curl", ContinueOnError)
+	fset.StringVar(&output, 'o', "output", "Write output to FILE.")
+	fset.SetMinMaxPositionalArgs(1, 1)
+
+	require.NoError(t, fset.ParseString(`-o "my file.txt" https://example.com`))
+	assert.Equal(t, "my file.txt", output)
+	assert.Equal(t, []string{"https://example.com"}, fset.Args())
+
+	assert.EqualError(t, fset.ParseString(`-o 'my file.txt`), "unterminated single quote in command line")
+}
@@ -25,27 +25,99 @@ import (
 // "Write to `FILE`.") overrides the default ArgumentName in help output.
 //
 // The placeholder @DEFAULT_VALUE@ in Description entries is replaced with the
-// current default value (via Value.String()) when printing help.
+// current default value (via Value.String(), or DefaultText) when printing help.
 //
 // Construct using [NewShortFlagBool], [NewShortFlagString], etc.
 type ShortFlag struct {
 	// Description contains the flag description paragraphs to use in the help.
+	//
+	// Like the [*DefaultUsagePrinter] Description, a paragraph starting with
+	// 4 spaces or with a "```" fence is a verbatim block that we do not wrap.
 	Description []string
 
+	// AllowDashValue allows the flag to take a separate argument starting with
+	// `-` as its value (e.g., `-m -5`) when the [*FlagSet] RejectDashValues
+	// is true. See [*FlagSet.AllowDashValue] for more information.
+	AllowDashValue bool
+
 	// ArgumentName is the name of the argument to use in the help.
 	ArgumentName string
 
+	// AttachedValue is the [AttachedValuePolicy] controlling whether the value
+	// of a flag taking a value may be, or must be, attached to the flag.
+	//
+	// The zero value is [AttachedValueAllowed].
+	AttachedValue AttachedValuePolicy
+
+	// Group is the name of the group under which the help lists the flag.
+	//
+	// See [*FlagSet.Group] for more information.
+	Group string
+
+	// HideDefault prevents showing the default value in the help, which
+	// is useful when the default is meaningless, huge, or sensitive.
+	HideDefault bool
+
+	// DefaultText, when not empty, replaces the default value shown in the help
+	// (e.g., `autodetected` or `$HOME/.config`), which is useful when the
+	// literal Value.String() output would be confusing or misleading.
+	//
+	// See [*FlagSet.SetDefaultText] for more information.
+	DefaultText string
+
+	// DefaultValue is the default value to use when the flag is present but no
+	// value is attached (e.g., `-O` rather than `-O2`). This is only used by
+	// [ShortFlagMakeOptionWithOptionalValue] and it must not be empty.
+	DefaultValue string
+
 	// MakeOption constructs the [*flagparser.Option] to use.
 	MakeOption func(fx *ShortFlag) *flagparser.Option
 
-	// Name is the flag short name.
-	Name byte
+	// MaxOccurrences is the maximum number of times the flag may appear on
+	// the command line, counting its long form (see [LongFlag].ShortName). When
+	// it is zero, which is the default, the flag may appear any number of times.
+	//
+	// Set this field to 1 for scalar flags that must not be repeated, such
+	// that repeating them is an error rather than silently using the last value.
+	MaxOccurrences int
+
+	// Name is the flag short name, which may be a non-ASCII character
+	// (e.g., `-é`) that is still groupable with the other short flags.
+	Name rune
+
+	// NegatedPrefix, when not empty, causes [*FlagSet.Parse] to also recognize
+	// the flag using this prefix (e.g., `-x` for `+x`), which sets the Value
+	// to false. This is only meaningful for flags bound to a [ValueBool].
+	//
+	// See [*FlagSet.ToggleBoolVar] for more information.
+	NegatedPrefix string
+
+	// OnDuplicate is the [DuplicatePolicy] to use when the flag, or its
+	// long form, appears multiple times on the command line.
+	//
+	// The zero value is [DuplicateLastWins].
+	OnDuplicate DuplicatePolicy
 
 	// Prefix is the flag short prefix.
 	Prefix string
 
+	// PrefixAliases contains additional prefixes accepted on the command line
+	// (e.g., `/` to accept `/4` as well as `-4`), which is useful for tools
+	// honoring both the Unix and the Windows conventions. When grouping flags
+	// using a prefix alias (e.g., `/4v`), the first flag determines the prefix.
+	//
+	// The help only lists the flag using its Prefix.
+	PrefixAliases []string
+
+	// Required indicates that the flag, or its long form, must be set.
+	// See [*FlagSet.MarkRequired] for more information.
+	Required bool
+
 	// Value is the flag [Value].
 	Value Value
+
+	// occurrences is how many times [*FlagSet.Parse] found the flag on the command line.
+	occurrences int
 }
 
 // argumentNameFromDocsOrDefault returns the `<name>` inside the first string in the
@@ -62,6 +134,8 @@ func argumentNameFromDocsOrDefault(description []string, defaultValue string) (o
 				output = " " + output
 			case strings.HasPrefix(defaultValue, "[=") && strings.HasSuffix(defaultValue, "]"):
 				output = "[=" + output + "]"
+			case strings.HasPrefix(defaultValue, "[") && strings.HasSuffix(defaultValue, "]"):
+				output = "[" + output + "]"
 			}
 		}
 	}
@@ -76,6 +150,13 @@ func (fx *ShortFlag) Usage() string {
 	return fmt.Sprintf("%s%s%s", fx.Prefix, string(fx.Name), argumentName)
 }
 
+// WasSet returns whether [*FlagSet.Parse] found the [*ShortFlag] on the command line.
+//
+// Use [*FlagSet.Changed] to also consider the long form of the flag.
+func (fx *ShortFlag) WasSet() bool {
+	return fx.occurrences > 0
+}
+
 // ShortFlagMakeOptionAutoHelp returns the [*flagparser.Option] to use for auto help.
 //
 // This method panics if the name or prefix are empty.
@@ -92,7 +173,7 @@ func ShortFlagMakeOptionAutoHelp(fx *ShortFlag) *flagparser.Option {
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
-func NewShortFlagAutoHelp(value ValueAutoHelp, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagAutoHelp(value ValueAutoHelp, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: "",
@@ -117,11 +198,45 @@ func ShortFlagMakeOptionBool(fx *ShortFlag) *flagparser.Option {
 	}
 }
 
+// NewShortFlagBigFloat constructs a new [*ShortFlag] bound to a [ValueBigFloat].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` BIGFLOAT` by default.
+func NewShortFlagBigFloat(value ValueBigFloat, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " BIGFLOAT",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagBigInt constructs a new [*ShortFlag] bound to a [ValueBigInt].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` BIGINT` by default.
+func NewShortFlagBigInt(value ValueBigInt, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " BIGINT",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
 // NewShortFlagBool constructs a new [*ShortFlag] bound to a [ValueBool].
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
-func NewShortFlagBool(value ValueBool, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagBool(value ValueBool, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: "",
@@ -132,6 +247,24 @@ func NewShortFlagBool(value ValueBool, name byte, helpText ...string) *ShortFlag
 	}
 }
 
+// ShortFlagMakeOptionWithOptionalValue returns the [*flagparser.Option] to use
+// for flags that take an optional value, which must be attached to the flag.
+//
+// Short flags with optional values are groupable and accept an attached argument
+// (e.g., `-O2` uses `2`, while `-O` uses the DefaultValue, like gcc does). Because
+// the value is optional, `-O 2` is `-O` followed by the `2` positional argument.
+//
+// This method panics if the name, the prefix, or the DefaultValue are empty.
+func ShortFlagMakeOptionWithOptionalValue(fx *ShortFlag) *flagparser.Option {
+	runtimex.Assert(fx.Prefix != "" && fx.Name != 0 && fx.DefaultValue != "")
+	return &flagparser.Option{
+		Type:         flagparser.OptionTypeGroupableArgumentRequired,
+		Prefix:       fx.Prefix,
+		Name:         string(fx.Name),
+		DefaultValue: fx.DefaultValue,
+	}
+}
+
 // ShortFlagMakeOptionWithValue returns the [*flagparser.Option] to use for
 // flags that require a value.
 //
@@ -147,13 +280,112 @@ func ShortFlagMakeOptionWithValue(fx *ShortFlag) *flagparser.Option {
 	}
 }
 
+// NewShortFlagBoolFunc constructs a new [*ShortFlag] bound to a [ValueBoolFunc].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+func NewShortFlagBoolFunc(value ValueBoolFunc, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: "",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionBool,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagBoolPtr constructs a new [*ShortFlag] bound to a [ValueBoolPtr].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+func NewShortFlagBoolPtr(value ValueBoolPtr, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: "",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionBool,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagBytesBase64 constructs a new [*ShortFlag] bound to a [ValueBytesBase64].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` BASE64` by default.
+func NewShortFlagBytesBase64(value ValueBytesBase64, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " BASE64",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagBytesHex constructs a new [*ShortFlag] bound to a [ValueBytesHex].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` HEX` by default.
+func NewShortFlagBytesHex(value ValueBytesHex, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " HEX",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagCount constructs a new [*ShortFlag] bound to a [ValueCount].
+//
+// Short count flags are groupable and take no argument, such that each
+// occurrence increments the counter (e.g., `-vvv` yields 3).
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+func NewShortFlagCount(value ValueCount, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: "",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionBool,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
 // NewShortFlagDuration constructs a new [*ShortFlag] bound to a [ValueDuration].
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` DURATION` by default.
-func NewShortFlagDuration(value ValueDuration, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagDuration(value ValueDuration, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " DURATION",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagDurationSlice constructs a new [*ShortFlag] bound to a [ValueDurationSlice].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` DURATION` by default.
+func NewShortFlagDurationSlice(value ValueDurationSlice, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " DURATION",
@@ -164,13 +396,64 @@ func NewShortFlagDuration(value ValueDuration, name byte, helpText ...string) *S
 	}
 }
 
+// NewShortFlagEnum constructs a new [*ShortFlag] bound to a [ValueEnum].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to the choices separated by `|` (e.g., ` json|yaml`).
+func NewShortFlagEnum(value ValueEnum, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " " + strings.Join(value.Choices(), "|"),
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagFileMode constructs a new [*ShortFlag] bound to a [ValueFileMode].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` MODE` by default.
+func NewShortFlagFileMode(value ValueFileMode, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " MODE",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagFloat32 constructs a new [*ShortFlag] bound to a [ValueFloat32].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` FLOAT32` by default.
+func NewShortFlagFloat32(value ValueFloat32, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " FLOAT32",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
 // NewShortFlagFloat64 constructs a new [*ShortFlag] bound to a [ValueFloat64].
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` FLOAT64` by default.
-func NewShortFlagFloat64(value ValueFloat64, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagFloat64(value ValueFloat64, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " FLOAT64",
@@ -181,13 +464,81 @@ func NewShortFlagFloat64(value ValueFloat64, name byte, helpText ...string) *Sho
 	}
 }
 
+// NewShortFlagFloat64Slice constructs a new [*ShortFlag] bound to a [ValueFloat64Slice].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` FLOAT64` by default.
+func NewShortFlagFloat64Slice(value ValueFloat64Slice, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " FLOAT64",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagFunc constructs a new [*ShortFlag] bound to a [ValueFunc].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` VALUE` by default.
+func NewShortFlagFunc(value ValueFunc, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " VALUE",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagGlob constructs a new [*ShortFlag] bound to a [ValueGlob].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` PATTERN` by default.
+func NewShortFlagGlob(value ValueGlob, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " PATTERN",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
 // NewShortFlagInt constructs a new [*ShortFlag] bound to a [ValueInt].
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` INT` by default.
-func NewShortFlagInt(value ValueInt, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagInt(value ValueInt, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " INT",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagIntSlice constructs a new [*ShortFlag] bound to a [ValueIntSlice].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` INT` by default.
+func NewShortFlagIntSlice(value ValueIntSlice, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " INT",
@@ -204,7 +555,7 @@ func NewShortFlagInt(value ValueInt, name byte, helpText ...string) *ShortFlag {
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` INT8` by default.
-func NewShortFlagInt8(value ValueInt8, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagInt8(value ValueInt8, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " INT8",
@@ -221,7 +572,7 @@ func NewShortFlagInt8(value ValueInt8, name byte, helpText ...string) *ShortFlag
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` INT16` by default.
-func NewShortFlagInt16(value ValueInt16, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagInt16(value ValueInt16, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " INT16",
@@ -238,7 +589,7 @@ func NewShortFlagInt16(value ValueInt16, name byte, helpText ...string) *ShortFl
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` INT32` by default.
-func NewShortFlagInt32(value ValueInt32, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagInt32(value ValueInt32, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " INT32",
@@ -255,7 +606,7 @@ func NewShortFlagInt32(value ValueInt32, name byte, helpText ...string) *ShortFl
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` INT64` by default.
-func NewShortFlagInt64(value ValueInt64, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagInt64(value ValueInt64, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " INT64",
@@ -266,13 +617,149 @@ func NewShortFlagInt64(value ValueInt64, name byte, helpText ...string) *ShortFl
 	}
 }
 
+// NewShortFlagInt64Slice constructs a new [*ShortFlag] bound to a [ValueInt64Slice].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` INT64` by default.
+func NewShortFlagInt64Slice(value ValueInt64Slice, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " INT64",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagIPSlice constructs a new [*ShortFlag] bound to a [ValueIPSlice].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` ADDR[,ADDR...]` by default.
+func NewShortFlagIPSlice(value ValueIPSlice, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " ADDR[,ADDR...]",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagJSON constructs a new [*ShortFlag] bound to a [ValueJSON].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` JSON` by default.
+func NewShortFlagJSON(value ValueJSON, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " JSON",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagLogLevel constructs a new [*ShortFlag] bound to a [ValueLogLevel].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` LEVEL` by default.
+func NewShortFlagLogLevel(value ValueLogLevel, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " LEVEL",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagNetipAddr constructs a new [*ShortFlag] bound to a [ValueNetipAddr].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` ADDR` by default.
+func NewShortFlagNetipAddr(value ValueNetipAddr, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " ADDR",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagPercent constructs a new [*ShortFlag] bound to a [ValuePercent].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` PERCENT` by default.
+func NewShortFlagPercent(value ValuePercent, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " PERCENT",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagRune constructs a new [*ShortFlag] bound to a [ValueRune].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` CHAR` by default.
+func NewShortFlagRune(value ValueRune, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " CHAR",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagSecretString constructs a new [*ShortFlag] bound to a [ValueSecretString].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` SECRET` by default.
+func NewShortFlagSecretString(value ValueSecretString, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " SECRET",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
 // NewShortFlagString constructs a new [*ShortFlag] bound to a [ValueString].
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` STRING` by default.
-func NewShortFlagString(value ValueString, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagString(value ValueString, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " STRING",
@@ -289,7 +776,7 @@ func NewShortFlagString(value ValueString, name byte, helpText ...string) *Short
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` STRING` by default.
-func NewShortFlagStringSlice(value ValueStringSlice, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagStringSlice(value ValueStringSlice, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " STRING",
@@ -300,13 +787,47 @@ func NewShortFlagStringSlice(value ValueStringSlice, name byte, helpText ...stri
 	}
 }
 
+// NewShortFlagStringToString constructs a new [*ShortFlag] bound to a [ValueStringToString].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` KEY=VALUE` by default.
+func NewShortFlagStringToString(value ValueStringToString, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " KEY=VALUE",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
 // NewShortFlagUint constructs a new [*ShortFlag] bound to a [ValueUint].
 //
 // This constructor sets the flag prefix to `-`. If you need a different prefix,
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` UINT` by default.
-func NewShortFlagUint(value ValueUint, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagUint(value ValueUint, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " UINT",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
+
+// NewShortFlagUintSlice constructs a new [*ShortFlag] bound to a [ValueUintSlice].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` UINT` by default.
+func NewShortFlagUintSlice(value ValueUintSlice, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " UINT",
@@ -323,7 +844,7 @@ func NewShortFlagUint(value ValueUint, name byte, helpText ...string) *ShortFlag
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` UINT8` by default.
-func NewShortFlagUint8(value ValueUint8, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagUint8(value ValueUint8, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " UINT8",
@@ -340,7 +861,7 @@ func NewShortFlagUint8(value ValueUint8, name byte, helpText ...string) *ShortFl
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` UINT16` by default.
-func NewShortFlagUint16(value ValueUint16, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagUint16(value ValueUint16, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " UINT16",
@@ -357,7 +878,7 @@ func NewShortFlagUint16(value ValueUint16, name byte, helpText ...string) *Short
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` UINT32` by default.
-func NewShortFlagUint32(value ValueUint32, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagUint32(value ValueUint32, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " UINT32",
@@ -374,7 +895,7 @@ func NewShortFlagUint32(value ValueUint32, name byte, helpText ...string) *Short
 // update the `Prefix` field in the returned [*ShortFlag] structure.
 //
 // The ArgumentName is set to ` UINT64` by default.
-func NewShortFlagUint64(value ValueUint64, name byte, helpText ...string) *ShortFlag {
+func NewShortFlagUint64(value ValueUint64, name rune, helpText ...string) *ShortFlag {
 	return &ShortFlag{
 		Description:  helpText,
 		ArgumentName: " UINT64",
@@ -384,3 +905,20 @@ func NewShortFlagUint64(value ValueUint64, name byte, helpText ...string) *Short
 		Value:        value,
 	}
 }
+
+// NewShortFlagUUID constructs a new [*ShortFlag] bound to a [ValueUUID].
+//
+// This constructor sets the flag prefix to `-`. If you need a different prefix,
+// update the `Prefix` field in the returned [*ShortFlag] structure.
+//
+// The ArgumentName is set to ` UUID` by default.
+func NewShortFlagUUID(value ValueUUID, name rune, helpText ...string) *ShortFlag {
+	return &ShortFlag{
+		Description:  helpText,
+		ArgumentName: " UUID",
+		Name:         name,
+		MakeOption:   ShortFlagMakeOptionWithValue,
+		Prefix:       "-",
+		Value:        value,
+	}
+}
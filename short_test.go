@@ -3,6 +3,10 @@
 package vflag
 
 import (
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"os"
 	"testing"
 	"time"
 
@@ -116,7 +120,7 @@ func TestShortFlagMakeOptionWithValue(t *testing.T) {
 func TestNewShortFlagAutoHelp(t *testing.T) {
 	sf := NewShortFlagAutoHelp(ValueAutoHelp{}, 'h', "Show help.", "Extra info.")
 
-	assert.Equal(t, byte('h'), sf.Name)
+	assert.Equal(t, 'h', sf.Name)
 	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, "", sf.ArgumentName)
 	assert.Equal(t, []string{"Show help.", "Extra info."}, sf.Description)
@@ -124,37 +128,173 @@ func TestNewShortFlagAutoHelp(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestNewShortFlagBigFloat(t *testing.T) {
+	var v big.Float
+	sf := NewShortFlagBigFloat(NewValueBigFloat(&v), 'a', "Set the amount.")
+
+	assert.Equal(t, 'a', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " BIGFLOAT", sf.ArgumentName)
+}
+
+func TestNewShortFlagBigInt(t *testing.T) {
+	var v big.Int
+	sf := NewShortFlagBigInt(NewValueBigInt(&v), 'e', "Set the exponent.")
+
+	assert.Equal(t, 'e', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " BIGINT", sf.ArgumentName)
+}
+
 func TestNewShortFlagBool(t *testing.T) {
 	var v bool
 	sf := NewShortFlagBool(NewValueBool(&v), 'v', "Enable verbose.")
 
-	assert.Equal(t, byte('v'), sf.Name)
+	assert.Equal(t, 'v', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, "", sf.ArgumentName)
+}
+
+func TestNewShortFlagBoolFunc(t *testing.T) {
+	sf := NewShortFlagBoolFunc(NewValueBoolFunc(func(string) error { return nil }), 't', "Enable tracing.")
+
+	assert.Equal(t, 't', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, "", sf.ArgumentName)
+	assert.Equal(t, flagparser.OptionTypeGroupableArgumentNone, sf.MakeOption(sf).Type)
+}
+
+func TestNewShortFlagBoolPtr(t *testing.T) {
+	var v *bool
+	sf := NewShortFlagBoolPtr(NewValueBoolPtr(&v), 'f', "Enable the feature.")
+
+	assert.Equal(t, 'f', sf.Name)
 	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, "", sf.ArgumentName)
+	assert.Equal(t, flagparser.OptionTypeGroupableArgumentNone, sf.MakeOption(sf).Type)
+}
+
+func TestNewShortFlagBytesBase64(t *testing.T) {
+	var v []byte
+	sf := NewShortFlagBytesBase64(NewValueBytesBase64(&v), 'k', "Set the key.")
+
+	assert.Equal(t, 'k', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " BASE64", sf.ArgumentName)
+}
+
+func TestNewShortFlagBytesHex(t *testing.T) {
+	var v []byte
+	sf := NewShortFlagBytesHex(NewValueBytesHex(&v), 'k', "Set the key.")
+
+	assert.Equal(t, 'k', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " HEX", sf.ArgumentName)
+}
+
+func TestNewShortFlagCount(t *testing.T) {
+	var v int
+	sf := NewShortFlagCount(NewValueCount(&v), 'v', "Increase verbosity.")
+
+	assert.Equal(t, 'v', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, "", sf.ArgumentName)
+	assert.Equal(t, flagparser.OptionTypeGroupableArgumentNone, sf.MakeOption(sf).Type)
 }
 
 func TestNewShortFlagDuration(t *testing.T) {
 	var v time.Duration
 	sf := NewShortFlagDuration(NewValueDuration(&v), 't', "Set timeout.")
 
-	assert.Equal(t, byte('t'), sf.Name)
+	assert.Equal(t, 't', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " DURATION", sf.ArgumentName)
+}
+
+func TestNewShortFlagDurationSlice(t *testing.T) {
+	var v []time.Duration
+	sf := NewShortFlagDurationSlice(NewValueDurationSlice(&v), 'r', "Retry after the given delay.")
+
+	assert.Equal(t, 'r', sf.Name)
 	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, " DURATION", sf.ArgumentName)
 }
 
+func TestNewShortFlagEnum(t *testing.T) {
+	var v string
+	sf := NewShortFlagEnum(NewValueEnum(&v, "json", "yaml"), 'f', "Set format.")
+
+	assert.Equal(t, 'f', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " json|yaml", sf.ArgumentName)
+}
+
+func TestNewShortFlagFileMode(t *testing.T) {
+	var v os.FileMode
+	sf := NewShortFlagFileMode(NewValueFileMode(&v), 'm', "Set the file mode.")
+
+	assert.Equal(t, 'm', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " MODE", sf.ArgumentName)
+}
+
+func TestNewShortFlagFloat32(t *testing.T) {
+	var v float32
+	sf := NewShortFlagFloat32(NewValueFloat32(&v), 'l', "Set learning rate.")
+
+	assert.Equal(t, 'l', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " FLOAT32", sf.ArgumentName)
+}
+
 func TestNewShortFlagFloat64(t *testing.T) {
 	var v float64
 	sf := NewShortFlagFloat64(NewValueFloat64(&v), 'r', "Set ratio.")
 
-	assert.Equal(t, byte('r'), sf.Name)
+	assert.Equal(t, 'r', sf.Name)
+	assert.Equal(t, " FLOAT64", sf.ArgumentName)
+}
+
+func TestNewShortFlagFloat64Slice(t *testing.T) {
+	var v []float64
+	sf := NewShortFlagFloat64Slice(NewValueFloat64Slice(&v), 'r', "Add ratio.")
+
+	assert.Equal(t, 'r', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, " FLOAT64", sf.ArgumentName)
 }
 
+func TestNewShortFlagFunc(t *testing.T) {
+	sf := NewShortFlagFunc(NewValueFunc(func(string) error { return nil }), 'H', "Add a header.")
+
+	assert.Equal(t, 'H', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " VALUE", sf.ArgumentName)
+}
+
+func TestNewShortFlagGlob(t *testing.T) {
+	var v []string
+	sf := NewShortFlagGlob(NewValueGlob(&v), 'i', "Include files matching PATTERN.")
+
+	assert.Equal(t, 'i', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " PATTERN", sf.ArgumentName)
+}
+
 func TestNewShortFlagInt(t *testing.T) {
 	var v int
 	sf := NewShortFlagInt(NewValueInt(&v), 'n', "Set count.")
 
-	assert.Equal(t, byte('n'), sf.Name)
+	assert.Equal(t, 'n', sf.Name)
+	assert.Equal(t, " INT", sf.ArgumentName)
+}
+
+func TestNewShortFlagIntSlice(t *testing.T) {
+	var v []int
+	sf := NewShortFlagIntSlice(NewValueIntSlice(&v), 'n', "Add number.")
+
+	assert.Equal(t, 'n', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, " INT", sf.ArgumentName)
 }
 
@@ -162,7 +302,7 @@ func TestNewShortFlagInt8(t *testing.T) {
 	var v int8
 	sf := NewShortFlagInt8(NewValueInt8(&v), 'b', "Set batch.")
 
-	assert.Equal(t, byte('b'), sf.Name)
+	assert.Equal(t, 'b', sf.Name)
 	assert.Equal(t, " INT8", sf.ArgumentName)
 }
 
@@ -170,7 +310,7 @@ func TestNewShortFlagInt16(t *testing.T) {
 	var v int16
 	sf := NewShortFlagInt16(NewValueInt16(&v), 'p', "Set port.")
 
-	assert.Equal(t, byte('p'), sf.Name)
+	assert.Equal(t, 'p', sf.Name)
 	assert.Equal(t, " INT16", sf.ArgumentName)
 }
 
@@ -178,7 +318,7 @@ func TestNewShortFlagInt32(t *testing.T) {
 	var v int32
 	sf := NewShortFlagInt32(NewValueInt32(&v), 'i', "Set index.")
 
-	assert.Equal(t, byte('i'), sf.Name)
+	assert.Equal(t, 'i', sf.Name)
 	assert.Equal(t, " INT32", sf.ArgumentName)
 }
 
@@ -186,15 +326,87 @@ func TestNewShortFlagInt64(t *testing.T) {
 	var v int64
 	sf := NewShortFlagInt64(NewValueInt64(&v), 's', "Set size.")
 
-	assert.Equal(t, byte('s'), sf.Name)
+	assert.Equal(t, 's', sf.Name)
+	assert.Equal(t, " INT64", sf.ArgumentName)
+}
+
+func TestNewShortFlagInt64Slice(t *testing.T) {
+	var v []int64
+	sf := NewShortFlagInt64Slice(NewValueInt64Slice(&v), 's', "Add size.")
+
+	assert.Equal(t, 's', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, " INT64", sf.ArgumentName)
 }
 
+func TestNewShortFlagIPSlice(t *testing.T) {
+	var v []netip.Addr
+	sf := NewShortFlagIPSlice(NewValueIPSlice(&v), 's', "Use the given DNS servers.")
+
+	assert.Equal(t, 's', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " ADDR[,ADDR...]", sf.ArgumentName)
+}
+
+func TestNewShortFlagJSON(t *testing.T) {
+	var v map[string]any
+	sf := NewShortFlagJSON(NewValueJSON(&v), 'f', "Filter the results.")
+
+	assert.Equal(t, 'f', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " JSON", sf.ArgumentName)
+}
+
+func TestNewShortFlagLogLevel(t *testing.T) {
+	var v slog.Level
+	sf := NewShortFlagLogLevel(NewValueLogLevel(&v), 'l', "Set the log level.")
+
+	assert.Equal(t, 'l', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " LEVEL", sf.ArgumentName)
+}
+
+func TestNewShortFlagNetipAddr(t *testing.T) {
+	var v netip.Addr
+	sf := NewShortFlagNetipAddr(NewValueNetipAddr(&v), 'b', "Bind to the given address.")
+
+	assert.Equal(t, 'b', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " ADDR", sf.ArgumentName)
+}
+
+func TestNewShortFlagPercent(t *testing.T) {
+	var v float64
+	sf := NewShortFlagPercent(NewValuePercent(&v), 't', "Set the threshold.")
+
+	assert.Equal(t, 't', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " PERCENT", sf.ArgumentName)
+}
+
+func TestNewShortFlagRune(t *testing.T) {
+	var v rune
+	sf := NewShortFlagRune(NewValueRune(&v), 'd', "Use the given delimiter.")
+
+	assert.Equal(t, 'd', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " CHAR", sf.ArgumentName)
+}
+
+func TestNewShortFlagSecretString(t *testing.T) {
+	var v SecretString
+	sf := NewShortFlagSecretString(NewValueSecretString(&v), 'p', "Use the given password.")
+
+	assert.Equal(t, 'p', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " SECRET", sf.ArgumentName)
+}
+
 func TestNewShortFlagString(t *testing.T) {
 	var v string
 	sf := NewShortFlagString(NewValueString(&v), 'o', "Set output.")
 
-	assert.Equal(t, byte('o'), sf.Name)
+	assert.Equal(t, 'o', sf.Name)
 	assert.Equal(t, " STRING", sf.ArgumentName)
 }
 
@@ -202,15 +414,33 @@ func TestNewShortFlagStringSlice(t *testing.T) {
 	var v []string
 	sf := NewShortFlagStringSlice(NewValueStringSlice(&v), 'H', "Set header.")
 
-	assert.Equal(t, byte('H'), sf.Name)
+	assert.Equal(t, 'H', sf.Name)
 	assert.Equal(t, " STRING", sf.ArgumentName)
 }
 
+func TestNewShortFlagStringToString(t *testing.T) {
+	var v map[string]string
+	sf := NewShortFlagStringToString(NewValueStringToString(&v), 'D', "Define a macro.")
+
+	assert.Equal(t, 'D', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " KEY=VALUE", sf.ArgumentName)
+}
+
 func TestNewShortFlagUint(t *testing.T) {
 	var v uint
 	sf := NewShortFlagUint(NewValueUint(&v), 'u', "Set users.")
 
-	assert.Equal(t, byte('u'), sf.Name)
+	assert.Equal(t, 'u', sf.Name)
+	assert.Equal(t, " UINT", sf.ArgumentName)
+}
+
+func TestNewShortFlagUintSlice(t *testing.T) {
+	var v []uint
+	sf := NewShortFlagUintSlice(NewValueUintSlice(&v), 'u', "Add user.")
+
+	assert.Equal(t, 'u', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
 	assert.Equal(t, " UINT", sf.ArgumentName)
 }
 
@@ -218,7 +448,7 @@ func TestNewShortFlagUint8(t *testing.T) {
 	var v uint8
 	sf := NewShortFlagUint8(NewValueUint8(&v), 'q', "Set queue.")
 
-	assert.Equal(t, byte('q'), sf.Name)
+	assert.Equal(t, 'q', sf.Name)
 	assert.Equal(t, " UINT8", sf.ArgumentName)
 }
 
@@ -226,7 +456,7 @@ func TestNewShortFlagUint16(t *testing.T) {
 	var v uint16
 	sf := NewShortFlagUint16(NewValueUint16(&v), 'm', "Set max.")
 
-	assert.Equal(t, byte('m'), sf.Name)
+	assert.Equal(t, 'm', sf.Name)
 	assert.Equal(t, " UINT16", sf.ArgumentName)
 }
 
@@ -234,7 +464,7 @@ func TestNewShortFlagUint32(t *testing.T) {
 	var v uint32
 	sf := NewShortFlagUint32(NewValueUint32(&v), 'c', "Set cache.")
 
-	assert.Equal(t, byte('c'), sf.Name)
+	assert.Equal(t, 'c', sf.Name)
 	assert.Equal(t, " UINT32", sf.ArgumentName)
 }
 
@@ -242,10 +472,19 @@ func TestNewShortFlagUint64(t *testing.T) {
 	var v uint64
 	sf := NewShortFlagUint64(NewValueUint64(&v), 'l', "Set limit.")
 
-	assert.Equal(t, byte('l'), sf.Name)
+	assert.Equal(t, 'l', sf.Name)
 	assert.Equal(t, " UINT64", sf.ArgumentName)
 }
 
+func TestNewShortFlagUUID(t *testing.T) {
+	var v string
+	sf := NewShortFlagUUID(NewValueUUID(&v), 'u', "Select the resource.")
+
+	assert.Equal(t, 'u', sf.Name)
+	assert.Equal(t, "-", sf.Prefix)
+	assert.Equal(t, " UUID", sf.ArgumentName)
+}
+
 func TestShortFlagMakeOptionPanicsOnEmptyPrefix(t *testing.T) {
 	var v bool
 	sf := NewShortFlagBool(NewValueBool(&v), 'v', "Verbose.")
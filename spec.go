@@ -0,0 +1,139 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// specDocument is the JSON document emitted by [*FlagSet.MarshalSpec].
+type specDocument struct {
+	Program     string         `json:"program"`
+	Flags       []*specFlag    `json:"flags"`
+	Positionals specPositional `json:"positionals"`
+}
+
+// specFlag describes a flag and its aliases in a [specDocument].
+type specFlag struct {
+	Names       []specName `json:"names"`
+	Type        string     `json:"type"`
+	Argument    string     `json:"argument"`
+	Default     string     `json:"default"`
+	Description string     `json:"description"`
+	Group       string     `json:"group"`
+	Required    bool       `json:"required"`
+}
+
+// specName is a flag name and its prefix in a [specFlag].
+type specName struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// specPositional describes the positional arguments in a [specDocument].
+type specPositional struct {
+	Min       int                 `json:"min"`
+	Max       int                 `json:"max"`
+	Arguments []specPositionalArg `json:"arguments"`
+}
+
+// specPositionalArg describes a named positional argument in a [specPositional].
+type specPositionalArg struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Optional    bool   `json:"optional"`
+	Variadic    bool   `json:"variadic"`
+}
+
+// MarshalSpec returns a JSON description of the [*FlagSet] for external tooling,
+// such as documentation generators, completion generators, and test harnesses.
+//
+// The document contains the `program` name, the `flags`, and the `positionals`.
+//
+// Each flag contains its `names` (each with `prefix` and `name`), the `type` of
+// its [Value] (e.g., `String` for a [ValueString]), the `argument` name used in
+// the help (empty when the flag takes no argument), the `default` value (the
+// DefaultText, if set, or empty when the flag has HideDefault set), the `description`, the `group`, and whether
+// it is `required`. The short and long forms of a flag (see [LongFlag].ShortName)
+// are listed as a single flag with multiple names.
+//
+// The positionals contain the `min` and `max` number of positional arguments,
+// where `max` is -1 when unbounded, and the named `arguments` (each with `name`,
+// `description`, `optional`, and `variadic`).
+func (fs *FlagSet) MarshalSpec() ([]byte, error) {
+	doc := &specDocument{
+		Program: fs.ProgramName,
+		Flags:   []*specFlag{},
+		Positionals: specPositional{
+			Min:       fs.MinPositionalArgs,
+			Max:       fs.MaxPositionalArgs,
+			Arguments: []specPositionalArg{},
+		},
+	}
+	if doc.Positionals.Max == math.MaxInt {
+		doc.Positionals.Max = -1
+	}
+
+	shorts := make(map[rune]*specFlag)
+	lookup := func(shortName rune, docs flagDocs, argumentName string) *specFlag {
+		if sflag, found := shorts[shortName]; found && shortName != 0 {
+			return sflag
+		}
+		sflag := &specFlag{
+			Names:       []specName{},
+			Type:        specValueType(docs.value),
+			Argument:    strings.Trim(argumentNameFromDocsOrDefault(docs.description, argumentName), " [=]"),
+			Default:     docs.defaultValue(),
+			Description: strings.Join(strings.Fields(strings.Join(docs.description, " ")), " "),
+			Group:       docs.group,
+		}
+		if docs.hideDefault {
+			sflag.Default = ""
+		}
+		doc.Flags = append(doc.Flags, sflag)
+		return sflag
+	}
+	for _, fx := range fs.ShortFlags {
+		sflag := lookup(0, fx.docs(), fx.ArgumentName)
+		shorts[fx.Name] = sflag
+		sflag.Names = append(sflag.Names, specName{Prefix: fx.Prefix, Name: string(fx.Name)})
+		sflag.Required = sflag.Required || fx.Required
+	}
+	for _, fx := range fs.LongFlags {
+		sflag := lookup(fx.ShortName, fx.docs(), fx.ArgumentName)
+		sflag.Names = append(sflag.Names, specName{Prefix: fx.Prefix, Name: fx.Name})
+		sflag.Required = sflag.Required || fx.Required
+		for _, alias := range fx.Aliases {
+			sflag.Names = append(sflag.Names, specName{Prefix: fx.Prefix, Name: alias})
+		}
+	}
+
+	for idx, arg := range fs.PositionalArguments {
+		doc.Positionals.Arguments = append(doc.Positionals.Arguments, specPositionalArg{
+			Name:        arg.Name,
+			Description: strings.Join(strings.Fields(strings.Join(arg.Description, " ")), " "),
+			Optional:    idx >= fs.MinPositionalArgs,
+			Variadic:    arg.Variadic,
+		})
+	}
+
+	return json.Marshal(doc)
+}
+
+// specValueType returns the type name of the given [Value] without the
+// `Value` prefix, looking through the wrappers we add (e.g., for constraints).
+func specValueType(value Value) string {
+	if constrained, ok := value.(*valueConstrained); ok {
+		value = constrained.Value
+	}
+	vtype := reflect.TypeOf(value)
+	for vtype.Kind() == reflect.Pointer {
+		vtype = vtype.Elem()
+	}
+	return strings.TrimPrefix(vtype.Name(), "Value")
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagSetMarshalSpec(t *testing.T) {
+	t.Run("flags and positionals", func(t *testing.T) {
+		var (
+			output  = "-"
+			retries int
+			src     []string
+			dst     string
+		)
+		fs := NewFlagSet("cp", ContinueOnError)
+		fs.AutoHelp('h', "help", "Show this help message and exit.")
+		fs.StringVar(&output, 'o', "output", "Write output to `FILE`.")
+		fs.Group("Network").IntVar(&retries, 0, "retries", "Retry the", "given number of times.")
+		fs.MarkRequired("retries")
+		fs.PositionalRestVar(&src, "SRC", "The source files.")
+		fs.PositionalStringVar(&dst, "DST", "The destination.")
+
+		data, err := fs.MarshalSpec()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"program": "cp",
+			"flags": [
+				{
+					"names": [{"prefix": "-", "name": "h"}, {"prefix": "--", "name": "help"}],
+					"type": "AutoHelp",
+					"argument": "",
+					"default": "false",
+					"description": "Show this help message and exit.",
+					"group": "",
+					"required": false
+				},
+				{
+					"names": [{"prefix": "-", "name": "o"}, {"prefix": "--", "name": "output"}],
+					"type": "String",
+					"argument": "FILE",
+					"default": "-",
+					"description": "Write output to `+"`FILE`"+`.",
+					"group": "",
+					"required": false
+				},
+				{
+					"names": [{"prefix": "--", "name": "retries"}],
+					"type": "Int",
+					"argument": "INT",
+					"default": "0",
+					"description": "Retry the given number of times.",
+					"group": "Network",
+					"required": true
+				}
+			],
+			"positionals": {
+				"min": 2,
+				"max": -1,
+				"arguments": [
+					{"name": "SRC", "description": "The source files.", "optional": false, "variadic": true},
+					{"name": "DST", "description": "The destination.", "optional": false, "variadic": false}
+				]
+			}
+		}`, string(data))
+	})
+
+	t.Run("empty flag set", func(t *testing.T) {
+		fs := NewFlagSet("true", ContinueOnError)
+		fs.SetMinMaxPositionalArgs(0, math.MaxInt)
+		data, err := fs.MarshalSpec()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"program": "true",
+			"flags": [],
+			"positionals": {"min": 0, "max": -1, "arguments": []}
+		}`, string(data))
+	})
+
+	t.Run("short flags with non-comparable values", func(t *testing.T) {
+		var color string
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		data, err := fs.MarshalSpec()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"program": "prog",
+			"flags": [
+				{
+					"names": [{"prefix": "-", "name": "c"}, {"prefix": "--", "name": "color"}],
+					"type": "Enum",
+					"argument": "red|blue",
+					"default": "",
+					"description": "Set the color.",
+					"group": "",
+					"required": false
+				}
+			],
+			"positionals": {"min": 0, "max": 0, "arguments": []}
+		}`, string(data))
+	})
+}
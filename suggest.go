@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bassosimone/flagparser"
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown
+// option and a registered option for suggesting the registered option.
+const maxSuggestionDistance = 2
+
+// withSuggestions wraps a [flagparser.ErrUnknownOption] error to add `did you mean`
+// suggestions computed among the given options, such that [errors.As] still finds
+// the original error. Otherwise, it returns the original error.
+//
+// We only consider options that cannot be grouped, since suggesting a short
+// option for a mistyped group of short options (e.g., `-vx`) is not useful.
+func withSuggestions(err error, options []*flagparser.Option) error {
+	var unknown flagparser.ErrUnknownOption
+	if !errors.As(err, &unknown) {
+		return err
+	}
+	name := unknown.Prefix + unknown.Name
+
+	var (
+		best        = maxSuggestionDistance + 1
+		suggestions []string
+	)
+	for _, opt := range options {
+		if opt.Type == flagparser.OptionTypeGroupableArgumentNone ||
+			opt.Type == flagparser.OptionTypeGroupableArgumentRequired {
+			continue
+		}
+		candidate := opt.Prefix + opt.Name
+		distance := levenshtein(name, candidate)
+		switch {
+		case distance < best:
+			best, suggestions = distance, []string{candidate}
+		case distance == best:
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	if len(suggestions) <= 0 {
+		return err
+	}
+	return fmt.Errorf("%w (did you mean %s?)", err, strings.Join(suggestions, " or "))
+}
+
+// levenshtein returns the Levenshtein distance between two strings.
+func levenshtein(left, right string) int {
+	a, b := []rune(left), []rune(right)
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
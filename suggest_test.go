@@ -0,0 +1,94 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bassosimone/flagparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		left, right string
+		expect      int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"--fail", "--fail", 0},
+		{"--fali", "--fail", 2},
+		{"--fial", "--fail", 2},
+		{"--verbos", "--verbose", 1},
+		{"kitten", "sitting", 3},
+		{"colour", "color", 1},
+		{"è", "e", 1},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, levenshtein(tc.left, tc.right), tc.left+" vs "+tc.right)
+	}
+}
+
+func TestFlagSetParseSuggestions(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			fail    bool
+			file    string
+			output  string
+			verbose bool
+		)
+		fset := NewFlagSet("curl", ContinueOnError)
+		fset.BoolVar(&fail, 'f', "fail", "Fail on HTTP errors.")
+		fset.StringVar(&file, 0, "file", "Read from file.")
+		fset.StringVar(&output, 'o', "output", "Write output to file.")
+		fset.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		return fset
+	}
+
+	cases := []struct {
+		args   []string
+		expect string
+	}{
+		{[]string{"--verbos"}, "unknown option: --verbos (did you mean --verbose?)"},
+		{[]string{"--outptu=x"}, "unknown option: --outptu (did you mean --output?)"},
+		{[]string{"--fiel"}, "unknown option: --fiel (did you mean --fail or --file?)"},
+		{[]string{"--nothing-similar"}, "unknown option: --nothing-similar"},
+	}
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			err := newFlagSet().Parse(tc.args)
+			require.Error(t, err)
+			assert.Equal(t, tc.expect, err.Error())
+		})
+	}
+
+	t.Run("ExitOnError prints the suggestion", func(t *testing.T) {
+		fset := newFlagSet()
+		fset.ErrorHandling = ExitOnError
+		var stderr strings.Builder
+		fset.Stderr = &stderr
+		fset.Exit = func(status int) { panic(status) }
+		assert.PanicsWithValue(t, 2, func() {
+			fset.Parse([]string{"--verbos"})
+		})
+		assert.Equal(t, "curl: unknown option: --verbos (did you mean --verbose?)\n", stderr.String())
+	})
+
+	t.Run("the unknown option error is wrapped", func(t *testing.T) {
+		err := newFlagSet().Parse([]string{"--verbos"})
+		var unknown flagparser.ErrUnknownOption
+		require.True(t, errors.As(err, &unknown))
+		assert.Equal(t, "verbos", unknown.Name)
+	})
+
+	t.Run("other errors are not modified", func(t *testing.T) {
+		err := errors.New("option requires an argument: --output")
+		assert.Equal(t, err, withSuggestions(err, nil))
+	})
+}
@@ -0,0 +1,19 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+//go:build !unix
+
+package vflag
+
+import "errors"
+
+// errTerminalUnsupported is the error returned by [readTerminalPassword]
+// on the systems where we do not support reading from the terminal.
+var errTerminalUnsupported = errors.New("reading from the terminal is not supported on this system")
+
+// readTerminalPassword always fails, since we only support reading
+// the password from the terminal on Unix systems.
+func readTerminalPassword() (string, error) {
+	return "", errTerminalUnsupported
+}
@@ -0,0 +1,52 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+//go:build unix
+
+package vflag
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// readTerminalPassword prompts for a password on the controlling terminal
+// and reads it with echo disabled using the `stty` command.
+//
+// This function fails on systems without `/dev/tty` and `stty`.
+//
+// If restoring echo fails, we return the error, since the user needs to
+// know that the terminal is still not echoing (e.g., to run `stty echo`).
+func readTerminalPassword() (password string, err error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer tty.Close()
+
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = tty
+		return cmd.Run()
+	}
+	if err := stty("-echo"); err != nil {
+		return "", err
+	}
+	defer func() {
+		fmt.Fprintln(tty)
+		if echoErr := stty("echo"); echoErr != nil && err == nil {
+			password, err = "", fmt.Errorf("cannot restore the terminal echo: %w", echoErr)
+		}
+	}()
+
+	fmt.Fprint(tty, "Password: ")
+	line, err := bufio.NewReader(tty).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -9,10 +9,11 @@ package vflag
 import (
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
+	"github.com/bassosimone/flagparser"
 	"github.com/bassosimone/must"
-	"github.com/bassosimone/textwrap"
 )
 
 // PrintUsageString writes the usage string to the given [io.Writer].
@@ -31,13 +32,368 @@ func (fs *FlagSet) PrintUsageError(w io.Writer, err error) {
 	fs.UsagePrinter.PrintUsageError(fs, w, err)
 }
 
+// PrintShortUsage writes the terse help, which only contains the usage line and
+// the synopsis of the flags, to the given [io.Writer]. For example:
+//
+//	Usage: curl [flags] URL
+//
+//	Flags: -h, --help, -o STRING, --output STRING, -s
+//
+//	Try `curl --help' for the full help.
+//
+// [*FlagSet.Parse] uses this method with [ExitOnError] when the user requests
+// the [HelpShort] [HelpLevel]. We only print the hint on how to obtain the full
+// help if there is a help flag using the [HelpFull] [HelpLevel].
+//
+// This function panics if writing to the [io.Writer] fails.
+func (fs *FlagSet) PrintShortUsage(w io.Writer) {
+	up, ok := fs.UsagePrinter.(*DefaultUsagePrinter)
+	if !ok {
+		up = &DefaultUsagePrinter{}
+	}
+	must.Fprintf(w, "Usage: %s%s%s\n", fs.ProgramName, up.flagsName(fs), up.positionalArgumentsUsage(fs))
+
+	var synopsis []string
+	for _, dflag := range docsFlags(fs) {
+		synopsis = append(synopsis, dflag.synopsis...)
+	}
+	if len(synopsis) > 0 {
+		indent := strings.Repeat(" ", len("Flags: "))
+		text := wrapText(strings.Join(synopsis, ", "), up.wrapColumn(), indent)
+		must.Fprintf(w, "\nFlags: %s\n", strings.TrimPrefix(text, indent))
+	}
+
+	if cmdline := fs.fullHelpInvocation(); cmdline != "" {
+		must.Fprintf(w, "\nTry `%s' for the full help.\n", cmdline)
+	}
+}
+
+// fullHelpInvocation is like [*FlagSet.HelpInvocation] but only
+// considers the help flags using the [HelpFull] [HelpLevel].
+func (fs *FlagSet) fullHelpInvocation() string {
+	for _, fx := range fs.LongFlags {
+		if help, ok := fx.Value.(ValueAutoHelp); ok && help.Level == HelpFull {
+			return fs.ProgramName + " " + fx.Prefix + fx.Name
+		}
+	}
+	for _, fx := range fs.ShortFlags {
+		if help, ok := fx.Value.(ValueAutoHelp); ok && help.Level == HelpFull {
+			return fs.ProgramName + " " + fx.Prefix + string(fx.Name)
+		}
+	}
+	return ""
+}
+
+// PrintDefaults writes a compact listing of the flags to [*FlagSet.Output].
+//
+// This method eases migrating from [flag.FlagSet.PrintDefaults]. Each flag
+// appears on its own line followed by its description on a single line:
+//
+//	-o FILE, --output FILE
+//	  	Write output to the given file. (default: -)
+//
+// Like [*DefaultUsagePrinter.PrintUsageString], we list flags with the same
+// description, and the long flags with their short form, together. We append the default value to the description
+// unless it is a zero value or the description already contains it.
+//
+// This method panics if writing to the [io.Writer] fails.
+func (fs *FlagSet) PrintDefaults() {
+	type defaultsFlag struct {
+		synopsis    []string
+		description string
+	}
+
+	var (
+		dflags []*defaultsFlag
+		index  = make(map[string]*defaultsFlag)
+		shorts = make(map[rune]*defaultsFlag)
+	)
+	add := func(synopsis []string, docs flagDocs, linked *defaultsFlag) *defaultsFlag {
+		text := strings.Join(docs.description, " ")
+		if ref, ok := index[text]; ok && text != "" && linked == nil {
+			linked = ref
+		}
+		if linked != nil {
+			linked.synopsis = append(linked.synopsis, synopsis...)
+			return linked
+		}
+		dflag := &defaultsFlag{synopsis: synopsis, description: defaultsDescription(docs)}
+		index[text] = dflag
+		dflags = append(dflags, dflag)
+		return dflag
+	}
+
+	for _, fx := range fs.ShortFlags {
+		synopsis := []string{fx.Usage()}
+		if fx.NegatedPrefix != "" {
+			synopsis = append(synopsis, fx.NegatedPrefix+string(fx.Name))
+		}
+		shorts[fx.Name] = add(synopsis, fx.docs(), nil)
+	}
+	for _, fx := range fs.LongFlags {
+		synopsis := []string{fx.Usage()}
+		if !fx.HideAliases {
+			synopsis = append(synopsis, fx.AliasesUsage()...)
+		}
+		if fx.Negatable {
+			synopsis = append(synopsis, fx.Prefix+fx.NegatedName())
+		}
+		add(synopsis, fx.docs(), shorts[fx.ShortName])
+	}
+
+	w := fs.Output()
+	for _, dflag := range dflags {
+		must.Fprintf(w, "  %s\n", strings.Join(dflag.synopsis, ", "))
+		if dflag.description != "" {
+			must.Fprintf(w, "    \t%s\n", dflag.description)
+		}
+	}
+}
+
+// HideDefault sets HideDefault to true for the flag with the given name and
+// its short or long form, such that the help does not show their
+// default value, which is useful for meaningless, huge, or sensitive defaults.
+//
+// Names are either long flag names or aliases, or short flag names, like
+// in [*FlagSet.Changed]. This method panics if there is no such flag.
+func (fs *FlagSet) HideDefault(name string) {
+	shorts, longs := fs.boundFlags(name)
+	for _, fx := range shorts {
+		fx.HideDefault = true
+	}
+	for _, fx := range longs {
+		fx.HideDefault = true
+	}
+}
+
+// SetDefaultText sets the DefaultText for the flag with the given name and its
+// short or long form, such that the help shows the given text as the
+// default value (e.g., `(default: autodetected)`) instead of Value.String().
+//
+// Names are either long flag names or aliases, or short flag names, like
+// in [*FlagSet.Changed]. This method panics if there is no such flag.
+func (fs *FlagSet) SetDefaultText(name, text string) {
+	shorts, longs := fs.boundFlags(name)
+	for _, fx := range shorts {
+		fx.DefaultText = text
+	}
+	for _, fx := range longs {
+		fx.DefaultText = text
+	}
+}
+
+// flagDocs contains the [*ShortFlag] and [*LongFlag] fields used for documenting the flag.
+type flagDocs struct {
+	defaultText string
+	description []string
+	group       string
+	hideDefault bool
+	required    bool
+	value       Value
+}
+
+// docs returns the [flagDocs] of the [*ShortFlag].
+func (fx *ShortFlag) docs() flagDocs {
+	return flagDocs{
+		defaultText: fx.DefaultText,
+		description: fx.Description,
+		group:       fx.Group,
+		hideDefault: fx.HideDefault,
+		required:    fx.Required,
+		value:       fx.Value,
+	}
+}
+
+// docs returns the [flagDocs] of the [*LongFlag].
+func (fx *LongFlag) docs() flagDocs {
+	return flagDocs{
+		defaultText: fx.DefaultText,
+		description: fx.Description,
+		group:       fx.Group,
+		hideDefault: fx.HideDefault,
+		required:    fx.Required,
+		value:       fx.Value,
+	}
+}
+
+// defaultValue returns the DefaultText, if set, or the current value.
+func (docs flagDocs) defaultValue() string {
+	if docs.defaultText != "" {
+		return docs.defaultText
+	}
+	return docs.value.String()
+}
+
+// defaultsDescription returns the single-line description used by [*FlagSet.PrintDefaults].
+//
+// For required flags, we append `(required)` instead of the default value, and
+// we do not append the default value of flags with HideDefault set to true.
+// We always append the DefaultText, when set, even if the value is a zero value.
+func defaultsDescription(docs flagDocs) string {
+	description := strings.Join(docs.description, " ")
+	current := docs.defaultValue()
+	hasDefault := strings.Contains(description, "@DEFAULT_VALUE@")
+	description = strings.ReplaceAll(description, "@DEFAULT_VALUE@", current)
+	description = strings.Join(strings.Fields(description), " ")
+	if docs.required {
+		return strings.TrimSpace(description + " (required)")
+	}
+	switch current {
+	case "", "0", "0s", "false":
+		if docs.defaultText == "" {
+			return description
+		}
+	}
+	if hasDefault || docs.hideDefault {
+		return description
+	}
+	return strings.TrimSpace(description + " (default: " + current + ")")
+}
+
+// docsFlag is a flag seen by the [UsagePrinter] implementations generating
+// documentation, where flags with the same description are merged.
+type docsFlag struct {
+	// synopsis contains the usage strings of the flags sharing the description.
+	synopsis []string
+
+	// description contains the single-line description.
+	description string
+
+	// group is the flag group.
+	group string
+}
+
+// docsFlags returns the [*docsFlag] list for the given [*FlagSet] merging
+// the flags with the same description, as well as the long flags with their
+// short form (see [LongFlag].ShortName), and using [defaultsDescription].
+func docsFlags(fset *FlagSet) []*docsFlag {
+	var (
+		dflags []*docsFlag
+		index  = make(map[string]*docsFlag)
+		shorts = make(map[rune]*docsFlag)
+	)
+	lookup := func(docs flagDocs, linked *docsFlag) *docsFlag {
+		if linked != nil {
+			return linked
+		}
+		text := strings.Join(docs.description, " ")
+		if ref, ok := index[text]; ok && text != "" {
+			return ref
+		}
+		dflag := &docsFlag{description: defaultsDescription(docs), group: docs.group}
+		index[text] = dflag
+		dflags = append(dflags, dflag)
+		return dflag
+	}
+	for _, fx := range fset.ShortFlags {
+		dflag := lookup(fx.docs(), nil)
+		shorts[fx.Name] = dflag
+		dflag.synopsis = append(dflag.synopsis, fx.Usage())
+		if fx.NegatedPrefix != "" {
+			dflag.synopsis = append(dflag.synopsis, fx.NegatedPrefix+string(fx.Name))
+		}
+	}
+	for _, fx := range fset.LongFlags {
+		dflag := lookup(fx.docs(), shorts[fx.ShortName])
+		dflag.synopsis = append(dflag.synopsis, fx.Usage())
+		if !fx.HideAliases {
+			dflag.synopsis = append(dflag.synopsis, fx.AliasesUsage()...)
+		}
+		if fx.Negatable {
+			dflag.synopsis = append(dflag.synopsis, fx.Prefix+fx.NegatedName())
+		}
+		if bindings := fset.bindingsUsage(fx); !strings.Contains(dflag.description, bindings) {
+			dflag.description = strings.TrimSpace(dflag.description + bindings)
+		}
+	}
+	return dflags
+}
+
 func (up *DefaultUsagePrinter) flagsName(fset *FlagSet) (output string) {
+	if up.GenerateSynopsis {
+		output = fset.optionalFlagsUsage()
+		for _, synopsis := range fset.requiredFlagsUsage() {
+			output += " " + synopsis
+		}
+		line := up.indent() + fset.ProgramName + output + up.positionalArgumentsUsage(fset)
+		if displayWidth(line) <= up.wrapColumn() {
+			return output
+		}
+		output = ""
+	}
 	if len(fset.ShortFlags) > 0 || len(fset.LongFlags) > 0 {
 		output = " [flags]"
 	}
+	for _, synopsis := range fset.requiredFlagsUsage() {
+		output += " " + synopsis
+	}
 	return output
 }
 
+// optionalFlagsUsage returns the usage string of the flags that are not required
+// listing a single form, preferably the short one, for each flag (e.g.,
+// `[-fsSL] [-o FILE] [--retry INT]`), where we group the short flags without
+// an argument sharing the same prefix together.
+func (fs *FlagSet) optionalFlagsUsage() (output string) {
+	var (
+		grouped  = make(map[string]string)
+		prefixes []string
+		seen     []*ShortFlag
+		synopsis []string
+	)
+	for _, fx := range fs.ShortFlags {
+		if fx.Required {
+			continue
+		}
+		seen = append(seen, fx)
+		if !fs.DisableGrouping && fx.MakeOption(fx).Type == flagparser.OptionTypeGroupableArgumentNone &&
+			fx.Usage() == fx.Prefix+string(fx.Name) {
+			if _, ok := grouped[fx.Prefix]; !ok {
+				prefixes = append(prefixes, fx.Prefix)
+			}
+			grouped[fx.Prefix] += string(fx.Name)
+			continue
+		}
+		synopsis = append(synopsis, fx.Usage())
+	}
+	for _, fx := range fs.LongFlags {
+		if fx.Required || slices.ContainsFunc(seen, func(sfx *ShortFlag) bool { return sameFlag(sfx, fx) }) {
+			continue
+		}
+		option := fx.MakeOption(fx)
+		if option.Type == flagparser.OptionTypeStandaloneArgumentOptional && option.DefaultValue == "true" {
+			synopsis = append(synopsis, fx.Prefix+fx.Name)
+			continue
+		}
+		synopsis = append(synopsis, fx.Usage())
+	}
+	for _, prefix := range prefixes {
+		output += " [" + prefix + grouped[prefix] + "]"
+	}
+	for _, entry := range synopsis {
+		output += " [" + entry + "]"
+	}
+	return
+}
+
+// requiredFlagsUsage returns the usage strings of the required flags,
+// listing a single form, preferably the long one, for each flag.
+func (fs *FlagSet) requiredFlagsUsage() (output []string) {
+	var seen []*LongFlag
+	for _, fx := range fs.LongFlags {
+		if fx.Required {
+			seen = append(seen, fx)
+			output = append(output, fx.Usage())
+		}
+	}
+	for _, fx := range fs.ShortFlags {
+		if fx.Required && !slices.ContainsFunc(seen, func(lfx *LongFlag) bool { return sameFlag(fx, lfx) }) {
+			output = append(output, fx.Usage())
+		}
+	}
+	return
+}
+
 // HelpInvocation returns the string with which to obtain help.
 func (fs *FlagSet) HelpInvocation() string {
 	// Prefer long flags for the help invocation hint
@@ -61,6 +417,10 @@ func (up *DefaultUsagePrinter) positionalArgumentsUsage(fset *FlagSet) (output s
 		switch {
 		case output != "":
 			// nothing
+		case fset.PositionalPattern != "":
+			output = fset.PositionalPattern
+		case len(fset.PositionalArguments) > 0:
+			output = up.namedPositionalArgumentsUsage(fset)
 		case minArgs == 0 && maxArgs == 1:
 			output = "[arg]"
 		case minArgs == 1 && maxArgs == 1:
@@ -75,6 +435,23 @@ func (up *DefaultUsagePrinter) positionalArgumentsUsage(fset *FlagSet) (output s
 	return
 }
 
+// namedPositionalArgumentsUsage returns the usage string for the named positional
+// arguments, where optional arguments are within brackets (e.g., `HOST [PORT]`).
+func (up *DefaultUsagePrinter) namedPositionalArgumentsUsage(fset *FlagSet) string {
+	names := make([]string, 0, len(fset.PositionalArguments)+1)
+	for idx, arg := range fset.PositionalArguments {
+		if idx >= fset.MinPositionalArgs {
+			names = append(names, "["+arg.usageName()+"]")
+			continue
+		}
+		names = append(names, arg.usageName())
+	}
+	if fset.MaxPositionalArgs > len(fset.PositionalArguments) && fset.variadicPositionalIndex() < 0 {
+		names = append(names, "[arg ...]")
+	}
+	return strings.Join(names, " ")
+}
+
 // UsagePrinter is the interface used to print the usage.
 type UsagePrinter interface {
 	PrintUsageString(fs *FlagSet, w io.Writer)
@@ -104,6 +481,12 @@ const (
 //
 //	    Transfer 1+ URLs using the HTTP/HTTPS protocol.
 //
+//	Arguments
+//
+//	    URL
+//
+//	        The URL to transfer.
+//
 //	Flags
 //
 //	    -o FILE, --output FILE (default: `-`)
@@ -120,6 +503,12 @@ const (
 //
 //	        curl -so /dev/null https://www.example.com/
 //
+// After the Commands section, we also print the Environment and Files
+// sections, and, after the Examples section, the Authors, Bugs, and
+// See Also sections, when the [*FlagSet] configures them. Likewise,
+// we print the [*FlagSet] UsageHeader paragraphs before the Usage
+// section and the UsageFooter paragraphs at the end.
+//
 // # Help Hint Format
 //
 // The template we use follows this pattern:
@@ -130,32 +519,128 @@ const (
 // We only print the help hint on the given [io.Writer] if the user has
 // configured a [*Flag] containing a [ValueAutoHelp] [Value].
 type DefaultUsagePrinter struct {
+	// Color enables colored output: flag names are bold, argument
+	// placeholders are dimmed, and headings are underlined.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to false.
+	//
+	// Even when this field is true, we do not emit colors if the
+	// `NO_COLOR` environment variable is set and not empty, or if
+	// the [io.Writer] is not a terminal.
+	Color bool
+
+	// Commands contains the subcommands listed when printing the usage.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to an empty slice.
+	//
+	// [*Command.AddCommand] appends to this field. Each command is listed
+	// using its name followed by its Summary, when not empty.
+	Commands []*Command
+
+	// CompareFlags is the function comparing flag names when sorting flags.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to nil, meaning that
+	// we use [strings.Compare]. Setting this field implies SortFlags.
+	//
+	// The names do not include the prefix and are the long name, if any, or
+	// the short name otherwise (e.g., `output` for `-o, --output FILE`).
+	CompareFlags func(a, b string) int
+
 	// Description contains the program description paragraphs used when printing the usage.
 	//
 	// [NewDefaultUsagePrinter] initializes this field to an empty slice.
 	//
 	// The [*DefaultUsagePrinter.PrintUsageString] method will treat each paragraph as independent
-	// and word wrap it to 72 characters removing leading spaces. However, if
-	// a paragraph starts with 4 spaces, the method will assume the user intends to
-	// emit a verbatim block and will not word wrap it.
+	// and word wrap it to WrapAtColumn characters removing leading spaces. However, if
+	// a paragraph starts with 4 spaces or with a "```" fence, the method will assume
+	// the user intends to emit a verbatim block and will not word wrap it.
+	//
+	// Paragraphs may use lightweight markup: `code` spans and *emphasis*, which we
+	// render as bold and underlined text when using Color and strip otherwise. The
+	// [*MarkdownUsagePrinter] keeps the markup as is, since it is valid Markdown.
 	Description []string
 
+	// DescriptionIndent is the number of spaces before the flags, arguments,
+	// and commands descriptions, which are nested within their section.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to zero, meaning
+	// that we use the default indentation of 8 spaces.
+	DescriptionIndent int
+
 	// Example contains the examples paragraphs used when printing the usage.
 	//
 	// [NewDefaultUsagePrinter] initializes this field to an empty slice.
 	//
 	// The [*DefaultUsagePrinter.PrintUsageString] method will treat each paragraph as independent
-	// and word wrap it to 72 characters removing leading spaces. However, if
-	// a paragraph starts with 4 spaces, the method will assume the user intends to
-	// emit a verbatim block and will not word wrap it.
+	// and word wrap it to WrapAtColumn characters removing leading spaces. However, if
+	// a paragraph starts with 4 spaces or with a "```" fence, the method will assume
+	// the user intends to emit a verbatim block and will not word wrap it.
 	Example []string
 
+	// GenerateSynopsis replaces the generic `[flags]` in the usage line with
+	// a synopsis generated from the flag definitions. For example:
+	//
+	//	curl [-fsSL] [-o FILE] [--retry INT] --url URL
+	//
+	// We group the short flags without an argument, list a single flag for
+	// each [Value], and list the required flags last. When the usage line
+	// would be wider than WrapAtColumn, we fall back to `[flags]`.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to false.
+	GenerateSynopsis bool
+
+	// Indent is the number of spaces before the entries of each section (e.g.,
+	// the flags synopsis and the description paragraphs).
+	//
+	// [NewDefaultUsagePrinter] initializes this field to zero, meaning
+	// that we use the default indentation of 4 spaces.
+	Indent int
+
 	// PositionalArgumentsUsage is the usage string for postional arguments.
 	//
 	// [NewDefaultUsagePrinter] initializes this field to "". If this value is empty,
 	// when printing help we use "", arg" or "args..." depending on whether
-	// zero, one, or multiple positional arguments are possible.
+	// zero, one, or multiple positional arguments are possible. When the
+	// [*FlagSet] declares PositionalArguments, we use their names instead.
 	PositionalArgumentsUsage string
+
+	// SortFlags causes [*DefaultUsagePrinter.PrintUsageString] to sort the
+	// flags alphabetically by long name, or short name if there is no long
+	// name, rather than listing them in declaration order. The help flags
+	// and the `version` flag come last.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to false.
+	SortFlags bool
+
+	// WrapAtColumn is the column at which we word wrap the paragraphs.
+	//
+	// [NewDefaultUsagePrinter] initializes this field to zero, meaning
+	// that we use the default column, which is 72.
+	WrapAtColumn int
+}
+
+// wrapColumn returns the configured WrapAtColumn or the default.
+func (up *DefaultUsagePrinter) wrapColumn() int {
+	if up.WrapAtColumn > 0 {
+		return up.WrapAtColumn
+	}
+	return wrapAtColumn
+}
+
+// indent returns the configured Indent or the default.
+func (up *DefaultUsagePrinter) indent() string {
+	if up.Indent > 0 {
+		return strings.Repeat(" ", up.Indent)
+	}
+	return indent4
+}
+
+// descriptionIndent returns the configured DescriptionIndent or the default.
+func (up *DefaultUsagePrinter) descriptionIndent() string {
+	if up.DescriptionIndent > 0 {
+		return strings.Repeat(" ", up.DescriptionIndent)
+	}
+	return indent8
 }
 
 // usageFlag is a flag seen by [*DefaultUsagePrinter.PrintUsageString].
@@ -168,21 +653,92 @@ type usageFlag struct {
 
 	// description contains the formatted flag description.
 	description string
+
+	// bindings contains the environment variable and config key annotations.
+	bindings string
+
+	// required is true when the flag is required.
+	required bool
+
+	// group is the flag group.
+	group string
+
+	// last is true for the help and `version` flags, which come last when sorting.
+	last bool
+
+	// long is true when name is a long flag name.
+	long bool
+
+	// short is the short flag name or, for long flags, their ShortName.
+	short rune
+
+	// name is the flag name used when sorting.
+	name string
+}
+
+// usageFlagGroups returns the groups of the usage flags with a non-empty
+// description ordered according to [orderFlagGroups].
+func usageFlagGroups(uflags []*usageFlag) []string {
+	groups := make([]string, 0, len(uflags))
+	for _, uflag := range uflags {
+		if uflag.description != "" {
+			groups = append(groups, uflag.group)
+		}
+	}
+	return orderFlagGroups(groups)
+}
+
+// orderFlagGroups returns the unique groups in order of appearance,
+// except for the default group, which always comes first.
+func orderFlagGroups(groups []string) []string {
+	var output []string
+	for _, group := range groups {
+		if !slices.Contains(output, group) {
+			output = append(output, group)
+		}
+	}
+	if idx := slices.Index(output, ""); idx > 0 {
+		output = slices.Insert(slices.Delete(output, idx, idx+1), 0, "")
+	}
+	return output
 }
 
 // PrintUsageString implements [vflag.UsagePrinter].
 //
 // This method panics on I/O error.
 func (up *DefaultUsagePrinter) PrintUsageString(fset *FlagSet, w io.Writer) {
+	style := usageStyle{enabled: colorEnabled(up.Color, w)}
+
+	// Header
+	for _, entry := range fset.UsageHeader {
+		up.paragraph(w, entry)
+	}
+
 	// ## Usage
-	up.div0(w, "Usage")
-	up.div0(w, fmt.Sprintf("    %s%s%s", fset.ProgramName, up.flagsName(fset), up.positionalArgumentsUsage(fset)))
+	up.div0(w, style.heading("Usage"))
+	up.div0(w, fmt.Sprintf("%s%s%s%s", up.indent(), fset.ProgramName, up.flagsName(fset), up.positionalArgumentsUsage(fset)))
 
 	// ## Description
 	if description := up.Description; len(description) > 0 {
-		up.div0(w, "Description")
+		up.div0(w, style.heading("Description"))
 		for _, entry := range description {
-			up.div1(w, entry)
+			up.markupDiv1(w, style, entry)
+		}
+	}
+
+	// ## Arguments
+	if len(fset.PositionalArguments) > 0 {
+		up.div0(w, style.heading("Arguments"))
+		for idx, arg := range fset.PositionalArguments {
+			synopsis := arg.usageName()
+			if idx >= fset.MinPositionalArgs {
+				synopsis += " (optional)"
+			}
+			up.div1(w, synopsis)
+			for _, dentry := range arg.Description {
+				dentry = strings.ReplaceAll(dentry, "@DEFAULT_VALUE@", arg.Value.String())
+				up.div2(w, dentry)
+			}
 		}
 	}
 
@@ -194,61 +750,185 @@ func (up *DefaultUsagePrinter) PrintUsageString(fset *FlagSet, w io.Writer) {
 		for _, fx := range fset.ShortFlags {
 			var sb strings.Builder
 			for _, dentry := range fx.Description {
-				up.div0(&sb, textwrap.Do(dentry, wrapAtColumn, indent8))
+				up.div2(&sb, dentry)
 			}
 			description := sb.String()
-			description = strings.ReplaceAll(description, "@DEFAULT_VALUE@", fx.Value.String())
+			description = strings.ReplaceAll(description, "@DEFAULT_VALUE@", fx.docs().defaultValue())
+			var aliases []string
+			if fx.NegatedPrefix != "" {
+				aliases = append(aliases, fx.NegatedPrefix+string(fx.Name))
+			}
+			_, help := fx.Value.(ValueAutoHelp)
 			uflags = append(uflags, &usageFlag{
 				synopsis:    fx.Usage(),
+				aliases:     aliases,
 				description: description,
+				group:       fx.Group,
+				last:        help,
+				name:        string(fx.Name),
+				required:    fx.Required,
+				short:       fx.Name,
 			})
 		}
 
 		for _, fx := range fset.LongFlags {
 			var sb strings.Builder
 			for _, dentry := range fx.Description {
-				up.div0(&sb, textwrap.Do(dentry, wrapAtColumn, indent8))
+				up.div2(&sb, dentry)
 			}
 			description := sb.String()
-			description = strings.ReplaceAll(description, "@DEFAULT_VALUE@", fx.Value.String())
+			description = strings.ReplaceAll(description, "@DEFAULT_VALUE@", fx.docs().defaultValue())
+			var aliases []string
+			if !fx.HideAliases {
+				aliases = fx.AliasesUsage()
+			}
+			if fx.Negatable {
+				aliases = append(aliases, fx.Prefix+fx.NegatedName())
+			}
+			_, help := fx.Value.(ValueAutoHelp)
 			uflags = append(uflags, &usageFlag{
 				synopsis:    fx.Usage(),
+				aliases:     aliases,
 				description: description,
+				group:       fx.Group,
+				bindings:    fset.bindingsUsage(fx),
+				last:        help || fx.Name == "version",
+				long:        true,
+				name:        fx.Name,
+				required:    fx.Required,
+				short:       fx.ShortName,
 			})
 		}
 
-		// Map unique descriptions to usage flags
-		udescr := make(map[string]*usageFlag, len(uflags))
+		// Map unique descriptions and short flags to usage flags, such that we
+		// merge the long flags with their short form regardless of the description
+		var (
+			udescr = make(map[string]*usageFlag, len(uflags))
+			ushort = make(map[rune]*usageFlag, len(fset.ShortFlags))
+		)
 		for _, uflag := range uflags {
 			ref, ok := udescr[uflag.description]
+			if linked, found := ushort[uflag.short]; found && uflag.long {
+				ref, ok = linked, true
+			}
 			if !ok {
+				ref = uflag
 				udescr[uflag.description] = uflag
+			}
+			if !uflag.long {
+				ushort[uflag.short] = ref
+			}
+			if ref == uflag {
 				continue
 			}
 			ref.aliases = append(ref.aliases, uflag.synopsis)
+			ref.aliases = append(ref.aliases, uflag.aliases...)
+			if !ref.long && uflag.long {
+				ref.long, ref.name = true, uflag.name
+			}
+			if ref.bindings == "" {
+				ref.bindings = uflag.bindings
+			}
+			ref.last = ref.last || uflag.last
+			ref.required = ref.required || uflag.required
 			uflag.synopsis, uflag.description = "", ""
 		}
 
-		// Print the flags with non-empty descriptions
-		up.div0(w, "Flags")
-		for _, uflag := range uflags {
-			synopsisList := append([]string{uflag.synopsis}, uflag.aliases...)
-			if uflag.description == "" {
-				continue
+		// Sort the flags, if needed
+		if up.SortFlags || up.CompareFlags != nil {
+			compare := up.CompareFlags
+			if compare == nil {
+				compare = strings.Compare
+			}
+			slices.SortStableFunc(uflags, func(a, b *usageFlag) int {
+				switch {
+				case a.last && !b.last:
+					return 1
+				case !a.last && b.last:
+					return -1
+				default:
+					return compare(a.name, b.name)
+				}
+			})
+		}
+
+		// Print the flags with non-empty descriptions under their group heading
+		for _, group := range usageFlagGroups(uflags) {
+			heading := group
+			if heading == "" {
+				heading = "Flags"
+			}
+			up.div0(w, style.heading(heading))
+			for _, uflag := range uflags {
+				synopsisList := append([]string{uflag.synopsis}, uflag.aliases...)
+				if uflag.description == "" || uflag.group != group {
+					continue
+				}
+				annotations := uflag.bindings
+				if uflag.required {
+					annotations += " (required)"
+				}
+				for idx, synopsis := range synopsisList {
+					synopsisList[idx] = style.synopsis(synopsis)
+				}
+				up.div1(w, strings.Join(synopsisList, ", ")+annotations)
+				must.Fprintf(w, "%s", uflag.description)
 			}
-			up.div1(w, strings.Join(synopsisList, ", "))
-			must.Fprintf(w, "%s", uflag.description)
 		}
 	}
 
+	// ## Commands
+	if commands := up.Commands; len(commands) > 0 {
+		up.div0(w, style.heading("Commands"))
+		for _, cmd := range commands {
+			up.div1(w, cmd.Name)
+			if cmd.Summary != "" {
+				up.div2(w, cmd.Summary)
+			}
+		}
+	}
+
+	// ## Environment
+	up.docEntries(w, style.heading("Environment"), fset.Environment)
+
+	// ## Files
+	up.docEntries(w, style.heading("Files"), fset.Files)
+
 	// ## Example
 	if example := up.Example; len(example) > 0 {
-		up.div0(w, "Examples")
+		up.div0(w, style.heading("Examples"))
 		for _, entry := range example {
 			up.div1(w, entry)
 		}
 	}
 
+	// ## Authors
+	if len(fset.Authors) > 0 {
+		up.div0(w, style.heading("Authors"))
+		for _, entry := range fset.Authors {
+			up.div1(w, entry)
+		}
+	}
+
+	// ## Bugs
+	if len(fset.Bugs) > 0 {
+		up.div0(w, style.heading("Bugs"))
+		for _, entry := range fset.Bugs {
+			up.div1(w, entry)
+		}
+	}
+
+	// ## See Also
+	if len(fset.SeeAlso) > 0 {
+		up.div0(w, style.heading("See Also"))
+		up.div1(w, strings.Join(fset.SeeAlso, ", "))
+	}
+
+	// Footer
+	for _, entry := range fset.UsageFooter {
+		up.paragraph(w, entry)
+	}
+
 	must.Fprintf(w, "\n")
 }
 
@@ -257,18 +937,92 @@ func (up *DefaultUsagePrinter) PrintUsageString(fset *FlagSet, w io.Writer) {
 // This method panics on I/O error.
 func (up *DefaultUsagePrinter) PrintUsageError(fset *FlagSet, w io.Writer, err error) {
 	programName := fset.ProgramName
-	must.Fprintf(w, "%s: %s\n", programName, err.Error())
+	for line := range strings.SplitSeq(err.Error(), "\n") {
+		must.Fprintf(w, "%s: %s\n", programName, line)
+	}
 	if cmdline := fset.HelpInvocation(); cmdline != "" {
 		must.Fprintf(w, "%s: try `%s' for more help.\n", programName, cmdline)
 	}
 }
 
+// docEntries prints the given [*DocEntry] list under the given heading, if not empty.
+func (up *DefaultUsagePrinter) docEntries(w io.Writer, heading string, entries []*DocEntry) {
+	if len(entries) <= 0 {
+		return
+	}
+	up.div0(w, heading)
+	for _, entry := range entries {
+		up.div1(w, entry.Name)
+		for _, dentry := range entry.Description {
+			up.div2(w, dentry)
+		}
+	}
+}
+
+// markupDiv1 is like div1 but renders the lightweight markup of the
+// entry using the given style, unless it is a verbatim block.
+func (up *DefaultUsagePrinter) markupDiv1(w io.Writer, style usageStyle, entry string) {
+	if _, verbatim := verbatimLines(entry); verbatim {
+		up.div1(w, entry)
+		return
+	}
+	up.div1(w, style.markup(entry))
+}
+
+// verbatimLines returns the lines of a verbatim paragraph, which is a paragraph
+// starting with 4 spaces or a fenced block delimited by "```" lines. In the
+// latter case, we remove the fences and indent the lines by 4 spaces.
+func verbatimLines(entry string) ([]string, bool) {
+	switch {
+	case strings.HasPrefix(entry, indent4):
+		return strings.Split(entry, "\n"), true
+	case strings.HasPrefix(entry, "```"):
+		lines := strings.Split(strings.TrimSuffix(entry, "\n"), "\n")[1:]
+		if count := len(lines); count > 0 && strings.HasPrefix(lines[count-1], "```") {
+			lines = lines[:count-1]
+		}
+		for idx, line := range lines {
+			lines[idx] = indent4 + line
+		}
+		return lines, true
+	default:
+		return nil, false
+	}
+}
+
+// verbatim prints the verbatim lines adding the given indentation to each line.
+func (up *DefaultUsagePrinter) verbatim(w io.Writer, lines []string, indent string) {
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(indent+line, " ")
+	}
+	up.div0(w, strings.Join(lines, "\n"))
+}
+
+// paragraph prints a top-level paragraph, which is word wrapped unless it is verbatim.
+func (up *DefaultUsagePrinter) paragraph(w io.Writer, entry string) {
+	if lines, ok := verbatimLines(entry); ok {
+		up.verbatim(w, lines, "")
+		return
+	}
+	up.div0(w, wrapText(entry, up.wrapColumn(), ""))
+}
+
+// div2 prints a description paragraph nested within a section entry, which is word
+// wrapped at the descriptionIndent unless it is verbatim (see [verbatimLines]).
+func (up *DefaultUsagePrinter) div2(w io.Writer, entry string) {
+	if lines, ok := verbatimLines(entry); ok {
+		up.verbatim(w, lines, up.descriptionIndent())
+		return
+	}
+	up.div0(w, wrapText(entry, up.wrapColumn(), up.descriptionIndent()))
+}
+
 func (up *DefaultUsagePrinter) div1(w io.Writer, entry string) {
-	if strings.HasPrefix(entry, indent4) {
-		up.div0(w, indent4+entry)
+	if lines, ok := verbatimLines(entry); ok {
+		up.verbatim(w, lines, up.indent())
 		return
 	}
-	up.div0(w, textwrap.Do(entry, wrapAtColumn, indent4))
+	up.div0(w, wrapText(entry, up.wrapColumn(), up.indent()))
 }
 
 func (up *DefaultUsagePrinter) div0(w io.Writer, value string) {
@@ -3,8 +3,11 @@
 package vflag
 
 import (
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -86,3 +89,574 @@ func TestUsageFlagSetPositionalArgumentsUsage(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageLongFlagAliases(t *testing.T) {
+	newFlagSet := func() (*FlagSet, *LongFlag) {
+		var color string
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.StringVar(&color, 'c', "color", "Colorize the output.")
+		lf := fs.LongFlags[0]
+		lf.Aliases = []string{"colour"}
+		return fs, lf
+	}
+
+	t.Run("aliases are listed", func(t *testing.T) {
+		fs, _ := newFlagSet()
+		var buf strings.Builder
+		fs.PrintUsageString(&buf)
+		require.Contains(t, buf.String(), "\n    -c STRING, --color STRING, --colour STRING\n")
+	})
+
+	t.Run("aliases are hidden", func(t *testing.T) {
+		fs, lf := newFlagSet()
+		lf.HideAliases = true
+		var buf strings.Builder
+		fs.PrintUsageString(&buf)
+		require.Contains(t, buf.String(), "\n    -c STRING, --color STRING\n")
+	})
+}
+
+func TestUsageLongFlagNegatable(t *testing.T) {
+	fs := NewFlagSet("prog", ContinueOnError)
+	value := true
+	fs.NegatableBoolVar(&value, 'c', "color", "Colorize the output.")
+
+	var buf strings.Builder
+	fs.PrintUsageString(&buf)
+	require.Contains(t, buf.String(), "\n    -c, --color[=true|false], --no-color\n")
+}
+
+func TestUsageAutoHelpAliases(t *testing.T) {
+	fs := NewFlagSet("prog", ContinueOnError)
+	fx := NewLongFlagAutoHelp(ValueAutoHelp{}, "h", "Show this help message and exit.")
+	fx.Prefix = "-"
+	fx.Aliases = []string{"help"}
+	fs.AddLongFlag(fx)
+
+	var buf strings.Builder
+	fs.PrintUsageString(&buf)
+	require.Contains(t, buf.String(), "\n    -h, -help\n\n        Show this help message and exit.\n")
+
+	buf.Reset()
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	require.Equal(t, "  -h, -help\n    \tShow this help message and exit.\n", buf.String())
+
+	require.ErrorIs(t, fs.Parse([]string{"-help"}), ErrHelp)
+}
+
+func TestUsageAutoHelpShortName(t *testing.T) {
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.AddShortFlag(NewShortFlagAutoHelp(ValueAutoHelp{}, 'h', "Show this help message and exit."))
+	fx := NewLongFlagAutoHelp(ValueAutoHelp{}, "help", "Alias for -h.")
+	fx.ShortName = 'h'
+	fs.AddLongFlag(fx)
+
+	var buf strings.Builder
+	fs.PrintUsageString(&buf)
+	require.Contains(t, buf.String(), "\n    -h, --help\n\n        Show this help message and exit.\n")
+	require.NotContains(t, buf.String(), "Alias for -h.")
+
+	buf.Reset()
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	require.Equal(t, "  -h, --help\n    \tShow this help message and exit.\n", buf.String())
+
+	buf.Reset()
+	NewCompactUsagePrinter().PrintUsageString(fs, &buf)
+	require.Contains(t, buf.String(), "  -h, --help  Show this help message and exit.\n")
+
+	require.ErrorIs(t, fs.Parse([]string{"--help"}), ErrHelp)
+}
+
+func TestFlagSetPrintDefaults(t *testing.T) {
+	fs := NewFlagSet("prog", ContinueOnError)
+	var (
+		count   int
+		output  = "-"
+		retries = 3
+		verbose bool
+	)
+	fs.IntVar(&count, 'n', "count", "Set count.")
+	fs.StringVar(&output, 'o', "output", "Write output to the", "given file.")
+	fs.IntVar(&retries, 0, "retries", "Set retries (default: @DEFAULT_VALUE@).")
+	fs.BoolVar(&verbose, 'v', "", "Enable verbose output.")
+	fs.NegatableBoolVar(&verbose, 0, "color", "")
+
+	var buf strings.Builder
+	fs.SetOutput(&buf)
+	require.Equal(t, &buf, fs.Output())
+	fs.PrintDefaults()
+
+	expect := "" +
+		"  -n INT, --count INT\n" +
+		"    \tSet count.\n" +
+		"  -o STRING, --output STRING\n" +
+		"    \tWrite output to the given file. (default: -)\n" +
+		"  -v\n" +
+		"    \tEnable verbose output.\n" +
+		"  --retries INT\n" +
+		"    \tSet retries (default: 3).\n" +
+		"  --color[=true|false], --no-color\n"
+	require.Equal(t, expect, buf.String())
+}
+
+func TestFlagSetHideDefault(t *testing.T) {
+	var (
+		output = "-"
+		token  = "s3cr3t"
+	)
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+	fs.StringVar(&token, 0, "token", "Use the given API token.")
+	fs.HideDefault("token")
+
+	var buf strings.Builder
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	assert.Contains(t, buf.String(), "Write output to FILE. (default: -)\n")
+	assert.Contains(t, buf.String(), "Use the given API token.\n")
+	assert.NotContains(t, buf.String(), "s3cr3t")
+
+	data, err := fs.MarshalSpec()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+
+	assert.Panics(t, func() { fs.HideDefault("quiet") })
+}
+
+func TestFlagSetSetDefaultText(t *testing.T) {
+	var (
+		config = "/home/user/.config/prog"
+		jobs   = 0
+	)
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.StringVar(&config, 'c', "config", "Read the config from DIR.")
+	fs.IntVar(&jobs, 'j', "jobs", "Run @DEFAULT_VALUE@ jobs in parallel.")
+	fs.SetDefaultText("config", "$HOME/.config/prog")
+	fs.SetDefaultText("j", "autodetected")
+
+	var buf strings.Builder
+	fs.SetOutput(&buf)
+	fs.PrintDefaults()
+	assert.Contains(t, buf.String(), "Read the config from DIR. (default: $HOME/.config/prog)\n")
+	assert.Contains(t, buf.String(), "Run autodetected jobs in parallel.\n")
+	assert.NotContains(t, buf.String(), "/home/user")
+
+	buf.Reset()
+	fs.PrintUsageString(&buf)
+	assert.Contains(t, buf.String(), "Run autodetected jobs in parallel.")
+
+	data, err := fs.MarshalSpec()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"default":"$HOME/.config/prog"`)
+
+	assert.Panics(t, func() { fs.SetDefaultText("quiet", "") })
+}
+
+func TestUsagePositionalArguments(t *testing.T) {
+	var (
+		host string
+		port = 22
+	)
+	fs := NewFlagSet("connect", ContinueOnError)
+	fs.PositionalStringVar(&host, "HOST", "The server host.")
+	fs.PositionalIntVar(&port, "PORT", "The server port (default: @DEFAULT_VALUE@).")
+	fs.SetMinMaxPositionalArgs(1, 2)
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+
+	expect := strings.Join([]string{
+		"",
+		"Usage",
+		"",
+		"    connect HOST [PORT]",
+		"",
+		"Arguments",
+		"",
+		"    HOST",
+		"",
+		"        The server host.",
+		"",
+		"    PORT (optional)",
+		"",
+		"        The server port (default: 22).",
+		"",
+		"",
+	}, "\n")
+	require.Equal(t, expect, sb.String())
+
+	t.Run("additional arguments", func(t *testing.T) {
+		fs.SetMinMaxPositionalArgs(2, 4)
+		usage := NewDefaultUsagePrinter()
+		require.Equal(t, " HOST PORT [arg ...]", usage.positionalArgumentsUsage(fs))
+	})
+}
+
+func TestUsageFlagGroups(t *testing.T) {
+	var (
+		format  string
+		output  string
+		quiet   bool
+		verbose bool
+	)
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.Group("Output options").StringVar(&output, 'o', "output", "Write output to FILE.")
+	fs.StringVar(&format, 0, "format", "Use the given FORMAT.")
+	fs.Group("").BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+	fs.Group("Logging options").BoolVar(&quiet, 0, "quiet", "Suppress output.")
+
+	assert.Equal(t, "Output options", fs.ShortFlags[0].Group)
+	assert.Equal(t, "Output options", fs.LongFlags[1].Group)
+	assert.Equal(t, "", fs.LongFlags[2].Group)
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+
+	expect := strings.Join([]string{
+		"",
+		"Usage",
+		"",
+		"    prog [flags]",
+		"",
+		"Flags",
+		"",
+		"    -v, --verbose[=true|false]",
+		"",
+		"        Enable verbose output.",
+		"",
+		"Output options",
+		"",
+		"    -o STRING, --output STRING",
+		"",
+		"        Write output to FILE.",
+		"",
+		"    --format STRING",
+		"",
+		"        Use the given FORMAT.",
+		"",
+		"Logging options",
+		"",
+		"    --quiet[=true|false]",
+		"",
+		"        Suppress output.",
+		"",
+		"",
+	}, "\n")
+	require.Equal(t, expect, sb.String())
+}
+
+func TestUsageSortFlags(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			all     bool
+			output  string
+			quiet   bool
+			verbose bool
+			version bool
+		)
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.AutoHelp('h', "help", "Show this help message and exit.")
+		fs.BoolVar(&version, 0, "version", "Show the version and exit.")
+		fs.BoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		fs.BoolVar(&quiet, 'q', "", "Suppress output.")
+		fs.BoolVar(&all, 'a', "all", "Process all the files.")
+		return fs
+	}
+
+	// flagsOrder returns the first line of each flag entry.
+	flagsOrder := func(fs *FlagSet) (output []string) {
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		for line := range strings.SplitSeq(sb.String(), "\n") {
+			if strings.HasPrefix(line, "    -") {
+				output = append(output, strings.Fields(line)[0])
+			}
+		}
+		return
+	}
+
+	t.Run("declaration order", func(t *testing.T) {
+		fs := newFlagSet()
+		assert.Equal(t, []string{"-h,", "-v,", "-o", "-q", "-a,", "--version[=true|false]"}, flagsOrder(fs))
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+		fs := newFlagSet()
+		up := NewDefaultUsagePrinter()
+		up.SortFlags = true
+		fs.UsagePrinter = up
+		assert.Equal(t, []string{"-a,", "-o", "-q", "-v,", "-h,", "--version[=true|false]"}, flagsOrder(fs))
+	})
+
+	t.Run("custom comparator", func(t *testing.T) {
+		fs := newFlagSet()
+		up := NewDefaultUsagePrinter()
+		up.CompareFlags = func(a, b string) int {
+			return strings.Compare(b, a)
+		}
+		fs.UsagePrinter = up
+		assert.Equal(t, []string{"-v,", "-q", "-o", "-a,", "--version[=true|false]", "-h,"}, flagsOrder(fs))
+	})
+}
+
+func TestUsageColor(t *testing.T) {
+	newFlagSet := func(color bool) *FlagSet {
+		var output string
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.StringVar(&output, 'o', "output", "Write output to FILE.")
+		up := NewDefaultUsagePrinter()
+		up.Color = color
+		fs.UsagePrinter = up
+		return fs
+	}
+
+	usageString := func(fs *FlagSet) string {
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		return sb.String()
+	}
+
+	// pretend that any writer is a terminal
+	savedIsTerminal := isTerminal
+	isTerminal = func(w io.Writer) bool { return true }
+	t.Cleanup(func() { isTerminal = savedIsTerminal })
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		output := usageString(newFlagSet(true))
+		assert.Contains(t, output, "\n\x1b[4mFlags\x1b[0m\n")
+		assert.Contains(t, output, "\n    \x1b[1m-o\x1b[0m\x1b[2m STRING\x1b[0m, \x1b[1m--output\x1b[0m\x1b[2m STRING\x1b[0m\n")
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		assert.NotContains(t, usageString(newFlagSet(false)), "\x1b[")
+	})
+
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assert.NotContains(t, usageString(newFlagSet(true)), "\x1b[")
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		isTerminal = savedIsTerminal
+		assert.NotContains(t, usageString(newFlagSet(true)), "\x1b[")
+	})
+}
+
+func TestUsageLayout(t *testing.T) {
+	var output string
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.StringVar(&output, 'o', "output", "Write the output to the given FILE, creating it if needed.")
+	up := NewDefaultUsagePrinter()
+	up.AddDescription("A program with a long description used to check word wrapping.")
+	up.WrapAtColumn = 40
+	up.Indent = 2
+	up.DescriptionIndent = 4
+	fs.UsagePrinter = up
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+
+	expect := strings.Join([]string{
+		"",
+		"Usage",
+		"",
+		"  prog [flags]",
+		"",
+		"Description",
+		"",
+		"  A program with a long description used",
+		"  to check word wrapping.",
+		"",
+		"Flags",
+		"",
+		"  -o STRING, --output STRING",
+		"",
+		"    Write the output to the given FILE,",
+		"    creating it if needed.",
+		"",
+		"",
+	}, "\n")
+	assert.Equal(t, expect, sb.String())
+}
+
+func TestUsageHeaderFooter(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.UsageHeader = []string{"prog 1.0 - Copyright (C) 2025 The Authors."}
+		fs.UsageFooter = []string{"Report bugs at https://example.com/issues."}
+		return fs
+	}
+
+	t.Run("default printer", func(t *testing.T) {
+		var sb strings.Builder
+		newFlagSet().PrintUsageString(&sb)
+		expect := strings.Join([]string{
+			"",
+			"prog 1.0 - Copyright (C) 2025 The Authors.",
+			"",
+			"Usage",
+			"",
+			"    prog",
+			"",
+			"Report bugs at https://example.com/issues.",
+			"",
+			"",
+		}, "\n")
+		assert.Equal(t, expect, sb.String())
+	})
+
+	t.Run("compact printer", func(t *testing.T) {
+		fs := newFlagSet()
+		fs.UsagePrinter = NewCompactUsagePrinter()
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		expect := strings.Join([]string{
+			"prog 1.0 - Copyright (C) 2025 The Authors.",
+			"",
+			"Usage: prog",
+			"",
+			"Report bugs at https://example.com/issues.",
+			"",
+		}, "\n")
+		assert.Equal(t, expect, sb.String())
+	})
+}
+
+func TestUsageDescriptionMarkup(t *testing.T) {
+	newFlagSet := func(color bool) *FlagSet {
+		fs := NewFlagSet("prog", ContinueOnError)
+		up := NewDefaultUsagePrinter()
+		up.Color = color
+		up.AddDescription("Run `prog --fast` to go *really* fast, or 2 * 3 times faster.", "    `verbatim` *text*")
+		fs.UsagePrinter = up
+		return fs
+	}
+
+	usageString := func(fs *FlagSet) string {
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		return sb.String()
+	}
+
+	t.Run("stripped", func(t *testing.T) {
+		output := usageString(newFlagSet(false))
+		assert.Contains(t, output, "\n    Run prog --fast to go really fast, or 2 * 3 times faster.\n")
+		assert.Contains(t, output, "\n        `verbatim` *text*\n")
+	})
+
+	t.Run("colored", func(t *testing.T) {
+		savedIsTerminal := isTerminal
+		isTerminal = func(w io.Writer) bool { return true }
+		t.Cleanup(func() { isTerminal = savedIsTerminal })
+		t.Setenv("NO_COLOR", "")
+
+		output := usageString(newFlagSet(true))
+		assert.Contains(t, output, "\n    Run \x1b[1mprog --fast\x1b[0m to go \x1b[4mreally\x1b[0m fast, or 2 * 3 times faster.\n")
+		assert.Contains(t, output, "\n        `verbatim` *text*\n")
+	})
+
+	t.Run("man page", func(t *testing.T) {
+		var sb strings.Builder
+		GenManPage(newFlagSet(false), 1, &sb)
+		assert.Contains(t, sb.String(), "prog \\- Run prog \\-\\-fast to go really fast, or 2 * 3 times faster.\n")
+		assert.Contains(t, sb.String(), ".PP\nRun \\fBprog \\-\\-fast\\fR to go \\fIreally\\fR fast, or 2 * 3 times faster.\n")
+	})
+}
+
+func TestUsageVerbatimBlocks(t *testing.T) {
+	var filter string
+	fs := NewFlagSet("prog", ContinueOnError)
+	fs.StringVar(&filter, 'f', "filter", "Filter the records using the given `EXPR`, for example:",
+		"    prog -f 'name == \"foo\" && size > 1024'",
+		"```\nprog --filter 'a'\n\nprog --filter 'b'\n```")
+	up := NewDefaultUsagePrinter()
+	up.AddDescription("```\n$ prog\nok\n```")
+	fs.UsagePrinter = up
+
+	var sb strings.Builder
+	fs.PrintUsageString(&sb)
+
+	expect := strings.Join([]string{
+		"",
+		"Usage",
+		"",
+		"    prog [flags]",
+		"",
+		"Description",
+		"",
+		"        $ prog",
+		"        ok",
+		"",
+		"Flags",
+		"",
+		"    -f EXPR, --filter EXPR",
+		"",
+		"        Filter the records using the given `EXPR`, for example:",
+		"",
+		"            prog -f 'name == \"foo\" && size > 1024'",
+		"",
+		"            prog --filter 'a'",
+		"",
+		"            prog --filter 'b'",
+		"",
+		"",
+	}, "\n")
+	assert.Equal(t, expect, sb.String())
+}
+
+func TestUsageGenerateSynopsis(t *testing.T) {
+	newFlagSet := func() *FlagSet {
+		var (
+			fail, silent, location, verbose bool
+			output, url                     string
+			retry                           int
+		)
+		fs := NewFlagSet("curl", ContinueOnError)
+		fs.BoolVar(&fail, 'f', "fail", "Fail on HTTP errors.")
+		fs.BoolVar(&silent, 's', "silent", "Do not emit output.")
+		fs.BoolVar(&location, 'L', "location", "Follow redirects.")
+		fs.StringVar(&output, 'o', "output", "Write output to `FILE`.")
+		fs.IntVar(&retry, 0, "retry", "Retry the given number of times.")
+		fs.BoolVar(&verbose, 0, "verbose", "Emit verbose output.")
+		fs.StringVar(&url, 0, "url", "Fetch the given `URL`.")
+		fs.MarkRequired("url")
+		up := NewDefaultUsagePrinter()
+		up.GenerateSynopsis = true
+		fs.UsagePrinter = up
+		return fs
+	}
+
+	t.Run("generated synopsis", func(t *testing.T) {
+		var sb strings.Builder
+		newFlagSet().PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "\n    curl [-fsL] [-o FILE] [--retry INT] [--verbose] --url URL\n")
+	})
+
+	t.Run("fallback when too long", func(t *testing.T) {
+		fs := newFlagSet()
+		fs.UsagePrinter.(*DefaultUsagePrinter).WrapAtColumn = 40
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "\n    curl [flags] --url URL\n")
+	})
+
+	t.Run("non-comparable values", func(t *testing.T) {
+		var color string
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.EnumVar(&color, []string{"red", "blue"}, 'c', "color", "Set the color.")
+		fs.Func('n', "name", "Set the name.", func(string) error { return nil })
+		fs.MarkRequired("name")
+		up := NewDefaultUsagePrinter()
+		up.GenerateSynopsis = true
+		fs.UsagePrinter = up
+		var sb strings.Builder
+		fs.PrintUsageString(&sb)
+		assert.Contains(t, sb.String(), "\n    prog [-c red|blue] --name VALUE\n")
+	})
+}
@@ -7,10 +7,25 @@
 package vflag
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"math/big"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/bassosimone/runtimex"
 )
 
 // Value represents a writable flag value.
@@ -28,9 +43,24 @@ type Value interface {
 	Set(value string) error
 }
 
+// HelpLevel is the level of detail of the help requested using a [ValueAutoHelp] flag.
+type HelpLevel int
+
+// These constants define the allowed [HelpLevel] values.
+const (
+	// HelpFull prints the full help using the [UsagePrinter].
+	HelpFull = HelpLevel(iota)
+
+	// HelpShort prints the terse help using [*FlagSet.PrintShortUsage].
+	HelpShort
+)
+
 // ValueAutoHelp is a sentinel value associated with the user
 // requesting for help using the command line.
-type ValueAutoHelp struct{}
+type ValueAutoHelp struct {
+	// Level is the [HelpLevel] to print, which is [HelpFull] by default.
+	Level HelpLevel
+}
 
 var _ Value = ValueAutoHelp{}
 
@@ -39,7 +69,7 @@ func (v ValueAutoHelp) Set(value string) error {
 	if value == "" {
 		value = "true"
 	}
-	_, err := strconv.ParseBool(value)
+	_, err := parseBool(value)
 	return err
 }
 
@@ -48,8 +78,73 @@ func (v ValueAutoHelp) String() string {
 	return "false"
 }
 
+// ValueBigFloat implements [Value] for [big.Float].
+//
+// Parsing uses the precision and rounding mode of the underlying [big.Float],
+// so set them before parsing to avoid losing precision. A zero precision
+// means the value is parsed using 64 bits of precision.
+//
+// Construct using [NewValueBigFloat].
+type ValueBigFloat struct {
+	vp *big.Float
+}
+
+// NewValueBigFloat constructs a new [ValueBigFloat] using an underlying [big.Float].
+func NewValueBigFloat(vp *big.Float) ValueBigFloat {
+	return ValueBigFloat{vp}
+}
+
+var _ Value = ValueBigFloat{}
+
+// Set implements [Value].
+func (v ValueBigFloat) Set(value string) error {
+	parsed, _, err := big.ParseFloat(value, 10, v.vp.Prec(), v.vp.Mode())
+	if err != nil {
+		return err
+	}
+	v.vp.Set(parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueBigFloat) String() string {
+	return v.vp.Text('g', -1)
+}
+
+// ValueBigInt implements [Value] for [big.Int].
+//
+// Construct using [NewValueBigInt].
+type ValueBigInt struct {
+	vp *big.Int
+}
+
+// NewValueBigInt constructs a new [ValueBigInt] using an underlying [big.Int].
+func NewValueBigInt(vp *big.Int) ValueBigInt {
+	return ValueBigInt{vp}
+}
+
+var _ Value = ValueBigInt{}
+
+// Set implements [Value].
+func (v ValueBigInt) Set(value string) error {
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return fmt.Errorf("invalid integer: %q", value)
+	}
+	v.vp.Set(parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueBigInt) String() string {
+	return v.vp.String()
+}
+
 // ValueBool implements [Value] for bool.
 //
+// Besides the values accepted by [strconv.ParseBool], this type accepts
+// `yes`, `no`, `on`, `off`, `y`, and `n`, regardless of their case.
+//
 // Construct using [NewValueBool].
 type ValueBool struct {
 	vp *bool
@@ -67,7 +162,7 @@ func (v ValueBool) Set(value string) error {
 	if value == "" {
 		value = "true"
 	}
-	parsed, err := strconv.ParseBool(value)
+	parsed, err := parseBool(value)
 	if err != nil {
 		return err
 	}
@@ -80,6 +175,189 @@ func (v ValueBool) String() string {
 	return strconv.FormatBool(*v.vp)
 }
 
+// parseBool is like [strconv.ParseBool] but also accepts `yes`, `no`,
+// `on`, `off`, `y`, and `n`, regardless of their case.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "on":
+		return true, nil
+	case "no", "n", "off":
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean value: %w", err)
+	}
+	return parsed, nil
+}
+
+// ValueBoolFunc implements [Value] by invoking a function for each
+// occurrence of a boolean-style flag.
+//
+// Each [ValueBoolFunc.Set] with an empty value invokes the function
+// with `true`, which is what happens when the flag has no value.
+//
+// Construct using [NewValueBoolFunc].
+type ValueBoolFunc struct {
+	fn func(string) error
+}
+
+// NewValueBoolFunc constructs a new [ValueBoolFunc] using the given function.
+func NewValueBoolFunc(fn func(string) error) ValueBoolFunc {
+	return ValueBoolFunc{fn}
+}
+
+var _ Value = ValueBoolFunc{}
+
+// Set implements [Value].
+func (v ValueBoolFunc) Set(value string) error {
+	if value == "" {
+		value = "true"
+	}
+	return v.fn(value)
+}
+
+// String implements [fmt.Stringer].
+//
+// This method always returns an empty string since there is no underlying value.
+func (v ValueBoolFunc) String() string {
+	return ""
+}
+
+// ValueBoolPtr implements [Value] for a *bool that is nil until set.
+//
+// This enables tri-state flags distinguishing `--feature=true`,
+// `--feature=false`, and the flag not being provided at all.
+//
+// Construct using [NewValueBoolPtr].
+type ValueBoolPtr struct {
+	vp **bool
+}
+
+// NewValueBoolPtr constructs a new [ValueBoolPtr] using an underlying *bool.
+func NewValueBoolPtr(vp **bool) ValueBoolPtr {
+	return ValueBoolPtr{vp}
+}
+
+var _ Value = ValueBoolPtr{}
+
+// Set implements [Value].
+func (v ValueBoolPtr) Set(value string) error {
+	if value == "" {
+		value = "true"
+	}
+	parsed, err := parseBool(value)
+	if err != nil {
+		return err
+	}
+	*v.vp = &parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+//
+// A nil *bool is represented as the empty string.
+func (v ValueBoolPtr) String() string {
+	if *v.vp == nil {
+		return ""
+	}
+	return strconv.FormatBool(**v.vp)
+}
+
+// ValueBytesBase64 implements [Value] for a byte slice using the standard base64 encoding defined in RFC 4648.
+//
+// Construct using [NewValueBytesBase64].
+type ValueBytesBase64 struct {
+	vp *[]byte
+}
+
+// NewValueBytesBase64 constructs a new [ValueBytesBase64] using an underlying byte slice.
+func NewValueBytesBase64(vp *[]byte) ValueBytesBase64 {
+	return ValueBytesBase64{vp}
+}
+
+var _ Value = ValueBytesBase64{}
+
+// Set implements [Value].
+func (v ValueBytesBase64) Set(value string) error {
+	parsed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return err
+	}
+	*v.vp = parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueBytesBase64) String() string {
+	return base64.StdEncoding.EncodeToString(*v.vp)
+}
+
+// ValueBytesHex implements [Value] for a byte slice using hexadecimal encoding.
+//
+// Construct using [NewValueBytesHex].
+type ValueBytesHex struct {
+	vp *[]byte
+}
+
+// NewValueBytesHex constructs a new [ValueBytesHex] using an underlying byte slice.
+func NewValueBytesHex(vp *[]byte) ValueBytesHex {
+	return ValueBytesHex{vp}
+}
+
+var _ Value = ValueBytesHex{}
+
+// Set implements [Value].
+func (v ValueBytesHex) Set(value string) error {
+	parsed, err := hex.DecodeString(value)
+	if err != nil {
+		return err
+	}
+	*v.vp = parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueBytesHex) String() string {
+	return hex.EncodeToString(*v.vp)
+}
+
+// ValueCount implements [Value] for an int counting occurrences.
+//
+// Each [ValueCount.Set] with an empty value increments the counter, such that
+// `-vvv` yields 3. A non-empty value (e.g., `--verbose=3`) sets the counter.
+//
+// Construct using [NewValueCount].
+type ValueCount struct {
+	vp *int
+}
+
+// NewValueCount constructs a new [ValueCount] using an underlying int.
+func NewValueCount(vp *int) ValueCount {
+	return ValueCount{vp}
+}
+
+var _ Value = ValueCount{}
+
+// Set implements [Value].
+func (v ValueCount) Set(value string) error {
+	if value == "" {
+		*v.vp++
+		return nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*v.vp = int(parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueCount) String() string {
+	return strconv.FormatInt(int64(*v.vp), 10)
+}
+
 // ValueDuration implements [Value] for [time.Duration].
 //
 // Construct using [NewValueDuration].
@@ -109,6 +387,163 @@ func (v ValueDuration) String() string {
 	return v.vp.String()
 }
 
+// ValueDurationSlice implements [Value] for a [time.Duration] slice.
+//
+// Each [ValueDurationSlice.Set] parses the value and appends it to the slice.
+//
+// Construct using [NewValueDurationSlice].
+type ValueDurationSlice struct {
+	vp *[]time.Duration
+}
+
+// NewValueDurationSlice constructs a new [ValueDurationSlice] using an underlying [time.Duration] slice.
+func NewValueDurationSlice(vp *[]time.Duration) ValueDurationSlice {
+	return ValueDurationSlice{vp}
+}
+
+var _ Value = ValueDurationSlice{}
+
+// Set implements [Value].
+func (v ValueDurationSlice) Set(value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*v.vp = append(*v.vp, parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueDurationSlice) String() string {
+	entries := make([]string, 0, len(*v.vp))
+	for _, entry := range *v.vp {
+		entries = append(entries, entry.String())
+	}
+	return strings.Join(entries, ",")
+}
+
+// ValueEnum implements [Value] for a string restricted to a set of choices.
+//
+// Construct using [NewValueEnum].
+type ValueEnum struct {
+	choices []string
+	vp      *string
+}
+
+// NewValueEnum constructs a new [ValueEnum] using an underlying string and
+// the given allowed choices (e.g., `"json", "yaml", "table"`).
+func NewValueEnum(vp *string, choices ...string) ValueEnum {
+	return ValueEnum{choices: choices, vp: vp}
+}
+
+var _ Value = ValueEnum{}
+
+// Choices returns the allowed choices.
+func (v ValueEnum) Choices() []string {
+	return v.choices
+}
+
+// Set implements [Value].
+func (v ValueEnum) Set(value string) error {
+	if !slices.Contains(v.choices, value) {
+		return fmt.Errorf("invalid value %q: must be one of: %s", value, strings.Join(v.choices, ", "))
+	}
+	*v.vp = value
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueEnum) String() string {
+	return *v.vp
+}
+
+// ValueFileMode implements [Value] for [os.FileMode].
+//
+// Values use the octal notation of chmod(1), such as `0644` or `755`. The
+// setuid (`04000`), setgid (`02000`), and sticky (`01000`) bits map to the
+// corresponding [os.FileMode] bits.
+//
+// Construct using [NewValueFileMode].
+type ValueFileMode struct {
+	vp *os.FileMode
+}
+
+// NewValueFileMode constructs a new [ValueFileMode] using an underlying [os.FileMode].
+func NewValueFileMode(vp *os.FileMode) ValueFileMode {
+	return ValueFileMode{vp}
+}
+
+var _ Value = ValueFileMode{}
+
+// fileModeSpecialBits maps the chmod(1) special bits to [os.FileMode] bits.
+var fileModeSpecialBits = []struct {
+	octal uint64
+	mode  os.FileMode
+}{
+	{04000, os.ModeSetuid},
+	{02000, os.ModeSetgid},
+	{01000, os.ModeSticky},
+}
+
+// Set implements [Value].
+func (v ValueFileMode) Set(value string) error {
+	parsed, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return err
+	}
+	if parsed > 07777 {
+		return fmt.Errorf("invalid file mode %q: must be at most 07777", value)
+	}
+	mode := os.FileMode(parsed) & os.ModePerm
+	for _, entry := range fileModeSpecialBits {
+		if parsed&entry.octal != 0 {
+			mode |= entry.mode
+		}
+	}
+	*v.vp = mode
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueFileMode) String() string {
+	octal := uint64(*v.vp & os.ModePerm)
+	for _, entry := range fileModeSpecialBits {
+		if *v.vp&entry.mode != 0 {
+			octal |= entry.octal
+		}
+	}
+	return fmt.Sprintf("%#o", octal)
+}
+
+// ValueFloat32 implements [Value] for float32.
+//
+// Construct using [NewValueFloat32].
+type ValueFloat32 struct {
+	vp *float32
+}
+
+// NewValueFloat32 constructs a new [ValueFloat32] using an underlying float32.
+func NewValueFloat32(vp *float32) ValueFloat32 {
+	return ValueFloat32{vp}
+}
+
+var _ Value = ValueFloat32{}
+
+// Set implements [Value].
+func (v ValueFloat32) Set(value string) error {
+	parsed, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return err
+	}
+	*v.vp = float32(parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueFloat32) String() string {
+	return strconv.FormatFloat(float64(*v.vp), 'g', -1, 32)
+}
+
 // ValueFloat64 implements [Value] for float64.
 //
 // Construct using [NewValueFloat64].
@@ -138,33 +573,161 @@ func (v ValueFloat64) String() string {
 	return strconv.FormatFloat(*v.vp, 'g', -1, 64)
 }
 
-// ValueInt implements [Value] for int.
+// ValueFloat64Slice implements [Value] for a float64 slice.
+//
+// Each [ValueFloat64Slice.Set] parses the value and appends it to the slice.
+//
+// Construct using [NewValueFloat64Slice].
+type ValueFloat64Slice struct {
+	vp *[]float64
+}
+
+// NewValueFloat64Slice constructs a new [ValueFloat64Slice] using an underlying float64 slice.
+func NewValueFloat64Slice(vp *[]float64) ValueFloat64Slice {
+	return ValueFloat64Slice{vp}
+}
+
+var _ Value = ValueFloat64Slice{}
+
+// Set implements [Value].
+func (v ValueFloat64Slice) Set(value string) error {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*v.vp = append(*v.vp, parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueFloat64Slice) String() string {
+	entries := make([]string, 0, len(*v.vp))
+	for _, entry := range *v.vp {
+		entries = append(entries, strconv.FormatFloat(entry, 'g', -1, 64))
+	}
+	return strings.Join(entries, ",")
+}
+
+// ValueFunc implements [Value] by invoking a function for each value.
+//
+// Construct using [NewValueFunc].
+type ValueFunc struct {
+	fn func(string) error
+}
+
+// NewValueFunc constructs a new [ValueFunc] using the given function.
+func NewValueFunc(fn func(string) error) ValueFunc {
+	return ValueFunc{fn}
+}
+
+var _ Value = ValueFunc{}
+
+// Set implements [Value].
+func (v ValueFunc) Set(value string) error {
+	return v.fn(value)
+}
+
+// String implements [fmt.Stringer].
+//
+// This method always returns an empty string since there is no underlying value.
+func (v ValueFunc) String() string {
+	return ""
+}
+
+// ValueGlob implements [Value] for a slice of glob patterns.
+//
+// Each [ValueGlob.Set] validates the pattern using the [filepath.Match]
+// syntax and appends it to the slice. Use [filepath.Match] to match
+// names against the collected patterns.
+//
+// Construct using [NewValueGlob].
+type ValueGlob struct {
+	vp *[]string
+}
+
+// NewValueGlob constructs a new [ValueGlob] using an underlying string slice.
+func NewValueGlob(vp *[]string) ValueGlob {
+	return ValueGlob{vp}
+}
+
+var _ Value = ValueGlob{}
+
+// Set implements [Value].
+func (v ValueGlob) Set(value string) error {
+	if _, err := filepath.Match(value, ""); err != nil {
+		return fmt.Errorf("invalid glob pattern %q: %w", value, err)
+	}
+	*v.vp = append(*v.vp, value)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueGlob) String() string {
+	return strings.Join(*v.vp, ",")
+}
+
+// ValueInt implements [Value] for int.
+//
+// Construct using [NewValueInt].
+type ValueInt struct {
+	vp *int
+}
+
+// NewValueInt constructs a new [ValueInt] using an underlying int.
+func NewValueInt(vp *int) ValueInt {
+	return ValueInt{vp}
+}
+
+var _ Value = ValueInt{}
+
+// Set implements [Value].
+func (v ValueInt) Set(value string) error {
+	parsed, err := strconv.ParseInt(value, 10, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*v.vp = int(parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueInt) String() string {
+	return strconv.FormatInt(int64(*v.vp), 10)
+}
+
+// ValueIntSlice implements [Value] for a int slice.
 //
-// Construct using [NewValueInt].
-type ValueInt struct {
-	vp *int
+// Each [ValueIntSlice.Set] parses the value and appends it to the slice.
+//
+// Construct using [NewValueIntSlice].
+type ValueIntSlice struct {
+	vp *[]int
 }
 
-// NewValueInt constructs a new [ValueInt] using an underlying int.
-func NewValueInt(vp *int) ValueInt {
-	return ValueInt{vp}
+// NewValueIntSlice constructs a new [ValueIntSlice] using an underlying int slice.
+func NewValueIntSlice(vp *[]int) ValueIntSlice {
+	return ValueIntSlice{vp}
 }
 
-var _ Value = ValueInt{}
+var _ Value = ValueIntSlice{}
 
 // Set implements [Value].
-func (v ValueInt) Set(value string) error {
+func (v ValueIntSlice) Set(value string) error {
 	parsed, err := strconv.ParseInt(value, 10, strconv.IntSize)
 	if err != nil {
 		return err
 	}
-	*v.vp = int(parsed)
+	*v.vp = append(*v.vp, int(parsed))
 	return nil
 }
 
 // String implements [fmt.Stringer].
-func (v ValueInt) String() string {
-	return strconv.FormatInt(int64(*v.vp), 10)
+func (v ValueIntSlice) String() string {
+	entries := make([]string, 0, len(*v.vp))
+	for _, entry := range *v.vp {
+		entries = append(entries, strconv.FormatInt(int64(entry), 10))
+	}
+	return strings.Join(entries, ",")
 }
 
 // ValueInt8 implements [Value] for int8.
@@ -283,6 +846,417 @@ func (v ValueInt64) String() string {
 	return strconv.FormatInt(*v.vp, 10)
 }
 
+// ValueInt64Slice implements [Value] for a int64 slice.
+//
+// Each [ValueInt64Slice.Set] parses the value and appends it to the slice.
+//
+// Construct using [NewValueInt64Slice].
+type ValueInt64Slice struct {
+	vp *[]int64
+}
+
+// NewValueInt64Slice constructs a new [ValueInt64Slice] using an underlying int64 slice.
+func NewValueInt64Slice(vp *[]int64) ValueInt64Slice {
+	return ValueInt64Slice{vp}
+}
+
+var _ Value = ValueInt64Slice{}
+
+// Set implements [Value].
+func (v ValueInt64Slice) Set(value string) error {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*v.vp = append(*v.vp, parsed)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueInt64Slice) String() string {
+	entries := make([]string, 0, len(*v.vp))
+	for _, entry := range *v.vp {
+		entries = append(entries, strconv.FormatInt(entry, 10))
+	}
+	return strings.Join(entries, ",")
+}
+
+// ValueIPSlice implements [Value] for a [netip.Addr] slice.
+//
+// Each [ValueIPSlice.Set] parses a comma-separated list of addresses
+// and appends them to the slice, such that `--dns-server 8.8.8.8,1.1.1.1`
+// is equivalent to `--dns-server 8.8.8.8 --dns-server 1.1.1.1`. The slice
+// is not modified unless all the addresses are valid.
+//
+// Construct using [NewValueIPSlice].
+type ValueIPSlice struct {
+	vp *[]netip.Addr
+}
+
+// NewValueIPSlice constructs a new [ValueIPSlice] using an underlying [netip.Addr] slice.
+func NewValueIPSlice(vp *[]netip.Addr) ValueIPSlice {
+	return ValueIPSlice{vp}
+}
+
+var _ Value = ValueIPSlice{}
+
+// Set implements [Value].
+func (v ValueIPSlice) Set(value string) error {
+	var parsed []netip.Addr
+	for entry := range strings.SplitSeq(value, ",") {
+		addr, err := netip.ParseAddr(strings.TrimSpace(entry))
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, addr)
+	}
+	*v.vp = append(*v.vp, parsed...)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueIPSlice) String() string {
+	entries := make([]string, 0, len(*v.vp))
+	for _, entry := range *v.vp {
+		entries = append(entries, entry.String())
+	}
+	return strings.Join(entries, ",")
+}
+
+// ValueJSON implements [Value] for any type supported by [json.Unmarshal].
+//
+// Each [ValueJSON.Set] decodes the value into a new instance of the underlying
+// type and replaces the underlying value only if decoding succeeds. Unknown
+// object keys and trailing data are rejected to catch typos at parse time.
+//
+// Construct using [NewValueJSON].
+type ValueJSON struct {
+	vp any
+}
+
+// NewValueJSON constructs a new [ValueJSON] using the given non-nil pointer.
+//
+// This function panics if vp is not a non-nil pointer.
+func NewValueJSON(vp any) ValueJSON {
+	rv := reflect.ValueOf(vp)
+	runtimex.Assert(rv.Kind() == reflect.Pointer && !rv.IsNil())
+	return ValueJSON{vp}
+}
+
+var _ Value = ValueJSON{}
+
+// Set implements [Value].
+func (v ValueJSON) Set(value string) error {
+	rv := reflect.ValueOf(v.vp).Elem()
+	parsed := reflect.New(rv.Type())
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(parsed.Interface()); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return fmt.Errorf("invalid JSON value %q: unexpected trailing data", value)
+	}
+	rv.Set(parsed.Elem())
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueJSON) String() string {
+	data, err := json.Marshal(v.vp)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ValueLogLevel implements [Value] for [slog.Level].
+//
+// Values are case-insensitive level names (`debug`, `info`, `warn`, or
+// `error`) optionally followed by a numeric offset (e.g., `info+2` or
+// `debug-4`), as parsed by [slog.Level.UnmarshalText]. Plain integers
+// (e.g., `-4`) are also accepted.
+//
+// Construct using [NewValueLogLevel].
+type ValueLogLevel struct {
+	vp *slog.Level
+}
+
+// NewValueLogLevel constructs a new [ValueLogLevel] using an underlying [slog.Level].
+func NewValueLogLevel(vp *slog.Level) ValueLogLevel {
+	return ValueLogLevel{vp}
+}
+
+var _ Value = ValueLogLevel{}
+
+// Set implements [Value].
+func (v ValueLogLevel) Set(value string) error {
+	if parsed, err := strconv.Atoi(value); err == nil {
+		*v.vp = slog.Level(parsed)
+		return nil
+	}
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(value)); err != nil {
+		return fmt.Errorf("invalid log level %q: must be one of: debug, info, warn, error", value)
+	}
+	*v.vp = parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueLogLevel) String() string {
+	return strings.ToLower(v.vp.String())
+}
+
+// ValueNetipAddr implements [Value] for [netip.Addr].
+//
+// Construct using [NewValueNetipAddr].
+type ValueNetipAddr struct {
+	vp *netip.Addr
+}
+
+// NewValueNetipAddr constructs a new [ValueNetipAddr] using an underlying [netip.Addr].
+func NewValueNetipAddr(vp *netip.Addr) ValueNetipAddr {
+	return ValueNetipAddr{vp}
+}
+
+var _ Value = ValueNetipAddr{}
+
+// Set implements [Value].
+func (v ValueNetipAddr) Set(value string) error {
+	parsed, err := netip.ParseAddr(value)
+	if err != nil {
+		return err
+	}
+	*v.vp = parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+//
+// The zero [netip.Addr] is represented as the empty string.
+func (v ValueNetipAddr) String() string {
+	if !v.vp.IsValid() {
+		return ""
+	}
+	return v.vp.String()
+}
+
+// Optional contains a flag value and whether the flag was set.
+//
+// Use [Optional.IsSet] to distinguish a zero value explicitly given on the
+// command line (e.g., `--retries 0`) from the flag not being provided.
+type Optional[T any] struct {
+	// Value is the flag value, which is the default until the flag is set.
+	Value T
+
+	// set is whether the flag was set.
+	set bool
+}
+
+// IsSet returns whether the flag was set.
+func (o *Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// ValueOptional implements [Value] for an [Optional] wrapping the [Value]
+// bound to its Value field and marking it as set on each successful
+// [ValueOptional.Set]. For example:
+//
+//	var retries vflag.Optional[int]
+//	value := vflag.NewValueOptional(&retries, vflag.NewValueInt(&retries.Value))
+//
+// Construct using [NewValueOptional].
+type ValueOptional[T any] struct {
+	op    *Optional[T]
+	value Value
+}
+
+// NewValueOptional constructs a new [ValueOptional] using an underlying
+// [Optional] and the [Value] bound to its Value field.
+func NewValueOptional[T any](op *Optional[T], value Value) ValueOptional[T] {
+	return ValueOptional[T]{op, value}
+}
+
+var _ Value = ValueOptional[int]{}
+
+// Set implements [Value].
+func (v ValueOptional[T]) Set(value string) error {
+	if err := v.value.Set(value); err != nil {
+		return err
+	}
+	v.op.set = true
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueOptional[T]) String() string {
+	return v.value.String()
+}
+
+// ValuePercent implements [Value] for a float64 fraction in [0, 1].
+//
+// Each [ValuePercent.Set] accepts either a percentage (e.g., `85%`) or
+// a fraction (e.g., `0.85`) and stores the corresponding fraction.
+//
+// Construct using [NewValuePercent].
+type ValuePercent struct {
+	vp *float64
+}
+
+// NewValuePercent constructs a new [ValuePercent] using an underlying float64.
+func NewValuePercent(vp *float64) ValuePercent {
+	return ValuePercent{vp}
+}
+
+var _ Value = ValuePercent{}
+
+// Set implements [Value].
+func (v ValuePercent) Set(value string) error {
+	number, isPercent := strings.CutSuffix(value, "%")
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return fmt.Errorf("invalid percentage: %q", value)
+	}
+	if isPercent {
+		parsed /= 100
+	}
+	if math.IsNaN(parsed) || parsed < 0 || parsed > 1 {
+		return fmt.Errorf("percentage out of range: %q: must be between 0%% and 100%%", value)
+	}
+	*v.vp = parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValuePercent) String() string {
+	return strconv.FormatFloat(*v.vp*100, 'f', -1, 64) + "%"
+}
+
+// ValueRune implements [Value] for a single Unicode character.
+//
+// The value may be a Go escape sequence, such as `\t`, `\x00`, or `\u00e8`,
+// which is useful for characters that are hard to type on a command line.
+//
+// Construct using [NewValueRune].
+type ValueRune struct {
+	vp *rune
+}
+
+// NewValueRune constructs a new [ValueRune] using an underlying rune.
+func NewValueRune(vp *rune) ValueRune {
+	return ValueRune{vp}
+}
+
+var _ Value = ValueRune{}
+
+// Set implements [Value].
+func (v ValueRune) Set(value string) error {
+	if strings.HasPrefix(value, `\`) {
+		parsed, _, tail, err := strconv.UnquoteChar(value, 0)
+		if err != nil || tail != "" {
+			return fmt.Errorf("invalid escape sequence: %q", value)
+		}
+		*v.vp = parsed
+		return nil
+	}
+	if utf8.RuneCountInString(value) != 1 || !utf8.ValidString(value) {
+		return fmt.Errorf("expected a single character, got %q", value)
+	}
+	parsed, _ := utf8.DecodeRuneInString(value)
+	*v.vp = parsed
+	return nil
+}
+
+// String implements [fmt.Stringer].
+//
+// Non-printable characters and the backslash are represented
+// using the same escape sequences accepted by [ValueRune.Set].
+func (v ValueRune) String() string {
+	if *v.vp == '\'' {
+		return "'"
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(strconv.QuoteRune(*v.vp), "'"), "'")
+}
+
+// SecretString is a string that does not reveal its content when printed
+// using [fmt], logged using [log/slog], or serialized using [encoding/json].
+//
+// Use [SecretString.Reveal] to access the content.
+type SecretString struct {
+	value string
+}
+
+// secretStringRedacted is the placeholder used in place of a [SecretString] content.
+const secretStringRedacted = "[REDACTED]"
+
+// Reveal returns the content of the [SecretString].
+func (s SecretString) Reveal() string {
+	return s.value
+}
+
+// String implements [fmt.Stringer].
+func (s SecretString) String() string {
+	return secretStringRedacted
+}
+
+// GoString implements [fmt.GoStringer].
+func (s SecretString) GoString() string {
+	return secretStringRedacted
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (s SecretString) MarshalText() ([]byte, error) {
+	return []byte(secretStringRedacted), nil
+}
+
+// LogValue implements [slog.LogValuer].
+func (s SecretString) LogValue() slog.Value {
+	return slog.StringValue(secretStringRedacted)
+}
+
+// ValueSecretString implements [Value] for a [SecretString].
+//
+// The [ValueSecretString.String] method always returns an empty string, such
+// that the help never prints the secret default value.
+//
+// Setting the value to `-` reads the secret from the terminal without echo,
+// which avoids exposing the secret in the shell history and process list.
+// Reading from the terminal is only supported on Unix systems and fails
+// elsewhere, where users should pass the secret using another source
+// (e.g., an environment variable).
+//
+// Construct using [NewValueSecretString].
+type ValueSecretString struct {
+	readPassword func() (string, error)
+	vp           *SecretString
+}
+
+// NewValueSecretString constructs a new [ValueSecretString] using an underlying [SecretString].
+func NewValueSecretString(vp *SecretString) ValueSecretString {
+	return ValueSecretString{readPassword: readTerminalPassword, vp: vp}
+}
+
+var _ Value = ValueSecretString{}
+
+// Set implements [Value].
+func (v ValueSecretString) Set(value string) error {
+	if value == "-" {
+		password, err := v.readPassword()
+		if err != nil {
+			return fmt.Errorf("cannot read secret from terminal: %w", err)
+		}
+		value = password
+	}
+	*v.vp = SecretString{value}
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueSecretString) String() string {
+	return ""
+}
+
 // ValueString implements [Value] for string.
 //
 // Construct using [NewValueString].
@@ -333,6 +1307,49 @@ func (v ValueStringSlice) String() string {
 	return strings.Join(*v.vp, ",")
 }
 
+// ValueStringToString implements [Value] for a string-to-string map.
+//
+// Each [ValueStringToString.Set] parses a `KEY=VALUE` string and adds the
+// KEY to the map, overriding any previous VALUE for the same KEY.
+//
+// Construct using [NewValueStringToString].
+type ValueStringToString struct {
+	vp *map[string]string
+}
+
+// NewValueStringToString constructs a new [ValueStringToString] using an underlying
+// string-to-string map. If the map is nil, [ValueStringToString.Set] allocates it.
+func NewValueStringToString(vp *map[string]string) ValueStringToString {
+	return ValueStringToString{vp}
+}
+
+var _ Value = ValueStringToString{}
+
+// Set implements [Value].
+func (v ValueStringToString) Set(value string) error {
+	key, val, found := strings.Cut(value, "=")
+	if !found {
+		return fmt.Errorf("invalid value %q: expected KEY=VALUE", value)
+	}
+	if *v.vp == nil {
+		*v.vp = make(map[string]string)
+	}
+	(*v.vp)[key] = val
+	return nil
+}
+
+// String implements [fmt.Stringer].
+//
+// The entries are sorted by key and separated by commas (e.g., `a=1,b=2`).
+func (v ValueStringToString) String() string {
+	keys := slices.Sorted(maps.Keys(*v.vp))
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, key+"="+(*v.vp)[key])
+	}
+	return strings.Join(entries, ",")
+}
+
 // ValueUint implements [Value] for uint.
 //
 // Construct using [NewValueUint].
@@ -362,6 +1379,41 @@ func (v ValueUint) String() string {
 	return strconv.FormatUint(uint64(*v.vp), 10)
 }
 
+// ValueUintSlice implements [Value] for a uint slice.
+//
+// Each [ValueUintSlice.Set] parses the value and appends it to the slice.
+//
+// Construct using [NewValueUintSlice].
+type ValueUintSlice struct {
+	vp *[]uint
+}
+
+// NewValueUintSlice constructs a new [ValueUintSlice] using an underlying uint slice.
+func NewValueUintSlice(vp *[]uint) ValueUintSlice {
+	return ValueUintSlice{vp}
+}
+
+var _ Value = ValueUintSlice{}
+
+// Set implements [Value].
+func (v ValueUintSlice) Set(value string) error {
+	parsed, err := strconv.ParseUint(value, 10, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*v.vp = append(*v.vp, uint(parsed))
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueUintSlice) String() string {
+	entries := make([]string, 0, len(*v.vp))
+	for _, entry := range *v.vp {
+		entries = append(entries, strconv.FormatUint(uint64(entry), 10))
+	}
+	return strings.Join(entries, ",")
+}
+
 // ValueUint8 implements [Value] for uint8.
 //
 // Construct using [NewValueUint8].
@@ -477,3 +1529,55 @@ func (v ValueUint64) Set(value string) error {
 func (v ValueUint64) String() string {
 	return strconv.FormatUint(*v.vp, 10)
 }
+
+// ValueUUID implements [Value] for a string containing a UUID.
+//
+// Each [ValueUUID.Set] validates the RFC 4122 textual representation of
+// the UUID (e.g., `f81d4fae-7dec-11d0-a765-00a0c91e6bf6`) and normalizes
+// it to lowercase. An empty string represents the absence of a UUID.
+//
+// Construct using [NewValueUUID].
+type ValueUUID struct {
+	vp *string
+}
+
+// NewValueUUID constructs a new [ValueUUID] using an underlying string.
+func NewValueUUID(vp *string) ValueUUID {
+	return ValueUUID{vp}
+}
+
+var _ Value = ValueUUID{}
+
+// Set implements [Value].
+func (v ValueUUID) Set(value string) error {
+	if !isUUID(value) {
+		return fmt.Errorf("invalid UUID: %q", value)
+	}
+	*v.vp = strings.ToLower(value)
+	return nil
+}
+
+// String implements [fmt.Stringer].
+func (v ValueUUID) String() string {
+	return *v.vp
+}
+
+// isUUID returns whether value is a UUID in the `8-4-4-4-12` hex digits format.
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+	for idx := 0; idx < len(value); idx++ {
+		switch idx {
+		case 8, 13, 18, 23:
+			if value[idx] != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", rune(value[idx])) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -3,6 +3,16 @@
 package vflag
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -21,6 +31,39 @@ func TestValueAutoHelp(t *testing.T) {
 	assert.Equal(t, "false", value.String())
 }
 
+func TestValueBigFloat(t *testing.T) {
+	raw := new(big.Float).SetPrec(200)
+	value := NewValueBigFloat(raw)
+
+	assert.Equal(t, "0", value.String())
+	require.NoError(t, value.Set("0.1"))
+	assert.Equal(t, uint(200), raw.Prec())
+	expect, _, err := big.ParseFloat("0.1", 10, 200, big.ToNearestEven)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expect.Cmp(raw))
+	require.NoError(t, value.Set("1e400"))
+	assert.Equal(t, "1e+400", value.String())
+
+	require.Error(t, value.Set("abc"))
+	assert.Equal(t, "1e+400", value.String())
+}
+
+func TestValueBigInt(t *testing.T) {
+	var raw big.Int
+	value := NewValueBigInt(&raw)
+
+	assert.Equal(t, "0", value.String())
+	require.NoError(t, value.Set("123456789012345678901234567890"))
+	assert.Equal(t, "123456789012345678901234567890", value.String())
+	require.NoError(t, value.Set("-42"))
+	assert.Equal(t, int64(-42), raw.Int64())
+
+	err := value.Set("12.5")
+	require.Error(t, err)
+	assert.Equal(t, `invalid integer: "12.5"`, err.Error())
+	assert.Equal(t, "-42", value.String())
+}
+
 func TestValueBool(t *testing.T) {
 	var raw bool
 	value := NewValueBool(&raw)
@@ -28,11 +71,109 @@ func TestValueBool(t *testing.T) {
 	assert.Equal(t, "false", value.String())
 	require.NoError(t, value.Set("true"))
 	assert.Equal(t, "true", value.String())
+	require.NoError(t, value.Set("Off"))
+	assert.Equal(t, "false", value.String())
+	require.NoError(t, value.Set("yes"))
+	assert.Equal(t, "true", value.String())
 
 	require.Error(t, value.Set("nope"))
 	assert.Equal(t, "true", value.String())
 }
 
+func TestParseBool(t *testing.T) {
+	for _, input := range []string{"1", "t", "T", "true", "TRUE", "True", "yes", "YES", "y", "Y", "on", "On"} {
+		parsed, err := parseBool(input)
+		require.NoError(t, err, input)
+		assert.True(t, parsed, input)
+	}
+	for _, input := range []string{"0", "f", "F", "false", "FALSE", "False", "no", "No", "n", "N", "off", "OFF"} {
+		parsed, err := parseBool(input)
+		require.NoError(t, err, input)
+		assert.False(t, parsed, input)
+	}
+	for _, input := range []string{"", "nope", "yess", "2"} {
+		_, err := parseBool(input)
+		var numErr *strconv.NumError
+		assert.ErrorAs(t, err, &numErr, input)
+	}
+}
+
+func TestValueBoolFunc(t *testing.T) {
+	var values []string
+	value := NewValueBoolFunc(func(s string) error {
+		if s == "invalid" {
+			return errors.New("invalid value")
+		}
+		values = append(values, s)
+		return nil
+	})
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set(""))
+	require.NoError(t, value.Set("false"))
+	assert.Equal(t, []string{"true", "false"}, values)
+	assert.Error(t, value.Set("invalid"))
+}
+
+func TestValueBoolPtr(t *testing.T) {
+	var raw *bool
+	value := NewValueBoolPtr(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("false"))
+	require.NotNil(t, raw)
+	assert.False(t, *raw)
+	assert.Equal(t, "false", value.String())
+	require.NoError(t, value.Set(""))
+	assert.True(t, *raw)
+
+	require.Error(t, value.Set("maybe"))
+	assert.True(t, *raw)
+}
+
+func TestValueBytesBase64(t *testing.T) {
+	var raw []byte
+	value := NewValueBytesBase64(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("AAEC/w=="))
+	assert.Equal(t, []byte{0, 1, 2, 255}, raw)
+	assert.Equal(t, "AAEC/w==", value.String())
+
+	require.Error(t, value.Set("AAEC/w"))
+	require.Error(t, value.Set("not base64!"))
+	assert.Equal(t, []byte{0, 1, 2, 255}, raw)
+}
+
+func TestValueBytesHex(t *testing.T) {
+	var raw []byte
+	value := NewValueBytesHex(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("deadBEEF"))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, raw)
+	assert.Equal(t, "deadbeef", value.String())
+
+	require.Error(t, value.Set("abc"))
+	require.Error(t, value.Set("zz"))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, raw)
+}
+
+func TestValueCount(t *testing.T) {
+	var raw int
+	value := NewValueCount(&raw)
+
+	assert.Equal(t, "0", value.String())
+	require.NoError(t, value.Set(""))
+	require.NoError(t, value.Set(""))
+	assert.Equal(t, "2", value.String())
+	require.NoError(t, value.Set("7"))
+	assert.Equal(t, "7", value.String())
+
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "7", value.String())
+}
+
 func TestValueDuration(t *testing.T) {
 	var raw time.Duration
 	value := NewValueDuration(&raw)
@@ -45,6 +186,72 @@ func TestValueDuration(t *testing.T) {
 	assert.Equal(t, "3s", value.String())
 }
 
+func TestValueDurationSlice(t *testing.T) {
+	var raw []time.Duration
+	value := NewValueDurationSlice(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("1s"))
+	require.NoError(t, value.Set("500ms"))
+	assert.Equal(t, "1s,500ms", value.String())
+	assert.Equal(t, []time.Duration{time.Second, 500 * time.Millisecond}, raw)
+
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "1s,500ms", value.String())
+}
+
+func TestValueEnum(t *testing.T) {
+	raw := "json"
+	value := NewValueEnum(&raw, "json", "yaml", "table")
+
+	assert.Equal(t, []string{"json", "yaml", "table"}, value.Choices())
+	assert.Equal(t, "json", value.String())
+	require.NoError(t, value.Set("yaml"))
+	assert.Equal(t, "yaml", value.String())
+
+	err := value.Set("xml")
+	require.Error(t, err)
+	assert.Equal(t, `invalid value "xml": must be one of: json, yaml, table`, err.Error())
+	assert.Equal(t, "yaml", value.String())
+}
+
+func TestValueFileMode(t *testing.T) {
+	var raw os.FileMode
+	value := NewValueFileMode(&raw)
+
+	assert.Equal(t, "0", value.String())
+	require.NoError(t, value.Set("0644"))
+	assert.Equal(t, os.FileMode(0644), raw)
+	assert.Equal(t, "0644", value.String())
+	require.NoError(t, value.Set("755"))
+	assert.Equal(t, os.FileMode(0755), raw)
+	require.NoError(t, value.Set("4755"))
+	assert.Equal(t, os.ModeSetuid|0755, raw)
+	assert.Equal(t, "04755", value.String())
+	require.NoError(t, value.Set("3777"))
+	assert.Equal(t, os.ModeSetgid|os.ModeSticky|0777, raw)
+	assert.Equal(t, "03777", value.String())
+
+	require.Error(t, value.Set("0888"))
+	require.Error(t, value.Set("rwxr-xr-x"))
+	require.Error(t, value.Set("10000"))
+	assert.Equal(t, "03777", value.String())
+}
+
+func TestValueFloat32(t *testing.T) {
+	var raw float32
+	value := NewValueFloat32(&raw)
+
+	assert.Equal(t, "0", value.String())
+	require.NoError(t, value.Set("0.1"))
+	assert.Equal(t, "0.1", value.String())
+	assert.Equal(t, float32(0.1), raw)
+
+	require.Error(t, value.Set("1e40"))
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "0.1", value.String())
+}
+
 func TestValueFloat64(t *testing.T) {
 	var raw float64
 	value := NewValueFloat64(&raw)
@@ -57,6 +264,54 @@ func TestValueFloat64(t *testing.T) {
 	assert.Equal(t, "1.25", value.String())
 }
 
+func TestValueFloat64Slice(t *testing.T) {
+	var raw []float64
+	value := NewValueFloat64Slice(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("1.5"))
+	require.NoError(t, value.Set("2"))
+	assert.Equal(t, "1.5,2", value.String())
+	assert.Equal(t, []float64{1.5, 2}, raw)
+
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "1.5,2", value.String())
+}
+
+func TestValueFunc(t *testing.T) {
+	var values []string
+	value := NewValueFunc(func(s string) error {
+		if s == "" {
+			return errors.New("empty value")
+		}
+		values = append(values, s)
+		return nil
+	})
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("a"))
+	require.NoError(t, value.Set("b"))
+	assert.Equal(t, []string{"a", "b"}, values)
+	assert.Error(t, value.Set(""))
+	assert.Equal(t, []string{"a", "b"}, values)
+}
+
+func TestValueGlob(t *testing.T) {
+	var raw []string
+	value := NewValueGlob(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("*.go"))
+	require.NoError(t, value.Set("cmd/[a-z]*"))
+	assert.Equal(t, []string{"*.go", "cmd/[a-z]*"}, raw)
+	assert.Equal(t, "*.go,cmd/[a-z]*", value.String())
+
+	err := value.Set("[a-")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, filepath.ErrBadPattern)
+	assert.Equal(t, []string{"*.go", "cmd/[a-z]*"}, raw)
+}
+
 func TestValueInt(t *testing.T) {
 	var raw int
 	value := NewValueInt(&raw)
@@ -69,6 +324,20 @@ func TestValueInt(t *testing.T) {
 	assert.Equal(t, "12", value.String())
 }
 
+func TestValueIntSlice(t *testing.T) {
+	var raw []int
+	value := NewValueIntSlice(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("1"))
+	require.NoError(t, value.Set("-2"))
+	assert.Equal(t, "1,-2", value.String())
+	assert.Equal(t, []int{1, -2}, raw)
+
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "1,-2", value.String())
+}
+
 func TestValueInt8(t *testing.T) {
 	var raw int8
 	value := NewValueInt8(&raw)
@@ -117,6 +386,225 @@ func TestValueInt64(t *testing.T) {
 	assert.Equal(t, "7", value.String())
 }
 
+func TestValueInt64Slice(t *testing.T) {
+	var raw []int64
+	value := NewValueInt64Slice(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("1"))
+	require.NoError(t, value.Set("-2"))
+	assert.Equal(t, "1,-2", value.String())
+	assert.Equal(t, []int64{1, -2}, raw)
+
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "1,-2", value.String())
+}
+
+func TestValueIPSlice(t *testing.T) {
+	var raw []netip.Addr
+	value := NewValueIPSlice(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("8.8.8.8"))
+	require.NoError(t, value.Set("1.1.1.1, 2606:4700:4700::1111"))
+	assert.Equal(t, []netip.Addr{
+		netip.MustParseAddr("8.8.8.8"),
+		netip.MustParseAddr("1.1.1.1"),
+		netip.MustParseAddr("2606:4700:4700::1111"),
+	}, raw)
+	assert.Equal(t, "8.8.8.8,1.1.1.1,2606:4700:4700::1111", value.String())
+
+	require.Error(t, value.Set("9.9.9.9,dns.google"))
+	require.Error(t, value.Set(""))
+	assert.Len(t, raw, 3)
+}
+
+func TestValueJSON(t *testing.T) {
+	type filter struct {
+		Status string   `json:"status"`
+		Tags   []string `json:"tags"`
+	}
+
+	raw := filter{Status: "any"}
+	value := NewValueJSON(&raw)
+
+	assert.Equal(t, `{"status":"any","tags":null}`, value.String())
+	require.NoError(t, value.Set(`{"status": "active"}`))
+	assert.Equal(t, filter{Status: "active"}, raw)
+	require.NoError(t, value.Set(`{"tags": ["a", "b"]}`))
+	assert.Equal(t, filter{Tags: []string{"a", "b"}}, raw)
+
+	for _, input := range []string{``, `{`, `[]`, `{"state": "active"}`, `{} {}`} {
+		assert.Error(t, value.Set(input), input)
+	}
+	assert.Equal(t, filter{Tags: []string{"a", "b"}}, raw)
+
+	assert.Panics(t, func() { NewValueJSON(raw) })
+	assert.Panics(t, func() { NewValueJSON((*filter)(nil)) })
+}
+
+func TestValueLogLevel(t *testing.T) {
+	var raw slog.Level
+	value := NewValueLogLevel(&raw)
+
+	assert.Equal(t, "info", value.String())
+
+	cases := []struct {
+		input  string
+		expect slog.Level
+		str    string
+	}{
+		{"debug", slog.LevelDebug, "debug"},
+		{"WARN", slog.LevelWarn, "warn"},
+		{"Error", slog.LevelError, "error"},
+		{"info+2", slog.LevelInfo + 2, "info+2"},
+		{"debug-4", slog.LevelDebug - 4, "debug-4"},
+		{"-4", slog.LevelDebug, "debug"},
+		{"12", slog.Level(12), "error+4"},
+	}
+	for _, tc := range cases {
+		require.NoError(t, value.Set(tc.input), tc.input)
+		assert.Equal(t, tc.expect, raw, tc.input)
+		assert.Equal(t, tc.str, value.String(), tc.input)
+	}
+
+	err := value.Set("verbose")
+	require.Error(t, err)
+	assert.Equal(t, `invalid log level "verbose": must be one of: debug, info, warn, error`, err.Error())
+	assert.Equal(t, slog.Level(12), raw)
+}
+
+func TestValueNetipAddr(t *testing.T) {
+	var raw netip.Addr
+	value := NewValueNetipAddr(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("10.0.0.1"))
+	assert.Equal(t, "10.0.0.1", value.String())
+	require.NoError(t, value.Set("::1"))
+	assert.Equal(t, "::1", value.String())
+	assert.Equal(t, netip.IPv6Loopback(), raw)
+
+	require.Error(t, value.Set("10.0.0.256"))
+	assert.Equal(t, "::1", value.String())
+}
+
+func TestValueOptional(t *testing.T) {
+	var raw Optional[int]
+	raw.Value = 10
+	value := NewValueOptional(&raw, NewValueInt(&raw.Value))
+
+	assert.False(t, raw.IsSet())
+	assert.Equal(t, "10", value.String())
+
+	require.Error(t, value.Set("invalid"))
+	assert.False(t, raw.IsSet())
+
+	require.NoError(t, value.Set("0"))
+	assert.True(t, raw.IsSet())
+	assert.Equal(t, 0, raw.Value)
+	assert.Equal(t, "0", value.String())
+}
+
+func TestValuePercent(t *testing.T) {
+	var raw float64
+	value := NewValuePercent(&raw)
+
+	assert.Equal(t, "0%", value.String())
+
+	cases := []struct {
+		input  string
+		expect float64
+		str    string
+	}{
+		{"85%", 0.85, "85%"},
+		{"0.25", 0.25, "25%"},
+		{"100%", 1, "100%"},
+		{"1", 1, "100%"},
+		{"0%", 0, "0%"},
+		{"12.5%", 0.125, "12.5%"},
+	}
+	for _, tc := range cases {
+		require.NoError(t, value.Set(tc.input), tc.input)
+		assert.Equal(t, tc.expect, raw, tc.input)
+		assert.Equal(t, tc.str, value.String(), tc.input)
+	}
+
+	for _, input := range []string{"", "%", "abc%", "85", "101%", "-1%", "1.5", "NaN"} {
+		assert.Error(t, value.Set(input), input)
+	}
+	assert.Equal(t, 0.125, raw)
+}
+
+func TestValueRune(t *testing.T) {
+	var raw rune
+	value := NewValueRune(&raw)
+
+	assert.Equal(t, `\x00`, value.String())
+
+	cases := []struct {
+		input  string
+		expect rune
+		str    string
+	}{
+		{",", ',', ","},
+		{"è", 'è', "è"},
+		{`\t`, '\t', `\t`},
+		{`\u00e8`, 'è', "è"},
+		{`\\`, '\\', `\\`},
+		{"'", '\'', "'"},
+	}
+	for _, tc := range cases {
+		require.NoError(t, value.Set(tc.input))
+		assert.Equal(t, tc.expect, raw)
+		assert.Equal(t, tc.str, value.String())
+		require.NoError(t, value.Set(value.String()))
+		assert.Equal(t, tc.expect, raw)
+	}
+
+	for _, input := range []string{"", "ab", `\`, `\tx`, `\q`, "\xff"} {
+		assert.Error(t, value.Set(input), input)
+	}
+	assert.Equal(t, '\'', raw)
+}
+
+func TestSecretString(t *testing.T) {
+	secret := SecretString{"hunter2"}
+
+	assert.Equal(t, "hunter2", secret.Reveal())
+	assert.Equal(t, "[REDACTED] [REDACTED] [REDACTED]", fmt.Sprintf("%s %v %#v", secret, secret, secret))
+
+	data, err := json.Marshal(struct{ Password SecretString }{secret})
+	require.NoError(t, err)
+	assert.Equal(t, `{"Password":"[REDACTED]"}`, string(data))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("login", "password", secret)
+	assert.Contains(t, buf.String(), "password=[REDACTED]")
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestValueSecretString(t *testing.T) {
+	var secret SecretString
+	value := NewValueSecretString(&secret)
+	value.readPassword = func() (string, error) {
+		return "from-terminal", nil
+	}
+
+	require.NoError(t, value.Set("hunter2"))
+	assert.Equal(t, "hunter2", secret.Reveal())
+	assert.Equal(t, "", value.String())
+
+	require.NoError(t, value.Set("-"))
+	assert.Equal(t, "from-terminal", secret.Reveal())
+
+	value.readPassword = func() (string, error) {
+		return "", errors.New("not a terminal")
+	}
+	assert.ErrorContains(t, value.Set("-"), "not a terminal")
+	assert.Equal(t, "from-terminal", secret.Reveal())
+}
+
 func TestValueString(t *testing.T) {
 	var raw string
 	value := NewValueString(&raw)
@@ -141,6 +629,21 @@ func TestValueStringSlice(t *testing.T) {
 	assert.Equal(t, "a,not-a-number", value.String())
 }
 
+func TestValueStringToString(t *testing.T) {
+	var raw map[string]string
+	value := NewValueStringToString(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("b=2"))
+	require.NoError(t, value.Set("a=1=1"))
+	assert.Equal(t, "a=1=1,b=2", value.String())
+	require.NoError(t, value.Set("b="))
+	assert.Equal(t, map[string]string{"a": "1=1", "b": ""}, raw)
+
+	require.Error(t, value.Set("nope"))
+	assert.Equal(t, "a=1=1,b=", value.String())
+}
+
 func TestValueUint(t *testing.T) {
 	var raw uint
 	value := NewValueUint(&raw)
@@ -153,6 +656,20 @@ func TestValueUint(t *testing.T) {
 	assert.Equal(t, "7", value.String())
 }
 
+func TestValueUintSlice(t *testing.T) {
+	var raw []uint
+	value := NewValueUintSlice(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("1"))
+	require.NoError(t, value.Set("2"))
+	assert.Equal(t, "1,2", value.String())
+	assert.Equal(t, []uint{1, 2}, raw)
+
+	require.Error(t, value.Set("-1"))
+	assert.Equal(t, "1,2", value.String())
+}
+
 func TestValueUint8(t *testing.T) {
 	var raw uint8
 	value := NewValueUint8(&raw)
@@ -200,3 +717,26 @@ func TestValueUint64(t *testing.T) {
 	require.Error(t, value.Set("-1"))
 	assert.Equal(t, "7", value.String())
 }
+
+func TestValueUUID(t *testing.T) {
+	var raw string
+	value := NewValueUUID(&raw)
+
+	assert.Equal(t, "", value.String())
+	require.NoError(t, value.Set("F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"))
+	assert.Equal(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", raw)
+	assert.Equal(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", value.String())
+
+	for _, input := range []string{
+		"",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf6a",
+		"f81d4fae-7dec-11d0-a765_00a0c91e6bf6",
+		"g81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf}",
+	} {
+		assert.Error(t, value.Set(input), input)
+	}
+	assert.Equal(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", raw)
+}
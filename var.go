@@ -6,19 +6,83 @@
 
 package vflag
 
-import "time"
+import (
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"os"
+	"time"
+)
 
 // AutoHelp registers auto-help flags using GNU conventions.
 //
 // If shortName is not zero, a short flag (e.g., `-h`) is added to ShortFlags.
 // If longName is not empty, a long flag (e.g., `--help`) is added to LongFlags.
-func (fs *FlagSet) AutoHelp(shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) AutoHelp(shortName rune, longName string, helpText ...string) {
 	value := ValueAutoHelp{}
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagAutoHelp(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagAutoHelp(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagAutoHelp(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagAutoHelp(value, longName, helpText...), shortName)
+	}
+}
+
+// AutoHelpLevels is like [*FlagSet.AutoHelp] but configures the [HelpLevel] of
+// the short and long flags. For example, use [HelpShort] for `-h` and [HelpFull]
+// for `--help` to print a terse synopsis with `-h` and the full help with `--help`.
+//
+// Regardless of the configured levels, the user could select the level of a long
+// flag using `--help=short` or `--help=full` on the command line.
+func (fs *FlagSet) AutoHelpLevels(shortName rune, shortLevel HelpLevel, longName string, longLevel HelpLevel, helpText ...string) {
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagAutoHelp(ValueAutoHelp{Level: shortLevel}, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagAutoHelp(ValueAutoHelp{Level: longLevel}, longName, helpText...), shortName)
+	}
+}
+
+// AttachedStringVar registers a short string flag whose value is optional and,
+// when present, attached to the flag, like gcc's `-O` and `-O2` flags. For example:
+//
+//	fs.AttachedStringVar(&level, 'O', "1", "Optimize at the given `LEVEL`.")
+//
+// When the flag appears without an attached value, we use the defaultValue, which
+// must not be empty. See [ShortFlagMakeOptionWithOptionalValue] for more information.
+func (fs *FlagSet) AttachedStringVar(vp *string, shortName rune, defaultValue string, helpText ...string) {
+	fx := NewShortFlagString(NewValueString(vp), shortName, helpText...)
+	fx.ArgumentName = "[STRING]"
+	fx.DefaultValue = defaultValue
+	fx.MakeOption = ShortFlagMakeOptionWithOptionalValue
+	fs.AddShortFlag(fx)
+}
+
+// BigFloatVar registers [big.Float] flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) BigFloatVar(vp *big.Float, shortName rune, longName string, helpText ...string) {
+	value := NewValueBigFloat(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagBigFloat(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBigFloat(value, longName, helpText...), shortName)
+	}
+}
+
+// BigIntVar registers [big.Int] flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) BigIntVar(vp *big.Int, shortName rune, longName string, helpText ...string) {
+	value := NewValueBigInt(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagBigInt(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBigInt(value, longName, helpText...), shortName)
 	}
 }
 
@@ -26,13 +90,122 @@ func (fs *FlagSet) AutoHelp(shortName byte, longName string, helpText ...string)
 //
 // If shortName is not zero, a short flag (e.g., `-v`) is added to ShortFlags.
 // If longName is not empty, a long flag (e.g., `--verbose`) is added to LongFlags.
-func (fs *FlagSet) BoolVar(vp *bool, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) BoolVar(vp *bool, shortName rune, longName string, helpText ...string) {
 	value := NewValueBool(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagBool(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagBool(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBool(value, longName, helpText...), shortName)
+	}
+}
+
+// Bool is like [*FlagSet.BoolVar] but allocates a bool initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Bool(shortName rune, longName string, value bool, helpText ...string) *bool {
+	vp := new(bool)
+	*vp = value
+	fs.BoolVar(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// NegatableBoolVar is like [*FlagSet.BoolVar] but the long flag is Negatable,
+// such that, e.g., `--no-verbose` sets the value to false. This is useful to
+// allow users to override boolean flags whose default value is true.
+func (fs *FlagSet) NegatableBoolVar(vp *bool, shortName rune, longName string, helpText ...string) {
+	fs.BoolVar(vp, shortName, longName, helpText...)
+	if longName != "" {
+		fs.LongFlags[len(fs.LongFlags)-1].Negatable = true
+	}
+}
+
+// ToggleBoolVar registers a chmod-style toggle flag bound to vp, such
+// that, e.g., `+x` sets the value to true and `-x` sets it to false.
+// The `+x` flag has `-` as its NegatedPrefix and both forms are
+// groupable with the other flags using the same prefix.
+func (fs *FlagSet) ToggleBoolVar(vp *bool, name rune, helpText ...string) {
+	fx := NewShortFlagBool(NewValueBool(vp), name, helpText...)
+	fx.Prefix = "+"
+	fx.NegatedPrefix = "-"
+	fs.AddShortFlag(fx)
+}
+
+// BoolFunc registers boolean-style flags invoking fn for each occurrence
+// of the flag using GNU conventions. This method is like [flag.FlagSet.BoolFunc]:
+// fn receives `true` when the flag has no value (e.g., `--trace`) and the
+// given value otherwise (e.g., `--trace=false`). Errors returned by fn are
+// reported as parsing errors.
+//
+// If shortName is not zero, a short flag (e.g., `-t`) is added to ShortFlags.
+// If longName is not empty, a long flag (e.g., `--trace`) is added to LongFlags.
+func (fs *FlagSet) BoolFunc(shortName rune, longName string, helpText string, fn func(string) error) {
+	value := NewValueBoolFunc(fn)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagBoolFunc(value, shortName, helpText))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBoolFunc(value, longName, helpText), shortName)
+	}
+}
+
+// BoolPtrVar registers tri-state boolean flags using GNU conventions.
+//
+// The *bool pointed to by vp remains nil unless the flags are set, which
+// allows distinguishing `--feature=false` from the flag not being provided.
+//
+// If shortName is not zero, a short flag (e.g., `-f`) is added to ShortFlags.
+// If longName is not empty, a long flag (e.g., `--feature`) is added to LongFlags.
+func (fs *FlagSet) BoolPtrVar(vp **bool, shortName rune, longName string, helpText ...string) {
+	value := NewValueBoolPtr(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagBoolPtr(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBoolPtr(value, longName, helpText...), shortName)
+	}
+}
+
+// BytesBase64Var registers base64-encoded byte slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) BytesBase64Var(vp *[]byte, shortName rune, longName string, helpText ...string) {
+	value := NewValueBytesBase64(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagBytesBase64(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBytesBase64(value, longName, helpText...), shortName)
+	}
+}
+
+// BytesHexVar registers hex-encoded byte slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) BytesHexVar(vp *[]byte, shortName rune, longName string, helpText ...string) {
+	value := NewValueBytesHex(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagBytesHex(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagBytesHex(value, longName, helpText...), shortName)
+	}
+}
+
+// CountVar registers counter flags using GNU conventions.
+//
+// If shortName is not zero, a short flag (e.g., `-v`) is added to ShortFlags.
+// If longName is not empty, a long flag (e.g., `--verbose`) is added to LongFlags.
+//
+// Each occurrence of the flag increments the value, such that `-vvv` yields 3.
+func (fs *FlagSet) CountVar(vp *int, shortName rune, longName string, helpText ...string) {
+	value := NewValueCount(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagCount(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagBool(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagCount(value, longName, helpText...), shortName)
 	}
 }
 
@@ -40,13 +213,82 @@ func (fs *FlagSet) BoolVar(vp *bool, shortName byte, longName string, helpText .
 //
 // If shortName is not zero, a short flag (e.g., `-t`) is added to ShortFlags.
 // If longName is not empty, a long flag (e.g., `--timeout`) is added to LongFlags.
-func (fs *FlagSet) DurationVar(vp *time.Duration, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) DurationVar(vp *time.Duration, shortName rune, longName string, helpText ...string) {
 	value := NewValueDuration(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagDuration(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagDuration(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagDuration(value, longName, helpText...), shortName)
+	}
+}
+
+// Duration is like [*FlagSet.DurationVar] but allocates a [time.Duration] initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Duration(shortName rune, longName string, value time.Duration, helpText ...string) *time.Duration {
+	vp := new(time.Duration)
+	*vp = value
+	fs.DurationVar(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// DurationSliceVar registers duration slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flag appends a value to the slice.
+func (fs *FlagSet) DurationSliceVar(vp *[]time.Duration, shortName rune, longName string, helpText ...string) {
+	value := NewValueDurationSlice(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagDurationSlice(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagDurationSlice(value, longName, helpText...), shortName)
+	}
+}
+
+// EnumVar registers flags restricted to the given choices using GNU conventions.
+//
+// If shortName is not zero, a short flag (e.g., `-f`) is added to ShortFlags.
+// If longName is not empty, a long flag (e.g., `--format`) is added to LongFlags.
+//
+// Parsing fails for values not in choices, and the error lists the choices.
+func (fs *FlagSet) EnumVar(vp *string, choices []string, shortName rune, longName string, helpText ...string) {
+	value := NewValueEnum(vp, choices...)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagEnum(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagEnum(value, longName, helpText...), shortName)
+	}
+}
+
+// FileModeVar registers [os.FileMode] flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) FileModeVar(vp *os.FileMode, shortName rune, longName string, helpText ...string) {
+	value := NewValueFileMode(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagFileMode(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagFileMode(value, longName, helpText...), shortName)
+	}
+}
+
+// Float32Var registers float32 flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) Float32Var(vp *float32, shortName rune, longName string, helpText ...string) {
+	value := NewValueFloat32(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagFloat32(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagDuration(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagFloat32(value, longName, helpText...), shortName)
 	}
 }
 
@@ -54,13 +296,80 @@ func (fs *FlagSet) DurationVar(vp *time.Duration, shortName byte, longName strin
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Float64Var(vp *float64, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Float64Var(vp *float64, shortName rune, longName string, helpText ...string) {
 	value := NewValueFloat64(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagFloat64(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagFloat64(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagFloat64(value, longName, helpText...), shortName)
+	}
+}
+
+// Float64 is like [*FlagSet.Float64Var] but allocates a float64 initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Float64(shortName rune, longName string, value float64, helpText ...string) *float64 {
+	vp := new(float64)
+	*vp = value
+	fs.Float64Var(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// Float64SliceVar registers float64 slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flag appends a value to the slice.
+func (fs *FlagSet) Float64SliceVar(vp *[]float64, shortName rune, longName string, helpText ...string) {
+	value := NewValueFloat64Slice(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagFloat64Slice(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagFloat64(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagFloat64Slice(value, longName, helpText...), shortName)
+	}
+}
+
+// Func registers flags invoking fn with the flag value for each
+// occurrence of the flag using GNU conventions. This method is
+// like [flag.FlagSet.Func], and errors returned by fn are reported
+// as parsing errors. For example:
+//
+//	fs.Func('H', "header", "Add a header.", func(value string) error {
+//		name, _, found := strings.Cut(value, ":")
+//		if !found || name == "" {
+//			return errors.New("expected NAME: VALUE")
+//		}
+//		headers = append(headers, value)
+//		return nil
+//	})
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) Func(shortName rune, longName string, helpText string, fn func(string) error) {
+	value := NewValueFunc(fn)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagFunc(value, shortName, helpText))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagFunc(value, longName, helpText), shortName)
+	}
+}
+
+// GlobVar registers glob pattern flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flags appends a pattern to the slice.
+func (fs *FlagSet) GlobVar(vp *[]string, shortName rune, longName string, helpText ...string) {
+	value := NewValueGlob(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagGlob(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagGlob(value, longName, helpText...), shortName)
 	}
 }
 
@@ -68,13 +377,48 @@ func (fs *FlagSet) Float64Var(vp *float64, shortName byte, longName string, help
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) IntVar(vp *int, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) IntVar(vp *int, shortName rune, longName string, helpText ...string) {
 	value := NewValueInt(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagInt(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagInt(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagInt(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagInt(value, longName, helpText...), shortName)
+	}
+}
+
+// Int is like [*FlagSet.IntVar] but allocates an int initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Int(shortName rune, longName string, value int, helpText ...string) *int {
+	vp := new(int)
+	*vp = value
+	fs.IntVar(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// NumericShorthandVar is like [*FlagSet.IntVar] but also sets NumericShorthand such
+// that, e.g., `-5` is equivalent to `--lines 5`, like the head and tail commands do.
+func (fs *FlagSet) NumericShorthandVar(vp *int, shortName rune, longName string, helpText ...string) {
+	fs.IntVar(vp, shortName, longName, helpText...)
+	fs.NumericShorthand = longName
+	if longName == "" {
+		fs.NumericShorthand = string(shortName)
+	}
+}
+
+// IntSliceVar registers int slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flag appends a value to the slice.
+func (fs *FlagSet) IntSliceVar(vp *[]int, shortName rune, longName string, helpText ...string) {
+	value := NewValueIntSlice(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagIntSlice(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagIntSlice(value, longName, helpText...), shortName)
 	}
 }
 
@@ -82,13 +426,13 @@ func (fs *FlagSet) IntVar(vp *int, shortName byte, longName string, helpText ...
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Int8Var(vp *int8, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Int8Var(vp *int8, shortName rune, longName string, helpText ...string) {
 	value := NewValueInt8(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagInt8(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagInt8(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagInt8(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagInt8(value, longName, helpText...), shortName)
 	}
 }
 
@@ -96,13 +440,13 @@ func (fs *FlagSet) Int8Var(vp *int8, shortName byte, longName string, helpText .
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Int16Var(vp *int16, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Int16Var(vp *int16, shortName rune, longName string, helpText ...string) {
 	value := NewValueInt16(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagInt16(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagInt16(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagInt16(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagInt16(value, longName, helpText...), shortName)
 	}
 }
 
@@ -110,13 +454,13 @@ func (fs *FlagSet) Int16Var(vp *int16, shortName byte, longName string, helpText
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Int32Var(vp *int32, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Int32Var(vp *int32, shortName rune, longName string, helpText ...string) {
 	value := NewValueInt32(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagInt32(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagInt32(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagInt32(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagInt32(value, longName, helpText...), shortName)
 	}
 }
 
@@ -124,13 +468,246 @@ func (fs *FlagSet) Int32Var(vp *int32, shortName byte, longName string, helpText
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Int64Var(vp *int64, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Int64Var(vp *int64, shortName rune, longName string, helpText ...string) {
 	value := NewValueInt64(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagInt64(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagInt64(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagInt64(value, longName, helpText...), shortName)
+	}
+}
+
+// Int64 is like [*FlagSet.Int64Var] but allocates an int64 initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Int64(shortName rune, longName string, value int64, helpText ...string) *int64 {
+	vp := new(int64)
+	*vp = value
+	fs.Int64Var(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// Int64SliceVar registers int64 slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flag appends a value to the slice.
+func (fs *FlagSet) Int64SliceVar(vp *[]int64, shortName rune, longName string, helpText ...string) {
+	value := NewValueInt64Slice(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagInt64Slice(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagInt64Slice(value, longName, helpText...), shortName)
+	}
+}
+
+// IPSliceVar registers [netip.Addr] slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flags appends one or more comma-separated addresses.
+func (fs *FlagSet) IPSliceVar(vp *[]netip.Addr, shortName rune, longName string, helpText ...string) {
+	value := NewValueIPSlice(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagIPSlice(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagIPSlice(value, longName, helpText...), shortName)
+	}
+}
+
+// JSONVar registers flags whose values are JSON documents decoded into
+// the value pointed to by vp using GNU conventions. For example:
+//
+//	var filter struct {
+//		Status string `json:"status"`
+//	}
+//	fs.JSONVar(&filter, 0, "filter", "Filter the results.")
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// This method panics if vp is not a non-nil pointer.
+func (fs *FlagSet) JSONVar(vp any, shortName rune, longName string, helpText ...string) {
+	value := NewValueJSON(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagJSON(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagJSON(value, longName, helpText...), shortName)
+	}
+}
+
+// LogLevelVar registers [slog.Level] flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) LogLevelVar(vp *slog.Level, shortName rune, longName string, helpText ...string) {
+	value := NewValueLogLevel(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagLogLevel(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagInt64(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagLogLevel(value, longName, helpText...), shortName)
+	}
+}
+
+// NetipAddrVar registers [netip.Addr] flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) NetipAddrVar(vp *netip.Addr, shortName rune, longName string, helpText ...string) {
+	value := NewValueNetipAddr(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagNetipAddr(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagNetipAddr(value, longName, helpText...), shortName)
+	}
+}
+
+// optionalVar registers flags bound to a [ValueOptional] wrapping the given value
+// using the given constructors, such that the flags otherwise behave like the
+// flags registered by the corresponding [*FlagSet] method (e.g., [*FlagSet.IntVar]).
+func optionalVar[T any, V Value](
+	fs *FlagSet,
+	op *Optional[T],
+	value V,
+	newShort func(V, rune, ...string) *ShortFlag,
+	newLong func(V, string, ...string) *LongFlag,
+	shortName rune,
+	longName string,
+	helpText ...string,
+) {
+	optional := NewValueOptional(op, value)
+	if shortName != 0 {
+		fx := newShort(value, shortName, helpText...)
+		fx.Value = optional
+		fs.AddShortFlag(fx)
+	}
+	if longName != "" {
+		fx := newLong(value, longName, helpText...)
+		fx.Value = optional
+		fs.addLongFlag(fx, shortName)
+	}
+}
+
+// OptionalBoolVar registers optional boolean flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.BoolVar].
+func (fs *FlagSet) OptionalBoolVar(op *Optional[bool], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueBool(&op.Value), NewShortFlagBool, NewLongFlagBool, shortName, longName, helpText...)
+}
+
+// OptionalDurationVar registers optional [time.Duration] flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.DurationVar].
+func (fs *FlagSet) OptionalDurationVar(op *Optional[time.Duration], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueDuration(&op.Value), NewShortFlagDuration, NewLongFlagDuration, shortName, longName, helpText...)
+}
+
+// OptionalFloat64Var registers optional float64 flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.Float64Var].
+func (fs *FlagSet) OptionalFloat64Var(op *Optional[float64], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueFloat64(&op.Value), NewShortFlagFloat64, NewLongFlagFloat64, shortName, longName, helpText...)
+}
+
+// OptionalIntVar registers optional int flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.IntVar].
+func (fs *FlagSet) OptionalIntVar(op *Optional[int], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueInt(&op.Value), NewShortFlagInt, NewLongFlagInt, shortName, longName, helpText...)
+}
+
+// OptionalInt64Var registers optional int64 flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.Int64Var].
+func (fs *FlagSet) OptionalInt64Var(op *Optional[int64], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueInt64(&op.Value), NewShortFlagInt64, NewLongFlagInt64, shortName, longName, helpText...)
+}
+
+// OptionalStringVar registers optional string flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.StringVar].
+func (fs *FlagSet) OptionalStringVar(op *Optional[string], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueString(&op.Value), NewShortFlagString, NewLongFlagString, shortName, longName, helpText...)
+}
+
+// OptionalUintVar registers optional uint flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.UintVar].
+func (fs *FlagSet) OptionalUintVar(op *Optional[uint], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueUint(&op.Value), NewShortFlagUint, NewLongFlagUint, shortName, longName, helpText...)
+}
+
+// OptionalUint64Var registers optional uint64 flags using GNU conventions.
+//
+// Use [Optional.IsSet] to know whether the flags were set. Otherwise,
+// the flags behave like the ones registered by [*FlagSet.Uint64Var].
+func (fs *FlagSet) OptionalUint64Var(op *Optional[uint64], shortName rune, longName string, helpText ...string) {
+	optionalVar(fs, op, NewValueUint64(&op.Value), NewShortFlagUint64, NewLongFlagUint64, shortName, longName, helpText...)
+}
+
+// PercentVar registers percentage flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// The flags accept either a percentage (e.g., `85%`) or a fraction
+// (e.g., `0.85`) and store the corresponding fraction in [0, 1].
+func (fs *FlagSet) PercentVar(vp *float64, shortName rune, longName string, helpText ...string) {
+	value := NewValuePercent(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagPercent(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagPercent(value, longName, helpText...), shortName)
+	}
+}
+
+// RuneVar registers single-character flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Note that [*FlagSet.BindStruct] binds rune fields as int32 flags
+// since rune is an alias for int32.
+func (fs *FlagSet) RuneVar(vp *rune, shortName rune, longName string, helpText ...string) {
+	value := NewValueRune(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagRune(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagRune(value, longName, helpText...), shortName)
+	}
+}
+
+// SecretStringVar registers secret string flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// The default value is never printed in the help. Pass `-` as the value
+// to read the secret from the terminal without echo, which is only
+// supported on Unix systems (see [ValueSecretString]).
+func (fs *FlagSet) SecretStringVar(vp *SecretString, shortName rune, longName string, helpText ...string) {
+	value := NewValueSecretString(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagSecretString(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagSecretString(value, longName, helpText...), shortName)
 	}
 }
 
@@ -138,27 +715,58 @@ func (fs *FlagSet) Int64Var(vp *int64, shortName byte, longName string, helpText
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) StringVar(vp *string, shortName byte, longName string, helpText ...string) {
+//
+// The returned [*StringFlag] allows to constrain the accepted values.
+func (fs *FlagSet) StringVar(vp *string, shortName rune, longName string, helpText ...string) *StringFlag {
 	value := NewValueString(vp)
+	sf := &StringFlag{}
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagString(value, shortName, helpText...))
+		sf.ShortFlag = NewShortFlagString(value, shortName, helpText...)
+		fs.AddShortFlag(sf.ShortFlag)
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagString(value, longName, helpText...))
+		sf.LongFlag = NewLongFlagString(value, longName, helpText...)
+		fs.addLongFlag(sf.LongFlag, shortName)
 	}
+	return sf
+}
+
+// String is like [*FlagSet.StringVar] but allocates a string initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) String(shortName rune, longName string, value string, helpText ...string) *string {
+	vp := new(string)
+	*vp = value
+	fs.StringVar(vp, shortName, longName, helpText...)
+	return vp
 }
 
 // StringSliceVar registers string slice flags using GNU conventions.
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) StringSliceVar(vp *[]string, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) StringSliceVar(vp *[]string, shortName rune, longName string, helpText ...string) {
 	value := NewValueStringSlice(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagStringSlice(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagStringSlice(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagStringSlice(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagStringSlice(value, longName, helpText...), shortName)
+	}
+}
+
+// StringToStringVar registers string-to-string map flags using GNU conventions.
+//
+// If shortName is not zero, a short flag (e.g., `-D`) is added to ShortFlags.
+// If longName is not empty, a long flag (e.g., `--define`) is added to LongFlags.
+//
+// Each occurrence of the flag takes a `KEY=VALUE` argument and adds it to the map.
+func (fs *FlagSet) StringToStringVar(vp *map[string]string, shortName rune, longName string, helpText ...string) {
+	value := NewValueStringToString(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagStringToString(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagStringToString(value, longName, helpText...), shortName)
 	}
 }
 
@@ -166,13 +774,38 @@ func (fs *FlagSet) StringSliceVar(vp *[]string, shortName byte, longName string,
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) UintVar(vp *uint, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) UintVar(vp *uint, shortName rune, longName string, helpText ...string) {
 	value := NewValueUint(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagUint(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagUint(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagUint(value, longName, helpText...), shortName)
+	}
+}
+
+// Uint is like [*FlagSet.UintVar] but allocates a uint initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Uint(shortName rune, longName string, value uint, helpText ...string) *uint {
+	vp := new(uint)
+	*vp = value
+	fs.UintVar(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// UintSliceVar registers uint slice flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+//
+// Each occurrence of the flag appends a value to the slice.
+func (fs *FlagSet) UintSliceVar(vp *[]uint, shortName rune, longName string, helpText ...string) {
+	value := NewValueUintSlice(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagUintSlice(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagUint(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagUintSlice(value, longName, helpText...), shortName)
 	}
 }
 
@@ -180,13 +813,13 @@ func (fs *FlagSet) UintVar(vp *uint, shortName byte, longName string, helpText .
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Uint8Var(vp *uint8, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Uint8Var(vp *uint8, shortName rune, longName string, helpText ...string) {
 	value := NewValueUint8(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagUint8(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagUint8(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagUint8(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagUint8(value, longName, helpText...), shortName)
 	}
 }
 
@@ -194,13 +827,13 @@ func (fs *FlagSet) Uint8Var(vp *uint8, shortName byte, longName string, helpText
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Uint16Var(vp *uint16, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Uint16Var(vp *uint16, shortName rune, longName string, helpText ...string) {
 	value := NewValueUint16(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagUint16(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagUint16(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagUint16(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagUint16(value, longName, helpText...), shortName)
 	}
 }
 
@@ -208,13 +841,13 @@ func (fs *FlagSet) Uint16Var(vp *uint16, shortName byte, longName string, helpTe
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Uint32Var(vp *uint32, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Uint32Var(vp *uint32, shortName rune, longName string, helpText ...string) {
 	value := NewValueUint32(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagUint32(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagUint32(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagUint32(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagUint32(value, longName, helpText...), shortName)
 	}
 }
 
@@ -222,12 +855,35 @@ func (fs *FlagSet) Uint32Var(vp *uint32, shortName byte, longName string, helpTe
 //
 // If shortName is not zero, a short flag is added to ShortFlags.
 // If longName is not empty, a long flag is added to LongFlags.
-func (fs *FlagSet) Uint64Var(vp *uint64, shortName byte, longName string, helpText ...string) {
+func (fs *FlagSet) Uint64Var(vp *uint64, shortName rune, longName string, helpText ...string) {
 	value := NewValueUint64(vp)
 	if shortName != 0 {
-		fs.ShortFlags = append(fs.ShortFlags, NewShortFlagUint64(value, shortName, helpText...))
+		fs.AddShortFlag(NewShortFlagUint64(value, shortName, helpText...))
+	}
+	if longName != "" {
+		fs.addLongFlag(NewLongFlagUint64(value, longName, helpText...), shortName)
+	}
+}
+
+// Uint64 is like [*FlagSet.Uint64Var] but allocates a uint64 initialized
+// to the given value and returns a pointer to it.
+func (fs *FlagSet) Uint64(shortName rune, longName string, value uint64, helpText ...string) *uint64 {
+	vp := new(uint64)
+	*vp = value
+	fs.Uint64Var(vp, shortName, longName, helpText...)
+	return vp
+}
+
+// UUIDVar registers UUID flags using GNU conventions.
+//
+// If shortName is not zero, a short flag is added to ShortFlags.
+// If longName is not empty, a long flag is added to LongFlags.
+func (fs *FlagSet) UUIDVar(vp *string, shortName rune, longName string, helpText ...string) {
+	value := NewValueUUID(vp)
+	if shortName != 0 {
+		fs.AddShortFlag(NewShortFlagUUID(value, shortName, helpText...))
 	}
 	if longName != "" {
-		fs.LongFlags = append(fs.LongFlags, NewLongFlagUint64(value, longName, helpText...))
+		fs.addLongFlag(NewLongFlagUUID(value, longName, helpText...), shortName)
 	}
 }
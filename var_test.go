@@ -3,6 +3,13 @@
 package vflag
 
 import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,7 +27,7 @@ func TestFlagSetVarAutoHelp(t *testing.T) {
 
 		// Verify short flag
 		short := fs.ShortFlags[0]
-		assert.Equal(t, byte('h'), short.Name)
+		assert.Equal(t, 'h', short.Name)
 		assert.Equal(t, "-", short.Prefix)
 		assert.Equal(t, []string{"Print help and exit."}, short.Description)
 		_, ok := short.Value.(ValueAutoHelp)
@@ -52,6 +59,84 @@ func TestFlagSetVarAutoHelp(t *testing.T) {
 	})
 }
 
+func TestFlagSetPointerMethods(t *testing.T) {
+	fs := NewFlagSet("prog", ContinueOnError)
+	verbose := fs.Bool('v', "verbose", false, "Enable verbose output.")
+	timeout := fs.Duration('t', "timeout", 5*time.Second, "Set the timeout.")
+	ratio := fs.Float64(0, "ratio", 0.5, "Set the ratio.")
+	count := fs.Int('n', "count", 1, "Set count.")
+	size := fs.Int64(0, "size", -1, "Set size.")
+	output := fs.String('o', "output", "-", "Write output to file.")
+	workers := fs.Uint(0, "workers", 4, "Set workers.")
+	limit := fs.Uint64(0, "limit", 100, "Set limit.")
+
+	require.Len(t, fs.ShortFlags, 4)
+	require.Len(t, fs.LongFlags, 8)
+
+	// Verify the defaults
+	assert.False(t, *verbose)
+	assert.Equal(t, 5*time.Second, *timeout)
+	assert.Equal(t, 0.5, *ratio)
+	assert.Equal(t, 1, *count)
+	assert.Equal(t, int64(-1), *size)
+	assert.Equal(t, "-", *output)
+	assert.Equal(t, uint(4), *workers)
+	assert.Equal(t, uint64(100), *limit)
+
+	// Verify that parsing sets the values
+	require.NoError(t, fs.Parse([]string{
+		"-v", "-t", "1s", "--ratio", "0.25", "-n", "7", "--size", "1024",
+		"-o", "out.txt", "--workers", "8", "--limit", "0",
+	}))
+	assert.True(t, *verbose)
+	assert.Equal(t, time.Second, *timeout)
+	assert.Equal(t, 0.25, *ratio)
+	assert.Equal(t, 7, *count)
+	assert.Equal(t, int64(1024), *size)
+	assert.Equal(t, "out.txt", *output)
+	assert.Equal(t, uint(8), *workers)
+	assert.Equal(t, uint64(0), *limit)
+}
+
+func TestFlagSetVarBigFloat(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := new(big.Float).SetPrec(128)
+		fs.BigFloatVar(value, 'a', "amount", "Set the amount.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " BIGFLOAT", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " BIGFLOAT", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"--amount", "12345678901234567890.125"}))
+		assert.Equal(t, "1.2345678901234567890125e+19", fs.ShortFlags[0].Value.String())
+	})
+}
+
+func TestFlagSetVarBigInt(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value big.Int
+		fs.BigIntVar(&value, 'e', "exponent", "Set the exponent.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " BIGINT", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " BIGINT", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"-e", "65537000000000000000000"}))
+		assert.Equal(t, "65537000000000000000000", fs.LongFlags[0].Value.String())
+		assert.Equal(t, "65537000000000000000000", value.String())
+	})
+}
+
 func TestFlagSetVarBool(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -63,7 +148,7 @@ func TestFlagSetVarBool(t *testing.T) {
 
 		// Verify short flag
 		short := fs.ShortFlags[0]
-		assert.Equal(t, byte('v'), short.Name)
+		assert.Equal(t, 'v', short.Name)
 		assert.Equal(t, "-", short.Prefix)
 		assert.Equal(t, "", short.ArgumentName)
 
@@ -98,6 +183,158 @@ func TestFlagSetVarBool(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarNegatableBool(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := true
+		fs.NegatableBoolVar(&value, 'c', "color", "Colorize the output.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+		assert.True(t, fs.LongFlags[0].Negatable)
+
+		require.NoError(t, fs.Parse([]string{"--no-color"}))
+		assert.False(t, value)
+	})
+
+	t.Run("short only", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value bool
+		fs.NegatableBoolVar(&value, 'c', "", "Colorize the output.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 0)
+	})
+}
+
+func TestFlagSetBoolFunc(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var values []string
+		fs.BoolFunc('t', "trace", "Enable tracing.", func(value string) error {
+			values = append(values, value)
+			return nil
+		})
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+		assert.Equal(t, []string{"Enable tracing."}, fs.LongFlags[0].Description)
+
+		// Verify that the function runs once per occurrence
+		require.NoError(t, fs.Parse([]string{"-t", "--trace", "--trace=false"}))
+		assert.Equal(t, []string{"true", "true", "false"}, values)
+	})
+
+	t.Run("errors are reported by Parse", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		fs.BoolFunc(0, "trace", "Enable tracing.", func(value string) error {
+			return errors.New("tracing not available")
+		})
+
+		err := fs.Parse([]string{"--trace"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "tracing not available")
+	})
+}
+
+func TestFlagSetVarBoolPtr(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		expect *bool
+	}{
+		{"not given", []string{}, nil},
+		{"short", []string{"-f"}, ptrTo(true)},
+		{"long", []string{"--feature"}, ptrTo(true)},
+		{"long true", []string{"--feature=true"}, ptrTo(true)},
+		{"long false", []string{"--feature=false"}, ptrTo(false)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := NewFlagSet("prog", ContinueOnError)
+			var value *bool
+			fs.BoolPtrVar(&value, 'f', "feature", "Enable the feature.")
+
+			require.Len(t, fs.ShortFlags, 1)
+			require.Len(t, fs.LongFlags, 1)
+
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.expect, value)
+		})
+	}
+}
+
+// ptrTo returns a pointer to the given value.
+func ptrTo[T any](value T) *T {
+	return &value
+}
+
+func TestFlagSetVarBytesBase64(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []byte
+		fs.BytesBase64Var(&value, 'k', "key", "Set the key.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " BASE64", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " BASE64", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"-k", "dmZsYWc="}))
+		assert.Equal(t, "dmZsYWc=", fs.LongFlags[0].Value.String())
+		assert.Equal(t, []byte("vflag"), value)
+
+		// Verify that parsing validates the encoding
+		assert.Error(t, fs.Parse([]string{"--key", "%%%"}))
+	})
+}
+
+func TestFlagSetVarBytesHex(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []byte
+		fs.BytesHexVar(&value, 'k', "key", "Set the key.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " HEX", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " HEX", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"-k", "76666c6167"}))
+		assert.Equal(t, "76666c6167", fs.LongFlags[0].Value.String())
+		assert.Equal(t, []byte("vflag"), value)
+
+		// Verify that parsing validates the encoding
+		assert.Error(t, fs.Parse([]string{"--key", "xyz"}))
+	})
+}
+
+func TestFlagSetVarCount(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value int
+		fs.CountVar(&value, 'v', "verbose", "Increase verbosity.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, "", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, "[=INT]", fs.LongFlags[0].ArgumentName)
+
+		// Verify that each occurrence increments the value
+		require.NoError(t, fs.Parse([]string{"-vvv", "--verbose"}))
+		assert.Equal(t, 4, value)
+	})
+}
+
 func TestFlagSetVarDuration(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -118,6 +355,91 @@ func TestFlagSetVarDuration(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarDurationSlice(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []time.Duration
+		fs.DurationSliceVar(&value, 'r', "retry-after", "Retry after the given delay.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " DURATION", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " DURATION", fs.LongFlags[0].ArgumentName)
+
+		// Verify that repeated flags accumulate
+		require.NoError(t, fs.Parse([]string{"--retry-after", "1s", "--retry-after", "5s", "-r", "30s"}))
+		assert.Equal(t, []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}, value)
+	})
+}
+
+func TestFlagSetVarEnum(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := "table"
+		fs.EnumVar(&value, []string{"json", "yaml", "table"}, 'f', "format", "Set output format.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " json|yaml|table", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " json|yaml|table", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.ShortFlags[0].Value.Set("json"))
+		assert.Equal(t, "json", fs.LongFlags[0].Value.String())
+		assert.Equal(t, "json", value)
+
+		// Verify that parsing rejects values not in choices
+		err := fs.Parse([]string{"--format", "xml"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be one of: json, yaml, table")
+	})
+}
+
+func TestFlagSetVarFileMode(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := os.FileMode(0755)
+		fs.FileModeVar(&value, 'm', "mode", "Set the file mode (default: @DEFAULT_VALUE@).")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " MODE", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " MODE", fs.LongFlags[0].ArgumentName)
+		assert.Equal(t, "0755", fs.LongFlags[0].Value.String())
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"-m", "0644"}))
+		assert.Equal(t, "0644", fs.LongFlags[0].Value.String())
+		assert.Equal(t, os.FileMode(0644), value)
+	})
+}
+
+func TestFlagSetVarFloat32(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value float32
+		fs.Float32Var(&value, 'l', "learning-rate", "Set learning rate.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " FLOAT32", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " FLOAT32", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.ShortFlags[0].Value.Set("0.001"))
+		assert.Equal(t, "0.001", fs.LongFlags[0].Value.String())
+		assert.Equal(t, float32(0.001), value)
+	})
+}
+
 func TestFlagSetVarFloat64(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -138,6 +460,77 @@ func TestFlagSetVarFloat64(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarFloat64Slice(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []float64
+		fs.Float64SliceVar(&value, 'r', "ratio", "Add ratio.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " FLOAT64", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " FLOAT64", fs.LongFlags[0].ArgumentName)
+
+		// Verify that repeated flags accumulate
+		require.NoError(t, fs.Parse([]string{"-r", "1.5", "--ratio", "2"}))
+		assert.Equal(t, []float64{1.5, 2}, value)
+	})
+}
+
+func TestFlagSetFunc(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var headers []string
+		fs.Func('H', "header", "Add a header.", func(value string) error {
+			if !strings.Contains(value, ":") {
+				return errors.New("expected NAME: VALUE")
+			}
+			headers = append(headers, value)
+			return nil
+		})
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names and help text
+		assert.Equal(t, " VALUE", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " VALUE", fs.LongFlags[0].ArgumentName)
+		assert.Equal(t, []string{"Add a header."}, fs.LongFlags[0].Description)
+
+		// Verify that the function runs once per occurrence
+		require.NoError(t, fs.Parse([]string{"-H", "A: 1", "--header", "B: 2"}))
+		assert.Equal(t, []string{"A: 1", "B: 2"}, headers)
+
+		// Verify that errors are reported by Parse
+		err := fs.Parse([]string{"--header", "invalid"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected NAME: VALUE")
+	})
+}
+
+func TestFlagSetVarGlob(t *testing.T) {
+	t.Run("long only", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []string
+		fs.GlobVar(&value, 0, "include", "Include files matching PATTERN.")
+
+		require.Len(t, fs.ShortFlags, 0)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument name
+		assert.Equal(t, " PATTERN", fs.LongFlags[0].ArgumentName)
+
+		// Verify that the flag accumulates values
+		require.NoError(t, fs.Parse([]string{"--include", "*.go", "--include", "*.md"}))
+		assert.Equal(t, []string{"*.go", "*.md"}, value)
+
+		// Verify that parsing validates the pattern
+		assert.Error(t, fs.Parse([]string{"--include", "[]"}))
+	})
+}
+
 func TestFlagSetVarInt(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -158,6 +551,25 @@ func TestFlagSetVarInt(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarIntSlice(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []int
+		fs.IntSliceVar(&value, 'n', "number", "Add number.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " INT", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " INT", fs.LongFlags[0].ArgumentName)
+
+		// Verify that repeated flags accumulate
+		require.NoError(t, fs.Parse([]string{"-n", "1", "--number", "-2"}))
+		assert.Equal(t, []int{1, -2}, value)
+	})
+}
+
 func TestFlagSetVarInt8(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -238,6 +650,242 @@ func TestFlagSetVarInt64(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarInt64Slice(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []int64
+		fs.Int64SliceVar(&value, 's', "size", "Add size.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " INT64", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " INT64", fs.LongFlags[0].ArgumentName)
+
+		// Verify that repeated flags accumulate
+		require.NoError(t, fs.Parse([]string{"-s", "1", "--size", "-2"}))
+		assert.Equal(t, []int64{1, -2}, value)
+	})
+}
+
+func TestFlagSetVarIPSlice(t *testing.T) {
+	t.Run("long only", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []netip.Addr
+		fs.IPSliceVar(&value, 0, "dns-server", "Use the given DNS servers.")
+
+		require.Len(t, fs.ShortFlags, 0)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument name
+		assert.Equal(t, " ADDR[,ADDR...]", fs.LongFlags[0].ArgumentName)
+
+		// Verify that the flag accumulates repeated and comma-separated values
+		require.NoError(t, fs.Parse([]string{"--dns-server", "8.8.8.8,8.8.4.4", "--dns-server", "::1"}))
+		assert.Equal(t, "8.8.8.8,8.8.4.4,::1", fs.LongFlags[0].Value.String())
+	})
+}
+
+func TestFlagSetVarJSON(t *testing.T) {
+	t.Run("long only", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value struct {
+			Status string `json:"status"`
+		}
+		fs.JSONVar(&value, 0, "filter", "Filter the results.")
+
+		require.Len(t, fs.ShortFlags, 0)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument name
+		assert.Equal(t, " JSON", fs.LongFlags[0].ArgumentName)
+
+		// Verify that parsing decodes the value
+		require.NoError(t, fs.Parse([]string{"--filter", `{"status":"active"}`}))
+		assert.Equal(t, "active", value.Status)
+
+		// Verify that parsing validates the value
+		assert.Error(t, fs.Parse([]string{"--filter", `{"status":1}`}))
+	})
+}
+
+func TestFlagSetVarLogLevel(t *testing.T) {
+	t.Run("long only", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := slog.LevelWarn
+		fs.LogLevelVar(&value, 0, "log-level", "Set the log level (default: @DEFAULT_VALUE@).")
+
+		require.Len(t, fs.ShortFlags, 0)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument name and default value
+		assert.Equal(t, " LEVEL", fs.LongFlags[0].ArgumentName)
+		assert.Equal(t, "warn", fs.LongFlags[0].Value.String())
+
+		// Verify that parsing sets the value
+		require.NoError(t, fs.Parse([]string{"--log-level", "debug"}))
+		assert.Equal(t, slog.LevelDebug, value)
+	})
+}
+
+func TestFlagSetVarNetipAddr(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value netip.Addr
+		fs.NetipAddrVar(&value, 'b', "bind", "Bind to the given address.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " ADDR", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " ADDR", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.ShortFlags[0].Value.Set("10.0.0.1"))
+		assert.Equal(t, "10.0.0.1", fs.LongFlags[0].Value.String())
+		assert.Equal(t, netip.MustParseAddr("10.0.0.1"), value)
+
+		// Verify that parsing validates the address
+		assert.Error(t, fs.Parse([]string{"--bind", "example.com"}))
+	})
+}
+
+func TestFlagSetVarOptional(t *testing.T) {
+	t.Run("distinguishes zero from unset", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var (
+			retries Optional[int]
+			timeout Optional[time.Duration]
+		)
+		retries.Value = 3
+		fs.OptionalIntVar(&retries, 'r', "retries", "Set the number of retries.")
+		fs.OptionalDurationVar(&timeout, 't', "timeout", "Set the timeout.")
+
+		require.Len(t, fs.ShortFlags, 2)
+		require.Len(t, fs.LongFlags, 2)
+		assert.Equal(t, " INT", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " DURATION", fs.LongFlags[1].ArgumentName)
+
+		require.NoError(t, fs.Parse([]string{"-r", "0"}))
+		assert.True(t, retries.IsSet())
+		assert.Equal(t, 0, retries.Value)
+		assert.False(t, timeout.IsSet())
+		assert.Equal(t, time.Duration(0), timeout.Value)
+	})
+
+	t.Run("bool flags do not require a value", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var (
+			cache   Optional[bool]
+			verbose Optional[bool]
+		)
+		fs.OptionalBoolVar(&cache, 0, "cache", "Enable caching.")
+		fs.OptionalBoolVar(&verbose, 'v', "verbose", "Enable verbose output.")
+
+		require.NoError(t, fs.Parse([]string{"-v", "--cache=false"}))
+		assert.True(t, verbose.IsSet())
+		assert.True(t, verbose.Value)
+		assert.True(t, cache.IsSet())
+		assert.False(t, cache.Value)
+	})
+
+	t.Run("other types", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var (
+			f64 Optional[float64]
+			i64 Optional[int64]
+			s   Optional[string]
+			u   Optional[uint]
+			u64 Optional[uint64]
+		)
+		fs.OptionalFloat64Var(&f64, 0, "f64")
+		fs.OptionalInt64Var(&i64, 0, "i64")
+		fs.OptionalStringVar(&s, 0, "s")
+		fs.OptionalUintVar(&u, 0, "u")
+		fs.OptionalUint64Var(&u64, 0, "u64")
+
+		require.NoError(t, fs.Parse([]string{"--f64", "0", "--s", "", "--u64", "0"}))
+		assert.True(t, f64.IsSet())
+		assert.False(t, i64.IsSet())
+		assert.True(t, s.IsSet())
+		assert.False(t, u.IsSet())
+		assert.True(t, u64.IsSet())
+	})
+}
+
+func TestFlagSetVarPercent(t *testing.T) {
+	t.Run("long only", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := 0.9
+		fs.PercentVar(&value, 0, "threshold", "Set the threshold (default: @DEFAULT_VALUE@).")
+
+		require.Len(t, fs.ShortFlags, 0)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument name and default value
+		assert.Equal(t, " PERCENT", fs.LongFlags[0].ArgumentName)
+		assert.Equal(t, "90%", fs.LongFlags[0].Value.String())
+
+		// Verify that parsing normalizes the value
+		require.NoError(t, fs.Parse([]string{"--threshold", "85%"}))
+		assert.Equal(t, 0.85, value)
+
+		// Verify that parsing validates the range
+		assert.Error(t, fs.Parse([]string{"--threshold", "150%"}))
+	})
+}
+
+func TestFlagSetVarRune(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := '\t'
+		fs.RuneVar(&value, 'd', "delimiter", "Use the given delimiter (default: @DEFAULT_VALUE@).")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " CHAR", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " CHAR", fs.LongFlags[0].ArgumentName)
+		assert.Equal(t, `\t`, fs.LongFlags[0].Value.String())
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"-d", ":"}))
+		assert.Equal(t, ":", fs.LongFlags[0].Value.String())
+		assert.Equal(t, ':', value)
+
+		// Verify that multi-character values are rejected
+		assert.Error(t, fs.Parse([]string{"--delimiter", "::"}))
+	})
+}
+
+func TestFlagSetVarSecretString(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		value := SecretString{"default-secret"}
+		fs.SecretStringVar(&value, 'p', "password", "Use the given password.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " SECRET", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " SECRET", fs.LongFlags[0].ArgumentName)
+
+		// Verify that the default value is never printed
+		var buf bytes.Buffer
+		fs.SetOutput(&buf)
+		fs.PrintDefaults()
+		assert.NotContains(t, buf.String(), "default-secret")
+
+		// Verify parsing
+		require.NoError(t, fs.Parse([]string{"-p", "hunter2"}))
+		assert.Equal(t, "hunter2", value.Reveal())
+	})
+}
+
 func TestFlagSetVarString(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -277,6 +925,25 @@ func TestFlagSetVarStringSlice(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarStringToString(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value map[string]string
+		fs.StringToStringVar(&value, 'D', "define", "Define a macro.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " KEY=VALUE", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " KEY=VALUE", fs.LongFlags[0].ArgumentName)
+
+		// Verify that repeated flags accumulate
+		require.NoError(t, fs.Parse([]string{"-D", "DEBUG=1", "--define", "NAME=vflag"}))
+		assert.Equal(t, map[string]string{"DEBUG": "1", "NAME": "vflag"}, value)
+	})
+}
+
 func TestFlagSetVarUint(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -297,6 +964,25 @@ func TestFlagSetVarUint(t *testing.T) {
 	})
 }
 
+func TestFlagSetVarUintSlice(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value []uint
+		fs.UintSliceVar(&value, 'u', "user", "Add user.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " UINT", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " UINT", fs.LongFlags[0].ArgumentName)
+
+		// Verify that repeated flags accumulate
+		require.NoError(t, fs.Parse([]string{"-u", "1", "--user", "2"}))
+		assert.Equal(t, []uint{1, 2}, value)
+	})
+}
+
 func TestFlagSetVarUint8(t *testing.T) {
 	t.Run("both short and long", func(t *testing.T) {
 		fs := NewFlagSet("prog", ContinueOnError)
@@ -376,3 +1062,26 @@ func TestFlagSetVarUint64(t *testing.T) {
 		assert.Equal(t, uint64(999999), value)
 	})
 }
+
+func TestFlagSetVarUUID(t *testing.T) {
+	t.Run("both short and long", func(t *testing.T) {
+		fs := NewFlagSet("prog", ContinueOnError)
+		var value string
+		fs.UUIDVar(&value, 'u', "id", "Select the resource.")
+
+		require.Len(t, fs.ShortFlags, 1)
+		require.Len(t, fs.LongFlags, 1)
+
+		// Verify argument names
+		assert.Equal(t, " UUID", fs.ShortFlags[0].ArgumentName)
+		assert.Equal(t, " UUID", fs.LongFlags[0].ArgumentName)
+
+		// Verify shared value by setting one and checking the other
+		require.NoError(t, fs.Parse([]string{"-u", "123E4567-E89B-12D3-A456-426614174000"}))
+		assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", fs.LongFlags[0].Value.String())
+		assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", value)
+
+		// Verify that parsing validates the UUID
+		assert.Error(t, fs.Parse([]string{"--id", "not-a-uuid"}))
+	})
+}
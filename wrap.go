@@ -0,0 +1,114 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wrapText word wraps text at the given column using the given indentation,
+// measuring words using [displayWidth] rather than their length in bytes,
+// such that ANSI escape sequences and wide characters wrap correctly.
+//
+// The width includes the indentation and we never break words, so a word
+// wider than the width gets its own line.
+func wrapText(text string, width int, indent string) string {
+	words := strings.Fields(text)
+	if len(words) <= 0 {
+		return ""
+	}
+
+	var lines []string
+	current := indent + words[0]
+	size := displayWidth(current)
+
+	for _, word := range words[1:] {
+		if wsize := displayWidth(word); size+1+wsize <= width {
+			current += " " + word
+			size += 1 + wsize
+			continue
+		}
+		lines = append(lines, current)
+		current = indent + word
+		size = displayWidth(current)
+	}
+	lines = append(lines, current)
+
+	return strings.Join(lines, "\n")
+}
+
+// displayWidth returns the number of terminal columns used by value, where ANSI
+// escape sequences take no space, East Asian wide characters take two columns,
+// and combining marks take no space.
+func displayWidth(value string) (width int) {
+	for len(value) > 0 {
+		if skip := ansiEscapeLen(value); skip > 0 {
+			value = value[skip:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(value)
+		value = value[size:]
+		width += runeWidth(r)
+	}
+	return
+}
+
+// ansiEscapeLen returns the length of the ANSI control sequence (e.g., `\x1b[1m`)
+// at the beginning of value, or zero if value does not start with such a sequence.
+func ansiEscapeLen(value string) int {
+	if !strings.HasPrefix(value, "\x1b[") {
+		return 0
+	}
+	for idx := 2; idx < len(value); idx++ {
+		if value[idx] >= 0x40 && value[idx] <= 0x7e {
+			return idx + 1
+		}
+	}
+	return len(value)
+}
+
+// runeWidth returns the number of terminal columns used by r.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.IsControl(r) || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || r == '\u200b':
+		return 0
+	case isWideRune(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// wideRuneRanges contains the main ranges of East Asian wide and fullwidth
+// characters (CJK, Hangul, fullwidth forms, and emoji) that take two columns.
+var wideRuneRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115f},
+	{0x2e80, 0x303e},
+	{0x3041, 0x33ff},
+	{0x3400, 0x4dbf},
+	{0x4e00, 0x9fff},
+	{0xa000, 0xa4cf},
+	{0xac00, 0xd7a3},
+	{0xf900, 0xfaff},
+	{0xfe30, 0xfe4f},
+	{0xff00, 0xff60},
+	{0xffe0, 0xffe6},
+	{0x1f300, 0x1f64f},
+	{0x1f900, 0x1f9ff},
+	{0x20000, 0x2fffd},
+	{0x30000, 0x3fffd},
+}
+
+// isWideRune returns whether r is an East Asian wide or fullwidth character.
+func isWideRune(r rune) bool {
+	for _, rr := range wideRuneRanges {
+		if r >= rr.lo && r <= rr.hi {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+
+package vflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 5, displayWidth("hello"))
+	assert.Equal(t, 5, displayWidth("\x1b[1mhello\x1b[0m"))
+	assert.Equal(t, 4, displayWidth("日本"))
+	assert.Equal(t, 4, displayWidth("café"))
+	assert.Equal(t, 4, displayWidth("cafe\u0301"))
+	assert.Equal(t, 0, displayWidth("\x1b[4"))
+}
+
+func TestWrapText(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		assert.Equal(t, "  aaa bbb\n  ccc", wrapText("aaa bbb ccc", 10, "  "))
+		assert.Equal(t, "", wrapText("   ", 10, "  "))
+	})
+
+	t.Run("ANSI escapes take no space", func(t *testing.T) {
+		bold := "\x1b[1maaa\x1b[0m"
+		assert.Equal(t, "  "+bold+" bbb\n  ccc", wrapText(bold+" bbb ccc", 10, "  "))
+	})
+
+	t.Run("wide characters take two columns", func(t *testing.T) {
+		assert.Equal(t, "  日本 語\n  中文", wrapText("日本 語 中文", 10, "  "))
+	})
+
+	t.Run("long words get their own line", func(t *testing.T) {
+		assert.Equal(t, "a\nbbbbbbbbbbbb\nc", wrapText("a bbbbbbbbbbbb c", 10, ""))
+	})
+}